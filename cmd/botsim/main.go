@@ -0,0 +1,121 @@
+// Command botsim plays bots against each other headless, as fast as it
+// can, and reports their win rates and any crash, deadlock or chip error
+// it found in the engine along the way. A failure is reported with its
+// session's seed and hand: -seed <seed> -tables 1 -hands <hand> plays it
+// again.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"poker-room/internal/cfr"
+	"poker-room/internal/game"
+	"poker-room/internal/sim"
+)
+
+func main() {
+	bots := flag.String("bots", "tightAggressive,loosePassive,maniac,callingStation,equity,random",
+		"comma-separated bots to seat: a rule bot style, equity, random or cfr:<strategy file>")
+	hands := flag.Int("hands", 100000, "hands to play")
+	seed := flag.Int64("seed", 1, "seed for the first table")
+	tables := flag.Int("tables", 0, "tables to play at once (default the number of CPUs)")
+	smallBlind := flag.Int("sb", 1, "small blind")
+	bigBlind := flag.Int("bb", 2, "big blind")
+	stack := flag.Int("stack", 0, "starting stack and rebuy (default 100 big blinds)")
+	buckets := flag.Int("buckets", cfr.DefaultBuckets, "hand strength buckets the cfr strategies were trained with")
+	maxBets := flag.Int("maxbets", cfr.DefaultMaxBets, "bets a street the cfr strategies were trained with")
+	stall := flag.Duration("stall", 10*time.Second, "time without progress before a table counts as deadlocked")
+	equity := flag.Bool("equity", false, "calculate equity at all-ins as real tables do (slow)")
+	samples := flag.Int("samples", game.DefaultEquitySamples, "deals the equity bot simulates per decision; fewer play faster")
+	rake := flag.Float64("rake", 0, "percent of each pot raked")
+	rakeCap := flag.Int("rakecap", 0, "most raked from a hand, 0 for no cap")
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	flag.Parse()
+
+	var seats []sim.Seat
+	for _, name := range strings.Split(*bots, ",") {
+		seat, err := newSeat(strings.TrimSpace(name), cfr.NewHoldem(*buckets, *maxBets), *samples)
+		if err != nil {
+			log.Fatal(err)
+		}
+		seats = append(seats, seat)
+	}
+
+	report, err := sim.Run(sim.Config{
+		Seats:        seats,
+		Hands:        *hands,
+		Seed:         *seed,
+		Tables:       *tables,
+		SmallBlind:   *smallBlind,
+		BigBlind:     *bigBlind,
+		Stack:        *stack,
+		StallTimeout: *stall,
+		AllInEquity:  *equity,
+		Rake:         game.RakeRules{Percent: *rake, Cap: *rakeCap},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		fmt.Print(report)
+	}
+	if !report.Passed() {
+		os.Exit(1)
+	}
+}
+
+// newSeat makes the seat for a bot named on the command line
+func newSeat(name string, holdem *cfr.Holdem, samples int) (sim.Seat, error) {
+	switch {
+	case name == "equity":
+		return sim.Seat{Name: name, New: func(rng game.RNG) game.Bot {
+			bot := game.NewEquityBot(rng)
+			bot.Samples = samples
+			return bot
+		}}, nil
+
+	case name == "random":
+		return sim.Seat{Name: name, New: func(rng game.RNG) game.Bot { return sim.NewRandomBot(rng) }}, nil
+
+	case strings.HasPrefix(name, "cfr:"):
+		path := strings.TrimPrefix(name, "cfr:")
+		f, err := os.Open(path)
+		if err != nil {
+			return sim.Seat{}, err
+		}
+		defer f.Close()
+		strategy, err := cfr.LoadStrategy(f)
+		if err != nil {
+			return sim.Seat{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if _, err := cfr.NewStrategyBot(strategy, holdem, nil); err != nil {
+			return sim.Seat{}, fmt.Errorf("%s: %w", path, err)
+		}
+		return sim.Seat{Name: name, New: func(rng game.RNG) game.Bot {
+			bot, _ := cfr.NewStrategyBot(strategy, holdem, rng)
+			return bot
+		}}, nil
+	}
+
+	style := game.BotStyle(name)
+	if _, err := game.NewRuleBot(style, nil); err != nil {
+		return sim.Seat{}, err
+	}
+	return sim.Seat{Name: name, New: func(rng game.RNG) game.Bot {
+		bot, _ := game.NewRuleBot(style, rng)
+		return bot
+	}}, nil
+}
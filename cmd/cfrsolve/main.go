@@ -0,0 +1,55 @@
+// Command cfrsolve trains a strategy for Kuhn poker, Leduc hold'em or
+// abstracted heads-up limit hold'em and writes it as JSON for bots to load.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"poker-room/internal/cfr"
+	"poker-room/internal/game"
+)
+
+func main() {
+	name := flag.String("game", "kuhn", "game to solve: kuhn, leduc or holdem")
+	iterations := flag.Int("n", 100000, "training iterations")
+	external := flag.Bool("external", false, "use external sampling instead of chance sampling")
+	buckets := flag.Int("buckets", cfr.DefaultBuckets, "hand strength buckets a street, for holdem")
+	maxBets := flag.Int("maxbets", cfr.DefaultMaxBets, "bets and raises a street, for holdem")
+	seed := flag.Int64("seed", 1, "seed for dealing")
+	out := flag.String("o", "", "file to write the strategy to (default stdout)")
+	flag.Parse()
+
+	g, err := cfr.NewGame(*name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *name == "holdem" {
+		g = cfr.NewHoldem(*buckets, *maxBets)
+	}
+
+	solver := cfr.NewSolver(g)
+	if *external {
+		solver.Sampling = cfr.ExternalSampling
+	}
+	start := time.Now()
+	value := solver.Train(*iterations, game.NewSeededRNG(*seed))
+	fmt.Fprintf(os.Stderr, "%s: %d iterations in %s, %d information sets, value to first player %.4f\n",
+		g.Name(), *iterations, time.Since(start).Round(time.Millisecond), solver.InfoSets(), value)
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := solver.Strategy().Save(w); err != nil {
+		log.Fatal(err)
+	}
+}
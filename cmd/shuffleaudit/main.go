@@ -0,0 +1,57 @@
+// Command shuffleaudit runs the statistical shuffle audit against every
+// RNG the game can deal with and prints a report for fairness audits.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"poker-room/internal/game"
+)
+
+func main() {
+	shuffles := flag.Int("n", 1000000, "shuffles per RNG")
+	seed := flag.Int64("seed", 1, "seed for the deterministic RNG")
+	asJSON := flag.Bool("json", false, "print the reports as JSON")
+	flag.Parse()
+
+	fair, err := game.NewFairShuffle(1)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rngs := []struct {
+		name string
+		rng  game.RNG
+	}{
+		{fmt.Sprintf("seeded (seed %d)", *seed), game.NewSeededRNG(*seed)},
+		{"crypto/rand", game.NewCryptoRNG()},
+		{"provably fair seed stream", fair.RNG()},
+	}
+
+	var reports []game.ShuffleAuditReport
+	passed := true
+	for _, r := range rngs {
+		report := game.RunShuffleAudit(r.name, r.rng, *shuffles)
+		reports = append(reports, report)
+		passed = passed && report.Passed
+		if !*asJSON {
+			fmt.Print(report.String())
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if !passed {
+		os.Exit(1)
+	}
+}
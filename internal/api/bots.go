@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"poker-room/internal/game"
+)
+
+// ErrRoomNotFound is returned by handler callbacks given an unknown room
+var ErrRoomNotFound = errors.New("room not found")
+
+// EquityStyle is the style that seats an equity bot rather than a rule bot
+const EquityStyle game.BotStyle = "equity"
+
+// PlayerIDHeader carries the ID the web client was given on joining a
+// room, with each bot request
+const PlayerIDHeader = "X-Player-ID"
+
+// BotHandler lets a room's host fill empty seats with bots:
+//
+//	GET    /api/bots                          the styles a bot can play
+//	POST   /api/rooms/{room}/bots             seat a bot
+//	DELETE /api/rooms/{room}/bots/{player}    remove a bot
+type BotHandler struct {
+	// Seat adds a bot to a room under name with chips, returning its
+	// player ID, or ErrRoomNotFound. Bots that watch the table, such as
+	// the equity bot, learn only from the events shown them with
+	// game.ShowBots.
+	Seat func(roomID string, bot game.Bot, name string, chips int) (string, error)
+
+	// Unseat removes a bot from a room, or returns ErrRoomNotFound or
+	// game.ErrPlayerNotFound
+	Unseat func(roomID, playerID string) error
+
+	// IsHost reports whether a request comes from the room's host. A
+	// server without accounts must still decide, e.g. with
+	// HostByPlayerID.
+	IsHost func(r *http.Request, roomID string) bool
+
+	mux *http.ServeMux
+}
+
+// NewBotHandler returns a handler seating bots with seat and removing them
+// with unseat, for requests isHost allows. It panics if isHost is nil.
+func NewBotHandler(seat func(roomID string, bot game.Bot, name string, chips int) (string, error), unseat func(roomID, playerID string) error, isHost func(r *http.Request, roomID string) bool) *BotHandler {
+	if isHost == nil {
+		panic("api: NewBotHandler needs a host check")
+	}
+	h := &BotHandler{Seat: seat, Unseat: unseat, IsHost: isHost, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /api/bots", h.styles)
+	h.mux.HandleFunc("POST /api/rooms/{room}/bots", h.seat)
+	h.mux.HandleFunc("DELETE /api/rooms/{room}/bots/{player}", h.unseat)
+	return h
+}
+
+func (h *BotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// HostByPlayerID returns a host check for a server without accounts: the
+// request's PlayerIDHeader must name the host hostOf gives for the room,
+// false if there is no such room. Player IDs are not secret, so this
+// keeps honest players from changing each other's rooms, no more.
+func HostByPlayerID(hostOf func(roomID string) (string, bool)) func(r *http.Request, roomID string) bool {
+	return func(r *http.Request, roomID string) bool {
+		host, ok := hostOf(roomID)
+		id := r.Header.Get(PlayerIDHeader)
+		return ok && id != "" && id == host
+	}
+}
+
+// botStyle is a style as listed for the host
+type botStyle struct {
+	Style game.BotStyle `json:"style"`
+	Name  string        `json:"name"`
+}
+
+func (h *BotHandler) styles(w http.ResponseWriter, r *http.Request) {
+	var styles []botStyle
+	for _, s := range game.BotStyles() {
+		styles = append(styles, botStyle{s, s.String()})
+	}
+	styles = append(styles, botStyle{EquityStyle, "Equity"})
+	writeJSON(w, http.StatusOK, styles)
+}
+
+// seatRequest is the JSON body for seating a bot. The name defaults to the
+// style's.
+type seatRequest struct {
+	Style game.BotStyle `json:"style"`
+	Name  string        `json:"name"`
+	Chips int           `json:"chips"`
+}
+
+func (h *BotHandler) seat(w http.ResponseWriter, r *http.Request) {
+	roomID := r.PathValue("room")
+	if !h.allowed(w, r, roomID) {
+		return
+	}
+
+	var req seatRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxUploadBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Chips <= 0 {
+		writeError(w, http.StatusBadRequest, game.ErrNoChips)
+		return
+	}
+	bot, err := newBot(req.Style)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Name == "" {
+		req.Name = req.Style.String()
+		if req.Style == EquityStyle {
+			req.Name = "Equity"
+		}
+	}
+
+	playerID, err := h.Seat(roomID, bot, req.Name, req.Chips)
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		// A full table or a hand in progress
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeJSON(w, http.StatusCreated, map[string]string{"playerId": playerID, "name": req.Name})
+	}
+}
+
+// newBot returns a bot playing a style
+func newBot(style game.BotStyle) (game.Bot, error) {
+	if style == EquityStyle {
+		return game.NewEquityBot(game.NewCryptoRNG()), nil
+	}
+	return game.NewRuleBot(style, game.NewCryptoRNG())
+}
+
+func (h *BotHandler) unseat(w http.ResponseWriter, r *http.Request) {
+	roomID := r.PathValue("room")
+	if !h.allowed(w, r, roomID) {
+		return
+	}
+
+	err := h.Unseat(roomID, r.PathValue("player"))
+	switch {
+	case errors.Is(err, ErrRoomNotFound), errors.Is(err, game.ErrPlayerNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusConflict, err)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// allowed checks the request comes from the room's host, writing an error
+// if not
+func (h *BotHandler) allowed(w http.ResponseWriter, r *http.Request, roomID string) bool {
+	if !h.IsHost(r, roomID) {
+		writeError(w, http.StatusForbidden, errors.New("only the host can seat bots"))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"poker-room/internal/game"
+)
+
+// botRoom is a room for the bot handler tests, hosted by "host"
+type botRoom struct {
+	bots map[string]game.Bot
+	full bool
+}
+
+func newBotServer(room *botRoom) *BotHandler {
+	seat := func(roomID string, bot game.Bot, name string, chips int) (string, error) {
+		if roomID != "room" {
+			return "", ErrRoomNotFound
+		}
+		if room.full {
+			return "", errors.New("table full")
+		}
+		id := "bot-" + name
+		room.bots[id] = bot
+		return id, nil
+	}
+	unseat := func(roomID, playerID string) error {
+		if roomID != "room" {
+			return ErrRoomNotFound
+		}
+		if room.bots[playerID] == nil {
+			return game.ErrPlayerNotFound
+		}
+		delete(room.bots, playerID)
+		return nil
+	}
+	hostOf := func(roomID string) (string, bool) { return "host", roomID == "room" }
+	return NewBotHandler(seat, unseat, HostByPlayerID(hostOf))
+}
+
+func TestBotHandlerStyles(t *testing.T) {
+	h := newBotServer(&botRoom{bots: make(map[string]game.Bot)})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/bots", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body)
+	}
+	var styles []botStyle
+	if err := json.NewDecoder(w.Body).Decode(&styles); err != nil {
+		t.Fatal(err)
+	}
+	if len(styles) != len(game.BotStyles())+1 || styles[len(styles)-1].Style != EquityStyle {
+		t.Errorf("styles = %+v", styles)
+	}
+}
+
+func TestBotHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		player   string
+		body     string
+		full     bool
+		want     int
+		wantBots []string
+	}{
+		{name: "seat", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{"style":"maniac","chips":500}`, want: http.StatusCreated, wantBots: []string{"bot-Maniac", "bot-old"}},
+		{name: "seat an equity bot", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{"style":"equity","name":"Eq","chips":500}`, want: http.StatusCreated, wantBots: []string{"bot-Eq", "bot-old"}},
+		{name: "seat without an ID", method: http.MethodPost, path: "/api/rooms/room/bots",
+			body: `{"style":"maniac","chips":500}`, want: http.StatusForbidden},
+		{name: "seat as a guest", method: http.MethodPost, path: "/api/rooms/room/bots", player: "guest",
+			body: `{"style":"maniac","chips":500}`, want: http.StatusForbidden},
+		{name: "unknown style", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{"style":"shark","chips":500}`, want: http.StatusBadRequest},
+		{name: "no chips", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{"style":"maniac"}`, want: http.StatusBadRequest},
+		{name: "bad JSON", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{`, want: http.StatusBadRequest},
+		{name: "full table", method: http.MethodPost, path: "/api/rooms/room/bots", player: "host",
+			body: `{"style":"maniac","chips":500}`, full: true, want: http.StatusConflict},
+		// Nobody hosts a room that does not exist
+		{name: "unknown room", method: http.MethodPost, path: "/api/rooms/nowhere/bots", player: "host",
+			body: `{"style":"maniac","chips":500}`, want: http.StatusForbidden},
+		{name: "remove", method: http.MethodDelete, path: "/api/rooms/room/bots/bot-old", player: "host",
+			want: http.StatusNoContent, wantBots: []string{}},
+		{name: "remove as a guest", method: http.MethodDelete, path: "/api/rooms/room/bots/bot-old", player: "guest",
+			want: http.StatusForbidden},
+		{name: "remove a stranger", method: http.MethodDelete, path: "/api/rooms/room/bots/bot-new", player: "host",
+			want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old, _ := game.NewRuleBot(game.CallingStation, nil)
+			room := &botRoom{bots: map[string]game.Bot{"bot-old": old}, full: tt.full}
+			h := newBotServer(room)
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.player != "" {
+				r.Header.Set(PlayerIDHeader, tt.player)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.want {
+				t.Fatalf("status %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+
+			if tt.wantBots == nil {
+				tt.wantBots = []string{"bot-old"}
+			}
+			if len(room.bots) != len(tt.wantBots) {
+				t.Errorf("bots = %v, want %v", room.bots, tt.wantBots)
+			}
+			for _, id := range tt.wantBots {
+				if room.bots[id] == nil {
+					t.Errorf("bots = %v, want %v", room.bots, tt.wantBots)
+				}
+			}
+		})
+	}
+}
+
+func TestBotHandlerSeatsEquityBot(t *testing.T) {
+	room := &botRoom{bots: make(map[string]game.Bot)}
+	h := newBotServer(room)
+	r := httptest.NewRequest(http.MethodPost, "/api/rooms/room/bots", strings.NewReader(`{"style":"equity","chips":100}`))
+	r.Header.Set(PlayerIDHeader, "host")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if _, ok := room.bots["bot-Equity"].(*game.EquityBot); !ok {
+		t.Errorf("bots = %v, want an equity bot named Equity", room.bots)
+	}
+}
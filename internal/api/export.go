@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"poker-room/internal/game"
+	"poker-room/internal/store"
+)
+
+// HandExportHandler serves a player's stored hands as PokerStars hand
+// histories, for import into tracking software:
+//
+//	GET /api/hands/export?room=ID&session=ID&limit=N
+//
+// Only hands the player was dealt into are written, showing their own hole
+// cards and any seen at showdown. Each is numbered by its store ID, which
+// unlike its hand number is never reused. A session limits the export to
+// the hands played during it; limit keeps only the most recent N.
+type HandExportHandler struct {
+	// Hands and Sessions look records up in the store
+	Hands    func(q store.HandQuery) ([]store.HandRecord, error)
+	Sessions func(q store.SessionQuery) ([]store.Session, error)
+
+	// Viewer identifies the player making a request, or returns "" if
+	// they are not signed in
+	Viewer func(r *http.Request) string
+
+	mux *http.ServeMux
+}
+
+// NewHandExportHandler returns a handler exporting the hands and sessions
+// s stores for the player viewer identifies
+func NewHandExportHandler(s store.Store, viewer func(r *http.Request) string) *HandExportHandler {
+	h := &HandExportHandler{Hands: s.Hands, Sessions: s.Sessions, Viewer: viewer, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /api/hands/export", h.export)
+	return h
+}
+
+func (h *HandExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *HandExportHandler) export(w http.ResponseWriter, r *http.Request) {
+	heroID := h.Viewer(r)
+	if heroID == "" {
+		writeError(w, http.StatusUnauthorized, errors.New("sign in to export your hands"))
+		return
+	}
+
+	query := r.URL.Query()
+	q := store.HandQuery{RoomID: query.Get("room"), PlayerID: heroID}
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a whole number"))
+			return
+		}
+		limit = n
+	}
+
+	var session *store.Session
+	if id := query.Get("session"); id != "" {
+		sessions, err := h.Sessions(store.SessionQuery{RoomID: q.RoomID, PlayerID: heroID})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		for i := range sessions {
+			if sessions[i].ID == id {
+				session = &sessions[i]
+			}
+		}
+		if session == nil {
+			writeError(w, http.StatusNotFound, errors.New("session not found"))
+			return
+		}
+		q.RoomID, q.Since = session.RoomID, session.JoinedAt
+	}
+
+	// The session's end is not part of the query, so the limit is applied
+	// once hands after it are dropped
+	if session == nil {
+		q.Limit = limit
+	}
+	hands, err := h.Hands(q)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if session != nil && session.LeftAt != nil {
+		kept := hands[:0]
+		for _, hand := range hands {
+			if !hand.PlayedAt.After(*session.LeftAt) {
+				kept = append(kept, hand)
+			}
+		}
+		hands = kept
+	}
+	if limit > 0 && len(hands) > limit {
+		hands = hands[len(hands)-limit:]
+	}
+
+	// Written in full before anything is sent, so a bad record is an error
+	// rather than a truncated file
+	var buf, hand bytes.Buffer
+	for _, rec := range hands {
+		hand.Reset()
+		err := game.WritePokerStars(&hand, rec.Events, game.PokerStarsOptions{
+			Table:      rec.RoomID,
+			HeroID:     heroID,
+			HandNumber: rec.HandNumber,
+			HandID:     func(int) int64 { return rec.ID },
+		})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("room %s hand %d: %w", rec.RoomID, rec.HandNumber, err))
+			return
+		}
+		if hand.Len() == 0 {
+			continue
+		}
+		if buf.Len() > 0 {
+			buf.WriteString("\n\n\n")
+		}
+		buf.Write(hand.Bytes())
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="hands.txt"`)
+	buf.WriteTo(w)
+}
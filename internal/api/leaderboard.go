@@ -0,0 +1,64 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"poker-room/internal/leaderboard"
+)
+
+// LeaderboardHandler serves leaderboards across every room:
+//
+//	GET /api/leaderboards/{metric}?window=day|week|all&limit=N&min=N
+//
+// Metrics are net, bb100, hands, roi and rating. The window defaults to
+// all time.
+type LeaderboardHandler struct {
+	// Board builds a leaderboard
+	Board func(q leaderboard.Query) (*leaderboard.Board, error)
+
+	mux *http.ServeMux
+}
+
+// NewLeaderboardHandler returns a handler serving the leaderboards board
+// builds
+func NewLeaderboardHandler(board func(q leaderboard.Query) (*leaderboard.Board, error)) *LeaderboardHandler {
+	h := &LeaderboardHandler{Board: board, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /api/leaderboards/{metric}", h.leaderboard)
+	return h
+}
+
+func (h *LeaderboardHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *LeaderboardHandler) leaderboard(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := leaderboard.Query{
+		Metric: leaderboard.Metric(r.PathValue("metric")),
+		Window: leaderboard.Window(query.Get("window")),
+	}
+	for name, field := range map[string]*int{"limit": &q.Limit, "min": &q.Min} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				writeError(w, http.StatusBadRequest, errors.New(name+" must be a whole number"))
+				return
+			}
+			*field = n
+		}
+	}
+
+	board, err := h.Board(q)
+	switch {
+	case errors.Is(err, leaderboard.ErrUnknownMetric):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, leaderboard.ErrUnknownWindow):
+		writeError(w, http.StatusBadRequest, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, board)
+	}
+}
@@ -0,0 +1,226 @@
+// Package api serves the poker room's HTTP endpoints for the web client
+package api
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"poker-room/internal/game"
+)
+
+const (
+	maxUploadBytes = 1 << 20
+	maxUploads     = 256 // Replays kept before the oldest is dropped
+)
+
+// ReplayHandler serves hand replays a step at a time:
+//
+//	POST /api/replays                           upload a hand history or event log
+//	GET  /api/replays/{id}/steps/{step}         a step of an uploaded hand
+//	GET  /api/tables/{table}/hands/{hand}/steps/{step}
+//	                                            a step of a table's completed hand
+//
+// Steps take ?hero=ID to show one player's cards and ?reveal=true to show
+// everyone's. On table hands those only apply to the viewer.
+type ReplayHandler struct {
+	// Events returns a copy of a table's event log, or false if there is
+	// no such table. Nil serves uploaded hands only.
+	Events func(tableID string) ([]game.Event, bool)
+
+	// Viewer identifies the player making a request, whose hole cards
+	// table replays show. Nil shows only cards seen at showdown.
+	Viewer func(r *http.Request) string
+
+	mu      sync.Mutex
+	uploads map[string]*game.HandReplayer
+	order   []string
+	mux     *http.ServeMux
+}
+
+// NewReplayHandler returns a handler serving replays of uploaded hands and
+// of the tables events looks up
+func NewReplayHandler(events func(tableID string) ([]game.Event, bool)) *ReplayHandler {
+	h := &ReplayHandler{
+		Events:  events,
+		uploads: make(map[string]*game.HandReplayer),
+		mux:     http.NewServeMux(),
+	}
+	h.mux.HandleFunc("POST /api/replays", h.upload)
+	h.mux.HandleFunc("GET /api/replays/{id}/steps/{step}", h.uploadedStep)
+	h.mux.HandleFunc("GET /api/tables/{table}/hands/{hand}/steps/{step}", h.tableStep)
+	return h
+}
+
+func (h *ReplayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// eventLog is the JSON body for uploading an event log
+type eventLog struct {
+	HandNumber int          `json:"handNumber"`
+	Events     []game.Event `json:"events"`
+}
+
+// upload takes a PokerStars or PHH hand history, replaying the hand picked
+// by ?hand=N (from 1), or a JSON event log
+func (h *ReplayHandler) upload(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	var replayer *game.HandReplayer
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/json" {
+		var log eventLog
+		if err := json.Unmarshal(body, &log); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		replayer, err = game.NewHandReplayer(log.Events, log.HandNumber)
+	} else {
+		replayer, err = replayImported(body, r.URL.Query().Get("hand"))
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := h.store(replayer)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Location", "/api/replays/"+id+"/steps/0")
+	writeJSON(w, http.StatusCreated, map[string]any{"id": id, "steps": replayer.Len()})
+}
+
+// replayImported parses a hand history and builds a replayer for one hand
+func replayImported(body []byte, hand string) (*game.HandReplayer, error) {
+	hands, err := game.ParseHandHistory(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	n := 1
+	if hand != "" {
+		if n, err = strconv.Atoi(hand); err != nil {
+			return nil, fmt.Errorf("invalid hand %q", hand)
+		}
+	}
+	if n < 1 || n > len(hands) {
+		return nil, fmt.Errorf("%w: %d of %d", game.ErrHandNotFound, n, len(hands))
+	}
+	return hands[n-1].NewHandReplayer()
+}
+
+// store keeps a replayer under a new random ID, dropping the oldest once
+// there are too many
+func (h *ReplayHandler) store(replayer *game.HandReplayer) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.order) >= maxUploads {
+		delete(h.uploads, h.order[0])
+		h.order = h.order[1:]
+	}
+	h.uploads[id] = replayer
+	h.order = append(h.order, id)
+	return id, nil
+}
+
+func (h *ReplayHandler) uploadedStep(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	replayer, ok := h.uploads[r.PathValue("id")]
+	h.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("replay not found"))
+		return
+	}
+
+	query := r.URL.Query()
+	view := game.ReplayView{
+		HeroID: query.Get("hero"),
+		Reveal: query.Get("reveal") == "true",
+	}
+	writeStep(w, replayer, r.PathValue("step"), view)
+}
+
+func (h *ReplayHandler) tableStep(w http.ResponseWriter, r *http.Request) {
+	if h.Events == nil {
+		writeError(w, http.StatusNotFound, errors.New("table not found"))
+		return
+	}
+	events, ok := h.Events(r.PathValue("table"))
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("table not found"))
+		return
+	}
+	hand, err := strconv.Atoi(r.PathValue("hand"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid hand %q", r.PathValue("hand")))
+		return
+	}
+
+	replayer, err := game.NewHandReplayer(events, hand)
+	if err != nil {
+		writeError(w, replayStatus(err), err)
+		return
+	}
+
+	// Hole cards at a live table are only shown to the player they belong to
+	var view game.ReplayView
+	if h.Viewer != nil {
+		view.HeroID = h.Viewer(r)
+	}
+	writeStep(w, replayer, r.PathValue("step"), view)
+}
+
+func writeStep(w http.ResponseWriter, replayer *game.HandReplayer, step string, view game.ReplayView) {
+	i, err := strconv.Atoi(step)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid step %q", step))
+		return
+	}
+	s, err := replayer.Step(i, view)
+	if err != nil {
+		writeError(w, replayStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, s)
+}
+
+// replayStatus maps replayer errors to HTTP status codes
+func replayStatus(err error) int {
+	switch {
+	case errors.Is(err, game.ErrHandNotFound), errors.Is(err, game.ErrStepOutOfRange):
+		return http.StatusNotFound
+	case errors.Is(err, game.ErrHandNotOver):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"poker-room/internal/game"
+)
+
+// SettleUpHandler serves a room's settle-up report:
+//
+//	GET /api/rooms/{room}/settlement?format=json|csv|html
+//
+// JSON is the default.
+type SettleUpHandler struct {
+	// Report settles up a room, or returns false if there is no such
+	// room. Rooms mid-hand return game.ErrGameInProgress.
+	Report func(roomID string) (*game.SettleUpReport, bool, error)
+
+	mux *http.ServeMux
+}
+
+// NewSettleUpHandler returns a handler serving the rooms report settles
+func NewSettleUpHandler(report func(roomID string) (*game.SettleUpReport, bool, error)) *SettleUpHandler {
+	h := &SettleUpHandler{Report: report, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /api/rooms/{room}/settlement", h.settlement)
+	return h
+}
+
+func (h *SettleUpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *SettleUpHandler) settlement(w http.ResponseWriter, r *http.Request) {
+	report, ok, err := h.Report(r.PathValue("room"))
+	switch {
+	case errors.Is(err, game.ErrGameInProgress):
+		writeError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	case !ok:
+		writeError(w, http.StatusNotFound, errors.New("room not found"))
+		return
+	}
+
+	// CSV and HTML are rendered in full first, so a failure is reported
+	// as an error rather than a truncated page
+	format := r.URL.Query().Get("format")
+	var buf bytes.Buffer
+	switch format {
+	case "", "json":
+		writeJSON(w, http.StatusOK, report)
+		return
+	case "csv":
+		err = report.WriteCSV(&buf)
+	case "html":
+		err = report.WriteHTML(&buf)
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("format must be json, csv or html"))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="settlement.csv"`)
+	} else {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	buf.WriteTo(w)
+}
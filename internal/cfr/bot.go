@@ -0,0 +1,131 @@
+package cfr
+
+import (
+	"fmt"
+	"strings"
+
+	"poker-room/internal/game"
+)
+
+// StrategyBot plays a trained hold'em strategy at an engine table. The
+// strategy is for limit betting, so the engine's no-limit bets are
+// collapsed: any bet or raise, a min-raise or a shove alike, is an "r", and
+// the bot's own raises are the limit size, a big blind before the turn and
+// two after. It must observe the table, with game.ShowBots, to follow the
+// betting. It is meant for heads-up tables, where the engine's seat order
+// matches Holdem's; with more players it plays as though only the bettors
+// were there.
+type StrategyBot struct {
+	Strategy *Strategy
+	Game     *Holdem
+	RNG      game.RNG
+
+	street  int
+	history [4]string
+	bets    map[string]int // Each player's bet this street
+	current int            // Bet to match this street
+	hand    int
+	buckets []int // The bot's bucket on each street so far this hand
+}
+
+// NewStrategyBot returns a bot playing a hold'em strategy with the
+// abstraction it was trained on
+func NewStrategyBot(s *Strategy, g *Holdem, rng game.RNG) (*StrategyBot, error) {
+	if s.Game != g.Name() {
+		return nil, fmt.Errorf("cannot play a %s strategy at hold'em", s.Game)
+	}
+	return &StrategyBot{Strategy: s, Game: g, RNG: rng, bets: make(map[string]int)}, nil
+}
+
+// Observe follows the betting
+func (b *StrategyBot) Observe(e game.Event) {
+	switch e.Type {
+	case game.EventHandStarted:
+		b.street, b.history, b.current = 0, [4]string{}, 0
+		b.buckets = nil
+		b.hand = e.HandNumber
+		clear(b.bets)
+
+	case game.EventSmallBlindPosted, game.EventBigBlindPosted:
+		b.bets[e.PlayerID] += e.Amount
+		b.current = max(b.current, b.bets[e.PlayerID])
+
+	case game.EventStreetDealt:
+		b.street = min(b.street+1, 3)
+		b.current = 0
+		clear(b.bets)
+
+	case game.EventActionTaken:
+		action, err := game.ParseActionType(e.Action)
+		if err != nil {
+			return
+		}
+		switch action {
+		case game.Call, game.AllIn:
+			b.bets[e.PlayerID] += e.Amount
+		case game.Bet, game.Raise:
+			b.bets[e.PlayerID] = e.Amount
+		}
+		move := "c"
+		switch {
+		case action == game.Fold:
+			move = "f"
+		case b.bets[e.PlayerID] > b.current:
+			move = "r"
+		}
+		b.current = max(b.current, b.bets[e.PlayerID])
+		b.history[b.street] += move
+	}
+}
+
+// Decide looks up the strategy for the bot's bucket and the betting so far
+func (b *StrategyBot) Decide(state *game.GameState, holeCards []game.Card) game.BotAction {
+	var me *game.PlayerState
+	for i := range state.Players {
+		if state.Players[i].ID == state.CurrentPlayerID {
+			me = &state.Players[i]
+		}
+	}
+	if me == nil || len(holeCards) != 2 {
+		return game.BotAction{Action: game.Fold}
+	}
+
+	if b.hand != state.HandNumber {
+		// Joined mid-hand or not observing: act on what it can see
+		b.hand, b.buckets = state.HandNumber, nil
+		b.street = max(len(state.CommunityCards)-2, 0)
+	}
+	for len(b.buckets) <= b.street {
+		shown := []int{0, 3, 4, 5}[len(b.buckets)]
+		b.buckets = append(b.buckets, HandBucket(holeCards, state.CommunityCards[:min(shown, len(state.CommunityCards))], b.Game.Buckets, b.RNG))
+	}
+
+	facing := state.CurrentBet > me.CurrentBet
+	bets := strings.Count(b.history[b.street], "r")
+	if b.street == 0 {
+		bets++ // The big blind
+	}
+	actions := []string{"c", "r"}
+	switch {
+	case facing && bets < b.Game.MaxBets:
+		actions = []string{"f", "c", "r"}
+	case facing:
+		actions = []string{"f", "c"}
+	}
+
+	infoSet := HoldemInfoSet(b.buckets, b.history[:b.street+1])
+	switch b.Strategy.Choose(infoSet, actions, b.RNG) {
+	case "f":
+		return game.BotAction{Action: game.Fold}
+	case "r":
+		size := state.BigBlind * holdemBetSize(b.street) / 2
+		if state.CurrentBet == 0 {
+			return game.BotAction{Action: game.Bet, Amount: size}
+		}
+		return game.BotAction{Action: game.Raise, Amount: state.CurrentBet + max(size, state.MinRaise)}
+	}
+	if facing {
+		return game.BotAction{Action: game.Call}
+	}
+	return game.BotAction{Action: game.Check}
+}
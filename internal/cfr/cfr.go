@@ -0,0 +1,248 @@
+// Package cfr solves small two-player poker games with counterfactual
+// regret minimization, for bots to play and for studying strategy
+package cfr
+
+import (
+	"fmt"
+
+	"poker-room/internal/game"
+)
+
+// Game is a two-player zero-sum game. Every card is dealt when a hand
+// starts, and states only reveal them as the hand goes on, so each
+// iteration samples chance once.
+type Game interface {
+	Name() string
+	Deal(rng game.RNG) State
+}
+
+// State is a point in a hand. Play returns a new state and leaves the old
+// one as it was.
+type State interface {
+	Terminal() bool
+	// Utility is what player 0 wins at a terminal state; player 1 wins
+	// the negation
+	Utility() float64
+	Player() int // To act, 0 or 1
+	Actions() []string
+	Play(action string) State
+	// InfoSet identifies what the player to act knows: their own cards,
+	// the public cards and the actions so far
+	InfoSet() string
+}
+
+// Sampling is how the solver samples the game tree
+type Sampling int
+
+const (
+	// ChanceSampling deals one hand per iteration and walks every action
+	ChanceSampling Sampling = iota
+	// ExternalSampling also samples the opponent's and chance's actions,
+	// walking only the updating player's, which is much faster on big
+	// games
+	ExternalSampling
+)
+
+// node holds the regrets and strategy totals for an information set
+type node struct {
+	actions     []string
+	regret      []float64
+	strategySum []float64
+}
+
+// strategy matches the current strategy to the positive regrets
+func (n *node) strategy() []float64 {
+	s := make([]float64, len(n.actions))
+	total := 0.0
+	for i, r := range n.regret {
+		s[i] = max(r, 0)
+		total += s[i]
+	}
+	for i := range s {
+		if total > 0 {
+			s[i] /= total
+		} else {
+			s[i] = 1 / float64(len(s))
+		}
+	}
+	return s
+}
+
+// average returns the average strategy over training, which is what
+// converges to an equilibrium
+func (n *node) average() []float64 {
+	s := make([]float64, len(n.actions))
+	total := 0.0
+	for _, v := range n.strategySum {
+		total += v
+	}
+	for i := range s {
+		if total > 0 {
+			s[i] = n.strategySum[i] / total
+		} else {
+			s[i] = 1 / float64(len(s))
+		}
+	}
+	return s
+}
+
+// Solver trains a strategy for a game by self-play
+type Solver struct {
+	Game       Game
+	Sampling   Sampling
+	Iterations int // Trained so far
+
+	nodes map[string]*node
+}
+
+// NewSolver returns an untrained solver using chance sampling
+func NewSolver(g Game) *Solver {
+	return &Solver{Game: g, nodes: make(map[string]*node)}
+}
+
+// Train runs iterations of CFR and returns player 0's average winnings per
+// hand over them, which tends to the game's value
+func (s *Solver) Train(iterations int, rng game.RNG) float64 {
+	total := 0.0
+	for i := 0; i < iterations; i++ {
+		root := s.Game.Deal(rng)
+		switch s.Sampling {
+		case ExternalSampling:
+			// Update each player in turn against sampled play
+			total += s.external(root, 0, rng)
+			s.external(s.Game.Deal(rng), 1, rng)
+		default:
+			total += s.chance(root, [2]float64{1, 1})
+		}
+		s.Iterations++
+	}
+	return total / float64(max(iterations, 1))
+}
+
+// InfoSets returns how many information sets training has reached
+func (s *Solver) InfoSets() int {
+	return len(s.nodes)
+}
+
+// node finds or makes the node for a state's information set
+func (s *Solver) node(st State) *node {
+	key := st.InfoSet()
+	n := s.nodes[key]
+	if n == nil {
+		actions := st.Actions()
+		n = &node{
+			actions:     actions,
+			regret:      make([]float64, len(actions)),
+			strategySum: make([]float64, len(actions)),
+		}
+		s.nodes[key] = n
+	}
+	return n
+}
+
+// chance walks every action of a dealt hand, given each player's chance
+// of reaching the state, and returns player 0's expected winnings
+func (s *Solver) chance(st State, reach [2]float64) float64 {
+	if st.Terminal() {
+		return st.Utility()
+	}
+	p := st.Player()
+	n := s.node(st)
+	strategy := n.strategy()
+
+	utils := make([]float64, len(n.actions))
+	value := 0.0
+	for i, a := range n.actions {
+		next := reach
+		next[p] *= strategy[i]
+		utils[i] = s.chance(st.Play(a), next)
+		value += strategy[i] * utils[i]
+	}
+
+	sign := 1.0
+	if p == 1 {
+		sign = -1
+	}
+	for i := range n.actions {
+		n.regret[i] += reach[1-p] * sign * (utils[i] - value)
+		n.strategySum[i] += reach[p] * strategy[i]
+	}
+	return value
+}
+
+// external walks every action of player t and one sampled action of the
+// other, returning t's expected winnings
+func (s *Solver) external(st State, t int, rng game.RNG) float64 {
+	if st.Terminal() {
+		if t == 1 {
+			return -st.Utility()
+		}
+		return st.Utility()
+	}
+	p := st.Player()
+	n := s.node(st)
+	strategy := n.strategy()
+
+	if p != t {
+		for i, v := range strategy {
+			n.strategySum[i] += v
+		}
+		return s.external(st.Play(n.actions[sample(strategy, rng)]), t, rng)
+	}
+
+	utils := make([]float64, len(n.actions))
+	value := 0.0
+	for i, a := range n.actions {
+		utils[i] = s.external(st.Play(a), t, rng)
+		value += strategy[i] * utils[i]
+	}
+	for i := range n.actions {
+		n.regret[i] += utils[i] - value
+	}
+	return value
+}
+
+// sampleScale is the resolution sample draws probabilities at
+const sampleScale = 1 << 30
+
+// sample picks an index with the given probabilities
+func sample(probabilities []float64, rng game.RNG) int {
+	x := float64(rng.Intn(sampleScale)) / sampleScale
+	for i, p := range probabilities {
+		if x < p {
+			return i
+		}
+		x -= p
+	}
+	return len(probabilities) - 1
+}
+
+// Strategy returns the average strategy trained so far
+func (s *Solver) Strategy() *Strategy {
+	strategy := &Strategy{
+		Game:       s.Game.Name(),
+		Iterations: s.Iterations,
+		InfoSets:   make(map[string]map[string]float64, len(s.nodes)),
+	}
+	for key, n := range s.nodes {
+		probabilities := make(map[string]float64, len(n.actions))
+		for i, p := range n.average() {
+			probabilities[n.actions[i]] = p
+		}
+		strategy.InfoSets[key] = probabilities
+	}
+	return strategy
+}
+
+// NewGame returns a game by name: kuhn, leduc or holdem
+func NewGame(name string) (Game, error) {
+	switch name {
+	case "kuhn":
+		return Kuhn{}, nil
+	case "leduc":
+		return Leduc{}, nil
+	case "holdem":
+		return NewHoldem(DefaultBuckets, DefaultMaxBets), nil
+	}
+	return nil, fmt.Errorf("unknown game %q", name)
+}
@@ -0,0 +1,112 @@
+package cfr
+
+import (
+	"math"
+	"testing"
+
+	"poker-room/internal/game"
+)
+
+// strategyValue is player 0's expected winnings from a state when both
+// players follow a strategy
+func strategyValue(s *Strategy, st State) float64 {
+	if st.Terminal() {
+		return st.Utility()
+	}
+	actions := st.Actions()
+	value := 0.0
+	for i, p := range s.Probabilities(st.InfoSet(), actions) {
+		if p > 0 {
+			value += p * strategyValue(s, st.Play(actions[i]))
+		}
+	}
+	return value
+}
+
+func TestKuhnConverges(t *testing.T) {
+	solver := NewSolver(Kuhn{})
+	solver.Train(200000, game.NewSeededRNG(1))
+	if got := solver.InfoSets(); got != 12 {
+		t.Errorf("got %d information sets, want 12", got)
+	}
+
+	// Average the strategy's value over all six deals
+	strategy := solver.Strategy()
+	value := 0.0
+	for a := 0; a < 3; a++ {
+		for b := 0; b < 3; b++ {
+			if a != b {
+				value += strategyValue(strategy, kuhnState{cards: [2]int{a, b}}) / 6
+			}
+		}
+	}
+	if want := -1.0 / 18; math.Abs(value-want) > 0.005 {
+		t.Errorf("value = %.4f, want %.4f", value, want)
+	}
+}
+
+func TestLeducInfoSets(t *testing.T) {
+	for _, sampling := range []Sampling{ChanceSampling, ExternalSampling} {
+		solver := NewSolver(Leduc{})
+		solver.Sampling = sampling
+		solver.Train(5000, game.NewSeededRNG(1))
+		if got := solver.InfoSets(); got != 288 {
+			t.Errorf("sampling %d: got %d information sets, want 288", sampling, got)
+		}
+	}
+}
+
+// The abstraction's player 0 is the engine's small blind, and both agree
+// who acts on every street
+func TestHoldemSeatOrder(t *testing.T) {
+	g := game.NewPokerGameWithRNG(1, 2, game.NewSeededRNG(1))
+	for _, id := range []string{"a", "b"} {
+		if err := g.AddPlayer(id, id, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var smallBlind string
+	g.OnEvent = func(e game.Event) {
+		if e.Type == game.EventSmallBlindPosted {
+			smallBlind = e.PlayerID
+		}
+	}
+	abstract := NewHoldem(2, DefaultMaxBets)
+
+	for hand := 0; hand < 4; hand++ {
+		if err := g.StartNewHand(); err != nil {
+			t.Fatal(err)
+		}
+		if dealer := g.Players[g.DealerIndex].ID; dealer != smallBlind {
+			t.Errorf("hand %d: dealer %s, small blind %s", hand+1, dealer, smallBlind)
+		}
+
+		// Check or call every street down on both
+		st := abstract.Deal(game.NewSeededRNG(int64(hand)))
+		for !g.HandComplete {
+			if st.Terminal() {
+				t.Fatalf("hand %d: abstraction over before the engine", hand+1)
+			}
+			state := g.GetState()
+			want := 1
+			if state.CurrentPlayerID == smallBlind {
+				want = 0
+			}
+			if got := st.Player(); got != want {
+				t.Errorf("hand %d %s: abstraction has player %d to act, engine player %d", hand+1, state.BettingRound, got, want)
+			}
+
+			action := game.Check
+			if state.CurrentBet > g.Players[g.CurrentIndex].CurrentBet {
+				action = game.Call
+			}
+			if err := g.ProcessAction(state.CurrentPlayerID, action, 0); err != nil {
+				t.Fatal(err)
+			}
+			st = st.Play("c")
+		}
+		if !st.Terminal() {
+			t.Errorf("hand %d: engine over before the abstraction", hand+1)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package cfr
+
+import (
+	"strconv"
+	"strings"
+
+	"poker-room/internal/game"
+)
+
+// Defaults for the hold'em abstraction
+const (
+	DefaultBuckets = 6
+	DefaultMaxBets = 4
+)
+
+// bucketSamples is how many run-outs estimate a hand's strength
+const bucketSamples = 200
+
+// Holdem is heads-up limit hold'em, abstracted so it can be solved.
+// Cards are abstracted by grouping hands into buckets of similar strength
+// on each street. Bets are limit: blinds of 1 and 2, bets of 2 before the
+// turn and 4 after, capped at MaxBets a street with the big blind as the
+// first. Hands are ranked by the engine's evaluator.
+//
+// Player 0 is the small blind and dealer, first to act before the flop
+// and last after it, as in the engine heads-up.
+type Holdem struct {
+	Buckets int
+	MaxBets int
+}
+
+// NewHoldem returns the game with buckets hand strength buckets a street
+// and at most maxBets bets and raises a street
+func NewHoldem(buckets, maxBets int) *Holdem {
+	return &Holdem{Buckets: max(buckets, 1), MaxBets: max(maxBets, 2)}
+}
+
+func (h *Holdem) Name() string { return "holdem" }
+
+// holdemDeal is the cards of a hand and each player's bucket on each
+// street
+type holdemDeal struct {
+	holeCards [2][]game.Card
+	board     []game.Card
+	buckets   [2][4]int
+	winner    int // 0, 1, or -1 for a split pot
+}
+
+// Deal deals the hole cards and the whole board, and buckets each player's
+// hand on every street
+func (h *Holdem) Deal(rng game.RNG) State {
+	deck := game.NewDeckWithRNG(rng)
+	deck.Shuffle()
+	draw := func(n int) []game.Card {
+		cards := make([]game.Card, n)
+		for i := range cards {
+			cards[i], _ = deck.Draw()
+		}
+		return cards
+	}
+
+	d := &holdemDeal{}
+	d.holeCards[0], d.holeCards[1] = draw(2), draw(2)
+	d.board = draw(5)
+	for p := 0; p < 2; p++ {
+		for street, shown := range []int{0, 3, 4, 5} {
+			d.buckets[p][street] = HandBucket(d.holeCards[p], d.board[:shown], h.Buckets, rng)
+		}
+	}
+
+	v0 := game.EvaluateHandValue(append(append([]game.Card{}, d.holeCards[0]...), d.board...))
+	v1 := game.EvaluateHandValue(append(append([]game.Card{}, d.holeCards[1]...), d.board...))
+	switch {
+	case v0 > v1:
+		d.winner = 0
+	case v1 > v0:
+		d.winner = 1
+	default:
+		d.winner = -1
+	}
+
+	return holdemState{
+		game:   h,
+		deal:   d,
+		put:    [2]int{1, 2},
+		bets:   1,
+		folded: -1,
+	}
+}
+
+// HandBucket sorts hole cards into one of n buckets by their chance of
+// beating a random hand on the board so far, from 0 for the weakest.
+// Before the flop that is the engine's precomputed equity; after it, an
+// estimate from random run-outs.
+func HandBucket(holeCards, board []game.Card, n int, rng game.RNG) int {
+	var strength float64
+	if len(board) == 0 {
+		stats, _ := game.PreflopStats(holeCards)
+		strength = stats.EquityVs(1) / 100
+	} else {
+		strength = handStrength(holeCards, board, rng)
+	}
+	return min(int(strength*float64(n)), n-1)
+}
+
+// handStrength estimates the share of pots hole cards win against a random
+// hand, running out the rest of the board at random
+func handStrength(holeCards, board []game.Card, rng game.RNG) float64 {
+	used := make(map[game.Card]bool, 9)
+	for _, c := range holeCards {
+		used[c] = true
+	}
+	for _, c := range board {
+		used[c] = true
+	}
+	unseen := make([]game.Card, 0, 52)
+	for suit := game.Clubs; suit <= game.Spades; suit++ {
+		for rank := game.Two; rank <= game.Ace; rank++ {
+			if c := (game.Card{Suit: suit, Rank: rank}); !used[c] {
+				unseen = append(unseen, c)
+			}
+		}
+	}
+
+	mine := make([]game.Card, 0, 7)
+	theirs := make([]game.Card, 0, 7)
+	won := 0.0
+	for s := 0; s < bucketSamples; s++ {
+		// A partial shuffle picks the opponent's cards and the run-out
+		need := 2 + 5 - len(board)
+		for i := 0; i < need; i++ {
+			j := i + rng.Intn(len(unseen)-i)
+			unseen[i], unseen[j] = unseen[j], unseen[i]
+		}
+		runout := unseen[2:need]
+
+		mine = append(append(append(mine[:0], holeCards...), board...), runout...)
+		theirs = append(append(append(theirs[:0], unseen[:2]...), board...), runout...)
+		switch a, b := game.EvaluateHandValue(mine), game.EvaluateHandValue(theirs); {
+		case a > b:
+			won++
+		case a == b:
+			won += 0.5
+		}
+	}
+	return won / bucketSamples
+}
+
+// holdemState is a hand of abstracted hold'em. Histories use f to fold, c
+// to check or call and r to bet or raise.
+type holdemState struct {
+	game    *Holdem
+	deal    *holdemDeal
+	street  int // 0 to 3 for preflop to river, 4 once the river is over
+	history [4]string
+	put     [2]int // Chips each player has put in
+	bets    int    // Bets and raises this street
+	folded  int    // Player who folded, or -1
+}
+
+func (s holdemState) Terminal() bool {
+	return s.folded >= 0 || s.street == 4
+}
+
+func (s holdemState) Utility() float64 {
+	winner := s.deal.winner
+	if s.folded >= 0 {
+		winner = 1 - s.folded
+	}
+	switch winner {
+	case 0:
+		return float64(s.put[1])
+	case 1:
+		return -float64(s.put[0])
+	}
+	return 0
+}
+
+// Player is the small blind first before the flop, the big blind after
+func (s holdemState) Player() int {
+	first := 1
+	if s.street == 0 {
+		first = 0
+	}
+	return (first + len(s.history[s.street])) % 2
+}
+
+func (s holdemState) Actions() []string {
+	p := s.Player()
+	facing := s.put[1-p] > s.put[p]
+	switch {
+	case facing && s.bets < s.game.MaxBets:
+		return []string{"f", "c", "r"}
+	case facing:
+		return []string{"f", "c"}
+	}
+	return []string{"c", "r"}
+}
+
+// holdemBetSize is the limit bet on a street
+func holdemBetSize(street int) int {
+	if street < 2 {
+		return 2
+	}
+	return 4
+}
+
+func (s holdemState) Play(action string) State {
+	p := s.Player()
+	s.history[s.street] += action
+	switch action {
+	case "f":
+		s.folded = p
+	case "c":
+		s.put[p] = s.put[1-p]
+		// A call closes the street unless it is the small blind limping
+		if len(s.history[s.street]) >= 2 {
+			s.street++
+			s.bets = 0
+		}
+	case "r":
+		s.put[p] = s.put[1-p] + holdemBetSize(s.street)
+		s.bets++
+	}
+	return s
+}
+
+func (s holdemState) InfoSet() string {
+	return HoldemInfoSet(s.deal.buckets[s.Player()][:s.street+1], s.history[:s.street+1])
+}
+
+// HoldemInfoSet builds a hold'em information set key from the player's
+// bucket and the actions on each street so far, e.g. "4:rc/2:c"
+func HoldemInfoSet(buckets []int, history []string) string {
+	var b strings.Builder
+	for street := range buckets {
+		if street > 0 {
+			b.WriteByte('/')
+		}
+		b.WriteString(strconv.Itoa(buckets[street]))
+		b.WriteByte(':')
+		b.WriteString(history[street])
+	}
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package cfr
+
+import "poker-room/internal/game"
+
+// Kuhn is Kuhn poker: a three-card deck of J, Q and K, one card each, an
+// ante of 1 and a single bet of 1. Its value to the first player is -1/18.
+type Kuhn struct{}
+
+func (Kuhn) Name() string { return "kuhn" }
+
+// Deal deals a card to each player
+func (Kuhn) Deal(rng game.RNG) State {
+	cards := [3]int{0, 1, 2}
+	for i := len(cards) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	return kuhnState{cards: [2]int{cards[0], cards[1]}}
+}
+
+// kuhnState is a hand of Kuhn poker. The history is p for pass and b for
+// bet.
+type kuhnState struct {
+	cards   [2]int
+	history string
+}
+
+func (s kuhnState) Terminal() bool {
+	switch s.history {
+	case "pp", "bp", "bb", "pbp", "pbb":
+		return true
+	}
+	return false
+}
+
+func (s kuhnState) Utility() float64 {
+	showdown := 1.0
+	if s.cards[1] > s.cards[0] {
+		showdown = -1
+	}
+	switch s.history {
+	case "bp":
+		return 1
+	case "pbp":
+		return -1
+	case "pp":
+		return showdown
+	}
+	return 2 * showdown
+}
+
+func (s kuhnState) Player() int       { return len(s.history) % 2 }
+func (s kuhnState) Actions() []string { return []string{"p", "b"} }
+
+func (s kuhnState) Play(action string) State {
+	return kuhnState{cards: s.cards, history: s.history + action}
+}
+
+func (s kuhnState) InfoSet() string {
+	return string("JQK"[s.cards[s.Player()]]) + s.history
+}
@@ -0,0 +1,107 @@
+package cfr
+
+import "poker-room/internal/game"
+
+// Leduc is Leduc hold'em: a six-card deck of two each of J, Q and K, one
+// private card each and one public card after the first round. Each
+// player antes 1, bets are 2 in the first round and 4 in the second, and
+// each round allows a bet and a raise. A pair with the public card wins,
+// then the higher card.
+type Leduc struct{}
+
+func (Leduc) Name() string { return "leduc" }
+
+// Deal deals the private cards and the public card
+func (Leduc) Deal(rng game.RNG) State {
+	deck := [6]int{0, 0, 1, 1, 2, 2}
+	for i := len(deck) - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		deck[i], deck[j] = deck[j], deck[i]
+	}
+	return leducState{
+		cards:  [2]int{deck[0], deck[1]},
+		board:  deck[2],
+		folded: -1,
+		put:    [2]int{1, 1},
+	}
+}
+
+// leducState is a hand of Leduc hold'em. Histories use f to fold, c to
+// check or call and r to bet or raise.
+type leducState struct {
+	cards   [2]int
+	board   int
+	round   int
+	history [2]string
+	put     [2]int // Chips each player has put in
+	raises  int    // This round
+	folded  int    // Player who folded, or -1
+}
+
+const leducMaxRaises = 2
+
+func (s leducState) Terminal() bool {
+	return s.folded >= 0 || s.round == 2
+}
+
+func (s leducState) Utility() float64 {
+	switch s.folded {
+	case 0:
+		return -float64(s.put[0])
+	case 1:
+		return float64(s.put[1])
+	}
+	mine, theirs := s.cards[0], s.cards[1]
+	switch {
+	case mine == theirs:
+		return 0
+	case mine == s.board:
+		return float64(s.put[1])
+	case theirs == s.board:
+		return -float64(s.put[0])
+	case mine > theirs:
+		return float64(s.put[1])
+	}
+	return -float64(s.put[0])
+}
+
+func (s leducState) Player() int { return len(s.history[s.round]) % 2 }
+
+func (s leducState) Actions() []string {
+	p := s.Player()
+	facing := s.put[1-p] > s.put[p]
+	switch {
+	case facing && s.raises < leducMaxRaises:
+		return []string{"f", "c", "r"}
+	case facing:
+		return []string{"f", "c"}
+	}
+	return []string{"c", "r"}
+}
+
+func (s leducState) Play(action string) State {
+	p := s.Player()
+	s.history[s.round] += action
+	switch action {
+	case "f":
+		s.folded = p
+	case "c":
+		s.put[p] = s.put[1-p]
+		if len(s.history[s.round]) >= 2 {
+			s.round++
+			s.raises = 0
+		}
+	case "r":
+		s.put[p] = s.put[1-p] + 2*(s.round+1)
+		s.raises++
+	}
+	return s
+}
+
+func (s leducState) InfoSet() string {
+	key := string("JQK"[s.cards[s.Player()]]) + ":" + s.history[0]
+	if s.round == 1 {
+		key += "|" + string("JQK"[s.board]) + ":" + s.history[1]
+	}
+	return key
+}
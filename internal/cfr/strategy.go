@@ -0,0 +1,60 @@
+package cfr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"poker-room/internal/game"
+)
+
+// Strategy is a trained strategy: for each information set, how often to
+// take each action
+type Strategy struct {
+	Game       string                        `json:"game"`
+	Iterations int                           `json:"iterations"`
+	InfoSets   map[string]map[string]float64 `json:"infoSets"`
+}
+
+// Probabilities returns how often to take each of actions at an
+// information set, evenly if training never reached it
+func (s *Strategy) Probabilities(infoSet string, actions []string) []float64 {
+	probabilities := make([]float64, len(actions))
+	known := s.InfoSets[infoSet]
+	total := 0.0
+	for i, a := range actions {
+		probabilities[i] = known[a]
+		total += probabilities[i]
+	}
+	for i := range probabilities {
+		if total > 0 {
+			probabilities[i] /= total
+		} else {
+			probabilities[i] = 1 / float64(len(actions))
+		}
+	}
+	return probabilities
+}
+
+// Choose picks one of actions at an information set by the strategy
+func (s *Strategy) Choose(infoSet string, actions []string, rng game.RNG) string {
+	return actions[sample(s.Probabilities(infoSet, actions), rng)]
+}
+
+// Save writes the strategy as JSON
+func (s *Strategy) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// LoadStrategy reads a strategy written by Save
+func LoadStrategy(r io.Reader) (*Strategy, error) {
+	var s Strategy
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, fmt.Errorf("strategy: %w", err)
+	}
+	if s.Game == "" || s.InfoSets == nil {
+		return nil, errors.New("strategy: missing game or information sets")
+	}
+	return &s, nil
+}
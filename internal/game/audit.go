@@ -0,0 +1,241 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// AuditSignificance is the p-value below which an audit check fails
+const AuditSignificance = 0.001
+
+// AuditCheck is the outcome of one statistical test
+type AuditCheck struct {
+	Name      string  `json:"name"`
+	Statistic float64 `json:"statistic"`
+	DOF       int     `json:"dof"` // Degrees of freedom, 0 when not a chi-squared test
+	PValue    float64 `json:"pValue"`
+	Passed    bool    `json:"passed"`
+	Detail    string  `json:"detail"`
+}
+
+// ShuffleAuditReport summarizes the uniformity of many Deck shuffles
+type ShuffleAuditReport struct {
+	RNG      string       `json:"rng"`
+	Shuffles int          `json:"shuffles"`
+	Checks   []AuditCheck `json:"checks"`
+	Passed   bool         `json:"passed"`
+}
+
+// RunShuffleAudit shuffles a fresh deck with rng the given number of times
+// and tests the resulting permutations for uniformity: card positions,
+// which card follows which, and starting-hand frequencies. The chi-squared
+// tests need a few thousand shuffles to be accurate.
+func RunShuffleAudit(name string, rng RNG, shuffles int) ShuffleAuditReport {
+	fresh := NewDeck().cards
+	deck := NewDeckWithRNG(rng)
+
+	var positions [52][52]int  // [position][card index]
+	var successors [52][52]int // [card index][index of the card after it]
+	startingHands := make(map[string]int, 169)
+
+	for n := 0; n < shuffles; n++ {
+		copy(deck.cards, fresh)
+		deck.Shuffle()
+
+		for pos, c := range deck.cards {
+			positions[pos][cardIndex(c)]++
+		}
+		for pos := 0; pos < 51; pos++ {
+			successors[cardIndex(deck.cards[pos])][cardIndex(deck.cards[pos+1])]++
+		}
+		startingHands[CanonicalHand(deck.cards[:2])]++
+	}
+
+	report := ShuffleAuditReport{RNG: name, Shuffles: shuffles, Passed: true}
+	report.Checks = append(report.Checks,
+		positionCheck(positions, shuffles),
+		successorCheck(successors, shuffles),
+		startingHandCheck(startingHands, shuffles),
+	)
+	for _, c := range report.Checks {
+		report.Passed = report.Passed && c.Passed
+	}
+	return report
+}
+
+// positionCheck tests that every card is equally likely in every position
+func positionCheck(positions [52][52]int, shuffles int) AuditCheck {
+	expected := float64(shuffles) / 52
+	stat := 0.0
+	worstPos, worstCard, worstDev := 0, 0, 0.0
+	for pos := range positions {
+		for card, observed := range positions[pos] {
+			diff := float64(observed) - expected
+			stat += diff * diff / expected
+			if dev := math.Abs(diff) / expected; dev > worstDev {
+				worstPos, worstCard, worstDev = pos, card, dev
+			}
+		}
+	}
+
+	// Rows and columns each sum to the number of shuffles
+	dof := 51 * 51
+	p := chiSquaredPValue(stat, dof)
+	return AuditCheck{
+		Name:      "card position uniformity",
+		Statistic: stat,
+		DOF:       dof,
+		PValue:    p,
+		Passed:    p >= AuditSignificance,
+		Detail: fmt.Sprintf("largest deviation %.2f%% for %s at position %d",
+			worstDev*100, NewDeck().cards[worstCard].ShortString(), worstPos+1),
+	}
+}
+
+// successorCheck tests that every card is equally likely to follow every
+// other, wherever they are in the deck. A card follows a given card in
+// 1 shuffle in 52 on average, so each of the 52*51 ordered pairs is
+// expected shuffles/52 times.
+func successorCheck(successors [52][52]int, shuffles int) AuditCheck {
+	expected := float64(shuffles) / 52
+	stat := 0.0
+	worstCard, worstNext, worstDev := 0, 0, 0.0
+	for card := range successors {
+		for next, observed := range successors[card] {
+			if next == card {
+				continue
+			}
+			diff := float64(observed) - expected
+			stat += diff * diff / expected
+			if dev := math.Abs(diff) / expected; dev > worstDev {
+				worstCard, worstNext, worstDev = card, next, dev
+			}
+		}
+	}
+
+	// A card has at most one successor a shuffle and the last card none,
+	// which leaves the statistic a mean of 51*51 as for the positions
+	dof := 51 * 51
+	p := chiSquaredPValue(stat, dof)
+	fresh := NewDeck().cards
+	return AuditCheck{
+		Name:      "card succession",
+		Statistic: stat,
+		DOF:       dof,
+		PValue:    p,
+		Passed:    p >= AuditSignificance,
+		Detail: fmt.Sprintf("largest deviation %.2f%% for %s followed by %s",
+			worstDev*100, fresh[worstCard].ShortString(), fresh[worstNext].ShortString()),
+	}
+}
+
+// startingHandCheck tests the first two cards against the 169 starting
+// hand frequencies: 6 combinations per pair, 4 suited and 12 offsuit
+func startingHandCheck(counts map[string]int, shuffles int) AuditCheck {
+	stat := 0.0
+	worstHand, worstDev := "", 0.0
+	for _, notation := range StartingHandNotations() {
+		combos := len(HandCombos(notation))
+		expected := float64(shuffles) * float64(combos) / 1326
+		diff := float64(counts[notation]) - expected
+		stat += diff * diff / expected
+		if dev := math.Abs(diff) / expected; dev > worstDev {
+			worstHand, worstDev = notation, dev
+		}
+	}
+
+	dof := 168
+	p := chiSquaredPValue(stat, dof)
+	return AuditCheck{
+		Name:      "starting hand frequency",
+		Statistic: stat,
+		DOF:       dof,
+		PValue:    p,
+		Passed:    p >= AuditSignificance,
+		Detail:    fmt.Sprintf("largest deviation %.2f%% for %s", worstDev*100, worstHand),
+	}
+}
+
+// String formats the report for an audit record
+func (r ShuffleAuditReport) String() string {
+	var b strings.Builder
+	result := "PASS"
+	if !r.Passed {
+		result = "FAIL"
+	}
+	fmt.Fprintf(&b, "Shuffle audit: %s, %d shuffles: %s\n", r.RNG, r.Shuffles, result)
+	for _, c := range r.Checks {
+		result = "pass"
+		if !c.Passed {
+			result = "FAIL"
+		}
+		fmt.Fprintf(&b, "  %-30s %s  statistic=%.4f", c.Name, result, c.Statistic)
+		if c.DOF > 0 {
+			fmt.Fprintf(&b, " dof=%d", c.DOF)
+		}
+		fmt.Fprintf(&b, " p=%.4f  (%s)\n", c.PValue, c.Detail)
+	}
+	return b.String()
+}
+
+func cardIndex(c Card) int {
+	return int(c.Suit)*13 + int(c.Rank-Two)
+}
+
+// chiSquaredPValue returns the probability of a chi-squared statistic at
+// least this large with dof degrees of freedom
+func chiSquaredPValue(stat float64, dof int) float64 {
+	if stat <= 0 {
+		return 1
+	}
+	return upperGammaRegularized(float64(dof)/2, stat/2)
+}
+
+// upperGammaRegularized computes Q(a, x) by series or continued fraction
+func upperGammaRegularized(a, x float64) float64 {
+	const (
+		maxIterations = 1000
+		epsilon       = 1e-14
+	)
+	lgammaA, _ := math.Lgamma(a)
+
+	if x < a+1 {
+		// Series for P(a, x)
+		sum, term := 1/a, 1/a
+		for n := 1; n < maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lgammaA)
+	}
+
+	// Lentz's continued fraction for Q(a, x)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < maxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgammaA) * h
+}
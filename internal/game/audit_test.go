@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+// stickyRNG leaves a card where it is in one Fisher-Yates step in four
+type stickyRNG struct {
+	RNG
+}
+
+func (r stickyRNG) Intn(n int) int {
+	if r.RNG.Intn(4) == 0 {
+		return n - 1
+	}
+	return r.RNG.Intn(n)
+}
+
+func TestRunShuffleAudit(t *testing.T) {
+	tests := []struct {
+		name       string
+		rng        RNG
+		wantPassed bool
+	}{
+		{"seeded", NewSeededRNG(1), true},
+		{"another seed", NewSeededRNG(2), true},
+		{"sticky", stickyRNG{NewSeededRNG(3)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := RunShuffleAudit(tt.name, tt.rng, 3000)
+			if report.Passed != tt.wantPassed {
+				t.Errorf("passed = %v, want %v\n%s", report.Passed, tt.wantPassed, report)
+			}
+			if len(report.Checks) != 3 {
+				t.Errorf("got %d checks, want 3", len(report.Checks))
+			}
+		})
+	}
+}
+
+// A cut deck has every card equally likely in every position, but always
+// the same card after each one
+func TestSuccessorCheckCatchesCuts(t *testing.T) {
+	rng := NewSeededRNG(4)
+	fresh := NewDeck().cards
+	var positions, successors [52][52]int
+	const shuffles = 3000
+	for n := 0; n < shuffles; n++ {
+		cut := rng.Intn(52)
+		deck := append(append([]Card{}, fresh[cut:]...), fresh[:cut]...)
+		for pos, c := range deck {
+			positions[pos][cardIndex(c)]++
+		}
+		for pos := 0; pos < 51; pos++ {
+			successors[cardIndex(deck[pos])][cardIndex(deck[pos+1])]++
+		}
+	}
+
+	if c := positionCheck(positions, shuffles); !c.Passed {
+		t.Errorf("position check failed a cut deck: p=%g", c.PValue)
+	}
+	if c := successorCheck(successors, shuffles); c.Passed {
+		t.Errorf("succession check passed a cut deck: p=%g", c.PValue)
+	}
+}
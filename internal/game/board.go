@@ -0,0 +1,132 @@
+package game
+
+// BoardTexture describes the community cards
+type BoardTexture struct {
+	Paired    bool `json:"paired"`    // At least two cards share a rank
+	Trips     bool `json:"trips"`     // Three or more cards share a rank
+	Monotone  bool `json:"monotone"`  // Every card is the same suit
+	Rainbow   bool `json:"rainbow"`   // No two cards share a suit
+	Connected bool `json:"connected"` // A straight is possible
+
+	PossibleStraights []Rank `json:"possibleStraights"` // High card of each straight a holding can make
+	FlushSuits        []Suit `json:"flushSuits"`        // Suits with enough cards for a flush
+
+	Nuts         HandResult `json:"nuts"`
+	NutHoldings  [][]Card   `json:"nutHoldings"` // Every two-card holding that makes the nuts
+	HoldingCount int        `json:"holdingCount"`
+}
+
+// HandStrength places a holding among every holding possible on a board
+type HandStrength struct {
+	Hand       HandResult `json:"hand"`
+	Rank       int        `json:"rank"` // 1 for the nuts; holdings that beat this one plus one
+	Better     int        `json:"better"`
+	Ties       int        `json:"ties"`
+	Worse      int        `json:"worse"`
+	Percentile float64    `json:"percentile"` // Share of holdings beaten, ties counting half
+}
+
+// AnalyzeBoard classifies the texture of a flop, turn or river and finds
+// the nuts over all two-card holdings
+func AnalyzeBoard(board []Card) BoardTexture {
+	texture := BoardTexture{}
+	if len(board) < 3 {
+		return texture
+	}
+
+	var rankCounts [Ace + 1]int
+	var suitCounts [Spades + 1]int
+	for _, c := range board {
+		rankCounts[c.Rank]++
+		suitCounts[c.Suit]++
+	}
+
+	texture.Rainbow = true
+	for _, n := range rankCounts {
+		texture.Paired = texture.Paired || n >= 2
+		texture.Trips = texture.Trips || n >= 3
+	}
+	for suit, n := range suitCounts {
+		if n == len(board) {
+			texture.Monotone = true
+		}
+		if n >= 2 {
+			texture.Rainbow = false
+		}
+		if n >= 3 {
+			texture.FlushSuits = append(texture.FlushSuits, Suit(suit))
+		}
+	}
+
+	present := rankSet(board)
+	for _, low := range straightWindows() {
+		if countInWindow(present, low) >= 3 {
+			ranks := windowRanks(low)
+			texture.PossibleStraights = append(texture.PossibleStraights, ranks[4])
+		}
+	}
+	texture.Connected = len(texture.PossibleStraights) > 0
+
+	// Find the nuts by trying every holding
+	stub := remainingCards(board)
+	for i := 0; i < len(stub); i++ {
+		for j := i + 1; j < len(stub); j++ {
+			holding := []Card{stub[i], stub[j]}
+			hand := EvaluateBestHand(append(append([]Card{}, holding...), board...))
+			texture.HoldingCount++
+
+			cmp := 1
+			if len(texture.NutHoldings) > 0 {
+				cmp = CompareHands(hand, texture.Nuts)
+			}
+			switch cmp {
+			case 1:
+				texture.Nuts = hand
+				texture.NutHoldings = [][]Card{holding}
+			case 0:
+				texture.NutHoldings = append(texture.NutHoldings, holding)
+			}
+		}
+	}
+
+	return texture
+}
+
+// RankHandStrength compares hole cards against every other holding that
+// could be dealt on the board
+func RankHandStrength(holeCards []Card, board []Card) HandStrength {
+	hand := EvaluateBestHand(append(append([]Card{}, holeCards...), board...))
+	strength := HandStrength{Hand: hand}
+	if len(board) < 3 {
+		return strength
+	}
+
+	stub := remainingCards(append(append([]Card{}, board...), holeCards...))
+	for i := 0; i < len(stub); i++ {
+		for j := i + 1; j < len(stub); j++ {
+			other := EvaluateBestHand(append([]Card{stub[i], stub[j]}, board...))
+			switch CompareHands(other, hand) {
+			case 1:
+				strength.Better++
+			case 0:
+				strength.Ties++
+			default:
+				strength.Worse++
+			}
+		}
+	}
+
+	total := strength.Better + strength.Ties + strength.Worse
+	strength.Rank = strength.Better + 1
+	strength.Percentile = (float64(strength.Worse) + float64(strength.Ties)/2) / float64(total) * 100
+	return strength
+}
+
+// HandStrengths ranks each remaining player's hand on the current board
+func (g *PokerGame) HandStrengths() map[string]HandStrength {
+	strengths := make(map[string]HandStrength)
+	for _, p := range g.contenders() {
+		strengths[p.ID] = RankHandStrength(p.HoleCards, g.CommunityCards)
+	}
+	return strengths
+}
@@ -0,0 +1,81 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeBoard(t *testing.T) {
+	type flags struct{ paired, trips, monotone, rainbow, connected bool }
+	tests := []struct {
+		name      string
+		board     string
+		flags     flags
+		straights []Rank
+		flushes   []Suit
+		nuts      string
+		holdings  int // Holdings that make the nuts
+		total     int
+	}{
+		{name: "dry rainbow", board: "Kh8c2s", flags: flags{rainbow: true}, nuts: "Three of a Kind, kings", holdings: 3, total: 1176},
+		{name: "monotone and connected", board: "9h8h7h", flags: flags{monotone: true, connected: true}, straights: []Rank{Nine, Ten, Jack}, flushes: []Suit{Hearts}, nuts: "Straight Flush, Jack high", holdings: 1, total: 1176},
+		{name: "paired", board: "QsQd4c", flags: flags{paired: true, rainbow: true}, nuts: "Four of a Kind, queens", holdings: 1, total: 1176},
+		// The last seven with an ace kicker
+		{name: "trips", board: "7c7d7h", flags: flags{paired: true, trips: true, rainbow: true}, nuts: "Four of a Kind, sevens", holdings: 4, total: 1176},
+		{name: "three to a flush on the turn", board: "Jc9c2d5c", flushes: []Suit{Clubs}, nuts: "Flush, Ace high", holdings: 1, total: 1128},
+		// Any six and seven beat the wheel on the board
+		{name: "straight on the river", board: "Ah2d3c4s5h", flags: flags{connected: true}, straights: []Rank{Five, Six, Seven}, nuts: "Straight, Seven high", holdings: 16, total: 1081},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeBoard(mustCards(t, tt.board))
+			if f := (flags{got.Paired, got.Trips, got.Monotone, got.Rainbow, got.Connected}); f != tt.flags {
+				t.Errorf("flags = %+v, want %+v", f, tt.flags)
+			}
+			if !reflect.DeepEqual(got.PossibleStraights, tt.straights) {
+				t.Errorf("straights = %v, want %v", got.PossibleStraights, tt.straights)
+			}
+			if !reflect.DeepEqual(got.FlushSuits, tt.flushes) {
+				t.Errorf("flush suits = %v, want %v", got.FlushSuits, tt.flushes)
+			}
+			if got.Nuts.Description != tt.nuts || len(got.NutHoldings) != tt.holdings {
+				t.Errorf("nuts = %q from %d holdings, want %q from %d", got.Nuts.Description, len(got.NutHoldings), tt.nuts, tt.holdings)
+			}
+			if got.HoldingCount != tt.total {
+				t.Errorf("%d holdings, want %d", got.HoldingCount, tt.total)
+			}
+		})
+	}
+
+	if got := AnalyzeBoard(mustCards(t, "Kh8c")); !reflect.DeepEqual(got, BoardTexture{}) {
+		t.Errorf("preflop texture = %+v, want none", got)
+	}
+}
+
+func TestRankHandStrength(t *testing.T) {
+	board := "Kh8c2s"
+	tests := []struct {
+		hole               string
+		rank, better, ties int
+		worse              int
+	}{
+		{hole: "KsKd", rank: 1, worse: 1081},
+		// Only the three sets of kings are better
+		{hole: "8d8s", rank: 4, better: 3, worse: 1078},
+		// Sets, two pairs and aces beat top pair, top kicker, and the
+		// other six ace-kings tie it
+		{hole: "AsKs", rank: 32, better: 31, ties: 6, worse: 1044},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hole, func(t *testing.T) {
+			got := RankHandStrength(mustCards(t, tt.hole), mustCards(t, board))
+			if got.Rank != tt.rank || got.Better != tt.better || got.Ties != tt.ties || got.Worse != tt.worse {
+				t.Errorf("got rank %d (%d/%d/%d), want %d (%d/%d/%d)", got.Rank, got.Better, got.Ties, got.Worse, tt.rank, tt.better, tt.ties, tt.worse)
+			}
+			want := (float64(tt.worse) + float64(tt.ties)/2) / 1081 * 100
+			if got.Percentile != want {
+				t.Errorf("percentile = %g, want %g", got.Percentile, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BotAction is a bot's decision, as ProcessAction takes it
+type BotAction struct {
+	Action ActionType
+	Amount int // Bet or raise to, as for ProcessAction
+}
+
+// Bot decides the actions for a seat. It sees only what a human in the
+// seat would: the public state and the seat's own hole cards. It is asked
+// only when it is the seat's turn, so the seat is state.CurrentPlayerID.
+type Bot interface {
+	Decide(state *GameState, holeCards []Card) BotAction
+}
+
+// PlayBots plays the turns of the seats bots control, keyed by player ID,
+// until a human is to act, the hand is over or its board is being run out
+// a street at a time. An action the engine rejects is replaced by a check,
+// or a fold when the bot cannot check.
+func (g *PokerGame) PlayBots(bots map[string]Bot) error {
+	for g.HandNumber > 0 && !g.HandComplete && !g.runningOut {
+		id := g.Players[g.CurrentIndex].ID
+		bot := bots[id]
+		if bot == nil {
+			return nil
+		}
+
+		decision := bot.Decide(g.GetState(), g.GetPlayerCards(id))
+		if err := g.ProcessAction(id, decision.Action, decision.Amount); err == nil {
+			continue
+		}
+		fallback := Fold
+		if g.CurrentBet <= g.Players[g.CurrentIndex].CurrentBet {
+			fallback = Check
+		}
+		if err := g.ProcessAction(id, fallback, 0); err != nil {
+			return fmt.Errorf("bot %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// BotStyle is how a rule-based bot plays
+type BotStyle string
+
+const (
+	TightAggressive BotStyle = "tightAggressive"
+	LoosePassive    BotStyle = "loosePassive"
+	Maniac          BotStyle = "maniac"
+	CallingStation  BotStyle = "callingStation"
+)
+
+// BotStyles lists the rule-based styles
+func BotStyles() []BotStyle {
+	return []BotStyle{TightAggressive, LoosePassive, Maniac, CallingStation}
+}
+
+// ErrUnknownBotStyle is returned for a style with no rules
+var ErrUnknownBotStyle = errors.New("unknown bot style")
+
+// String returns the style's display name
+func (s BotStyle) String() string {
+	if r, ok := botRules[s]; ok {
+		return r.name
+	}
+	return string(s)
+}
+
+// handStrength ranks a hand after the flop for a rule-based bot
+type handStrength int
+
+const (
+	nothing  handStrength = iota
+	drawing               // A flush or open-ended straight draw
+	weakMade              // A pair below top pair
+	topPair               // Top pair or an overpair
+	strong                // Two pair or better, not counting the board's
+)
+
+// ruleSet is the rules for a style. Preflop hands are judged by Chen score.
+type ruleSet struct {
+	name      string
+	playChen  int          // To call before the flop
+	raiseChen int          // To raise before the flop
+	betWith   handStrength // Weakest hand bet or raised after the flop
+	callWith  handStrength // Weakest hand called with after the flop
+	bluff     int          // Percent of other spots bet or raised anyway
+	sizing    int          // Bets and raises as a percentage of the pot
+}
+
+var botRules = map[BotStyle]ruleSet{
+	TightAggressive: {name: "Tight-aggressive", playChen: 8, raiseChen: 10, betWith: topPair, callWith: drawing, bluff: 10, sizing: 70},
+	LoosePassive:    {name: "Loose-passive", playChen: 3, raiseChen: 14, betWith: strong, callWith: drawing, bluff: 0, sizing: 50},
+	Maniac:          {name: "Maniac", playChen: 1, raiseChen: 5, betWith: weakMade, callWith: nothing, bluff: 50, sizing: 100},
+	CallingStation:  {name: "Calling station", playChen: -1, raiseChen: 99, betWith: strong, callWith: nothing, bluff: 0, sizing: 50},
+}
+
+// RuleBot plays a fixed style by simple rules
+type RuleBot struct {
+	Style BotStyle
+	RNG   RNG // For bluffs
+
+	rules ruleSet
+}
+
+// NewRuleBot returns a bot playing a style
+func NewRuleBot(style BotStyle, rng RNG) (*RuleBot, error) {
+	rules, ok := botRules[style]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownBotStyle, style)
+	}
+	return &RuleBot{Style: style, RNG: rng, rules: rules}, nil
+}
+
+// Decide plays the current turn by the style's rules
+func (b *RuleBot) Decide(state *GameState, holeCards []Card) BotAction {
+	me := statePlayer(state, state.CurrentPlayerID)
+	if me == nil {
+		return BotAction{Action: Fold}
+	}
+	toCall := state.CurrentBet - me.CurrentBet
+	bluff := b.rules.bluff > 0 && b.RNG.Intn(100) < b.rules.bluff
+
+	var raise, play bool
+	if len(state.CommunityCards) == 0 {
+		chen := ChenScore(holeCards)
+		raise = chen >= b.rules.raiseChen || (bluff && chen >= b.rules.playChen)
+		play = chen >= b.rules.playChen
+	} else {
+		strength := postflopStrength(holeCards, state.CommunityCards)
+		raise = strength >= b.rules.betWith || bluff
+		play = strength >= b.rules.callWith
+		if strength == drawing && b.rules.callWith == drawing {
+			// Draws call only when the price is right
+			play = CalculatePotOdds(toCall, state.Pot) <= CalculateOutsOdds(drawOuts(holeCards, state.CommunityCards), 5-len(state.CommunityCards))
+		}
+	}
+
+	switch {
+	case raise:
+		return sizedBet(state, me, b.rules.sizing)
+	case toCall <= 0:
+		return BotAction{Action: Check}
+	case play:
+		return BotAction{Action: Call}
+	}
+	return BotAction{Action: Fold}
+}
+
+// statePlayer finds a player in a state
+func statePlayer(state *GameState, id string) *PlayerState {
+	for i := range state.Players {
+		if state.Players[i].ID == id {
+			return &state.Players[i]
+		}
+	}
+	return nil
+}
+
+// sizedBet bets, or raises by, a percentage of the pot, going all-in when
+// that is most of the stack
+func sizedBet(state *GameState, me *PlayerState, percent int) BotAction {
+	toCall := state.CurrentBet - me.CurrentBet
+	size := (state.Pot + max(toCall, 0)) * percent / 100
+
+	action, to := Bet, max(size, state.BigBlind)
+	if state.CurrentBet > 0 {
+		action, to = Raise, state.CurrentBet+max(size, state.MinRaise)
+	}
+	if to-me.CurrentBet >= me.Chips*3/4 {
+		return BotAction{Action: AllIn}
+	}
+	return BotAction{Action: action, Amount: to}
+}
+
+// postflopStrength sorts a hand on the flop, turn or river into a few
+// bands, ignoring whatever the board makes by itself
+func postflopStrength(holeCards, board []Card) handStrength {
+	made := EvaluateHandValue(append(append([]Card{}, holeCards...), board...)).Rank()
+	if made > boardOnlyRank(board) {
+		if made >= TwoPair {
+			return strong
+		}
+		if made == OnePair {
+			top := Two
+			for _, c := range board {
+				top = max(top, c.Rank)
+			}
+			pocketPair := len(holeCards) == 2 && holeCards[0].Rank == holeCards[1].Rank
+			for _, c := range holeCards {
+				if c.Rank == top || (pocketPair && c.Rank > top) {
+					return topPair
+				}
+			}
+			return weakMade
+		}
+	}
+	if drawOuts(holeCards, board) > 0 {
+		return drawing
+	}
+	return nothing
+}
+
+// drawOuts counts the outs to a flush or eight-out straight draw, before
+// the river
+func drawOuts(holeCards, board []Card) int {
+	if len(board) < 3 || len(board) >= 5 {
+		return 0
+	}
+	made := EvaluateHandValue(append(append([]Card{}, holeCards...), board...)).Rank()
+	outs := 0
+	for _, d := range classifyDraws(holeCards, board, made) {
+		switch d {
+		case FlushDraw:
+			outs += 9
+		case OpenEndedStraightDraw, DoubleGutshotStraightDraw:
+			outs += 8
+		}
+	}
+	// A combined draw shares two outs
+	return min(outs, 15)
+}
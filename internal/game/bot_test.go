@@ -0,0 +1,137 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// fixedRNG always draws the same number, below n
+type fixedRNG int
+
+func (r fixedRNG) Intn(n int) int { return min(int(r), n-1) }
+
+func TestRuleBotDecide(t *testing.T) {
+	tests := []struct {
+		name       string
+		style      BotStyle
+		rng        RNG
+		hole       string
+		board      string
+		pot        int
+		currentBet int
+		myBet      int
+		chips      int
+		want       BotAction
+	}{
+		// Before the flop, facing the big blind of 10 with 15 in the pot
+		{name: "raise aces", style: TightAggressive, hole: "As Ah", pot: 15, currentBet: 10, want: BotAction{Action: Raise, Amount: 27}},
+		{name: "fold rags", style: TightAggressive, hole: "7c 2d", pot: 15, currentBet: 10, want: BotAction{Action: Fold}},
+		{name: "check the option", style: TightAggressive, hole: "7c 2d", pot: 20, currentBet: 10, myBet: 10, want: BotAction{Action: Check}},
+		{name: "station calls rags", style: CallingStation, hole: "7c 2d", pot: 15, currentBet: 10, want: BotAction{Action: Call}},
+		{name: "short stack shoves", style: TightAggressive, hole: "As Ah", pot: 15, currentBet: 10, chips: 20, want: BotAction{Action: AllIn}},
+		// After the flop
+		{name: "bet top pair", style: TightAggressive, hole: "Kh Qd", board: "Ks 8d 3c", pot: 100, want: BotAction{Action: Bet, Amount: 70}},
+		{name: "bluff with nothing", style: TightAggressive, rng: fixedRNG(0), hole: "7c 2d", board: "Ks 8d 3h", pot: 100, want: BotAction{Action: Bet, Amount: 70}},
+		{name: "give up with nothing", style: TightAggressive, hole: "7c 2d", board: "Ks 8d 3h", pot: 150, currentBet: 50, want: BotAction{Action: Fold}},
+		{name: "draw at a price", style: TightAggressive, hole: "Ah 5h", board: "Kh 8h 3c", pot: 150, currentBet: 50, want: BotAction{Action: Call}},
+		{name: "draw too dear", style: TightAggressive, hole: "Ah 5h", board: "Kh 8h 3c", pot: 150, currentBet: 300, chips: 2000, want: BotAction{Action: Fold}},
+		{name: "passive raises two pair", style: LoosePassive, hole: "Ks 8c", board: "Kh 8h 3c", pot: 150, currentBet: 50, want: BotAction{Action: Raise, Amount: 150}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := tt.rng
+			if rng == nil {
+				rng = fixedRNG(99)
+			}
+			bot, err := NewRuleBot(tt.style, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chips := tt.chips
+			if chips == 0 {
+				chips = 1000
+			}
+			state := &GameState{
+				Players:         []PlayerState{{ID: "me", Chips: chips, CurrentBet: tt.myBet}, {ID: "them", Chips: 1000, CurrentBet: tt.currentBet}},
+				CurrentPlayerID: "me",
+				Pot:             tt.pot,
+				BigBlind:        10,
+				CurrentBet:      tt.currentBet,
+				MinRaise:        10,
+			}
+			if tt.board != "" {
+				state.CommunityCards = mustCards(t, tt.board)
+			}
+			if got := bot.Decide(state, mustCards(t, tt.hole)); got != tt.want {
+				t.Errorf("Decide() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewRuleBot("shark", nil); !errors.Is(err, ErrUnknownBotStyle) {
+		t.Errorf("unknown style: got %v, want %v", err, ErrUnknownBotStyle)
+	}
+}
+
+// badBot always makes a bet too small to be allowed
+type badBot struct{}
+
+func (badBot) Decide(state *GameState, holeCards []Card) BotAction {
+	return BotAction{Action: Bet, Amount: 1}
+}
+
+func TestPlayBotsFallback(t *testing.T) {
+	g := newTestGame(t, 8, "human", "bot")
+	bots := map[string]Bot{"bot": badBot{}}
+	if err := g.PlayBots(bots); err != nil {
+		t.Errorf("before a hand: %v", err)
+	}
+
+	// Heads-up the button acts first before the flop; play until the
+	// human has it
+	for hand := 0; hand < 2; hand++ {
+		if err := g.StartNewHand(); err != nil {
+			t.Fatal(err)
+		}
+		if g.Players[g.CurrentIndex].ID == "human" {
+			break
+		}
+		// Facing the big blind, the bot's rejected bet becomes a fold
+		if err := g.PlayBots(bots); err != nil {
+			t.Fatal(err)
+		}
+		if !g.HandComplete || !g.Players[1].IsFolded {
+			t.Fatal("bot on the button did not fold to the big blind")
+		}
+	}
+
+	// With nothing to call, its rejected bets become checks, and play
+	// stops at the human's turn on the flop
+	act(t, g, BotAction{Action: Call})
+	if err := g.PlayBots(bots); err != nil {
+		t.Fatal(err)
+	}
+	if g.HandComplete || g.Players[g.CurrentIndex].ID != "human" || g.BettingRound != Flop {
+		t.Fatalf("stopped at %s's turn, round %s, complete %v", g.Players[g.CurrentIndex].ID, g.BettingRound, g.HandComplete)
+	}
+	checks := 0
+	for _, e := range g.Events {
+		if e.Type == EventActionTaken && e.PlayerID == "bot" && e.HandNumber == g.HandNumber {
+			if e.Action != Check.String() {
+				t.Errorf("bot %s %d, want a check", e.Action, e.Amount)
+			}
+			checks++
+		}
+	}
+	if checks != 2 {
+		t.Errorf("bot checked %d times, want 2", checks)
+	}
+
+	act(t, g, BotAction{Action: Bet, Amount: 20})
+	if err := g.PlayBots(bots); err != nil {
+		t.Fatal(err)
+	}
+	if !g.HandComplete || !g.Players[1].IsFolded {
+		t.Errorf("bot did not fold to the bet")
+	}
+}
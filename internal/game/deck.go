@@ -1,8 +1,9 @@
 package game
 
 import (
+	"encoding/json"
 	"fmt"
-	"math/rand"
+	"strings"
 )
 
 // Suit represents a card suit
@@ -42,12 +43,23 @@ type Card struct {
 
 // Deck represents a deck of cards
 type Deck struct {
-	cards []Card
-	used  int
+	cards   []Card
+	used    int
+	rng     RNG
+	stacked bool // Order is fixed, Shuffle only resets
+
+	burned            []Card
+	discards          []Card
+	reshuffleDiscards bool
 }
 
-// NewDeck creates a standard 52-card deck
+// NewDeck creates a standard 52-card deck shuffled with crypto/rand
 func NewDeck() *Deck {
+	return NewDeckWithRNG(NewCryptoRNG())
+}
+
+// NewDeckWithRNG creates a standard 52-card deck shuffled with rng
+func NewDeckWithRNG(rng RNG) *Deck {
 	cards := make([]Card, 0, 52)
 
 	for suit := Clubs; suit <= Spades; suit++ {
@@ -59,28 +71,138 @@ func NewDeck() *Deck {
 	return &Deck{
 		cards: cards,
 		used:  0,
+		rng:   rng,
 	}
 }
 
 // Shuffle randomizes the deck
 func (d *Deck) Shuffle() {
+	d.burned, d.discards = nil, nil
+	if d.stacked {
+		d.used = 0
+		return
+	}
+
+	if d.rng == nil {
+		d.rng = NewCryptoRNG()
+	}
+
 	// Fisher-Yates shuffle
 	for i := len(d.cards) - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
+		j := d.rng.Intn(i + 1)
 		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
 	}
 	d.used = 0
 }
 
-// Draw takes a card from the deck
-func (d *Deck) Draw() Card {
+// Draw takes a card from the deck. When the deck is empty it reshuffles
+// the discards if enabled, and otherwise returns ErrDeckEmpty.
+func (d *Deck) Draw() (Card, error) {
 	if d.used >= len(d.cards) {
-		panic("no cards left in deck")
+		if !d.reshuffleDiscards || d.reshuffle() == 0 {
+			return Card{}, ErrDeckEmpty
+		}
 	}
 
 	card := d.cards[d.used]
 	d.used++
-	return card
+	return card, nil
+}
+
+// Burn draws a card face down, out of play
+func (d *Deck) Burn() (Card, error) {
+	card, err := d.Draw()
+	if err != nil {
+		return Card{}, err
+	}
+	d.burned = append(d.burned, card)
+	return card, nil
+}
+
+// peek returns the next n cards without drawing them
+func (d *Deck) peek(n int) ([]Card, error) {
+	if d == nil {
+		return nil, ErrNoDeck
+	}
+	if d.used+n > len(d.cards) {
+		return nil, ErrDeckEmpty
+	}
+	return append([]Card{}, d.cards[d.used:d.used+n]...), nil
+}
+
+// Burned returns the cards burned since the last shuffle
+func (d *Deck) Burned() []Card {
+	return append([]Card{}, d.burned...)
+}
+
+// Discard puts cards out of play, e.g. those thrown in a draw game
+func (d *Deck) Discard(cards ...Card) {
+	d.discards = append(d.discards, cards...)
+}
+
+// SetReshuffleDiscards makes Draw shuffle the discards and burn cards into
+// a new stub when the deck runs out, as draw games do, instead of failing
+func (d *Deck) SetReshuffleDiscards(enabled bool) {
+	d.reshuffleDiscards = enabled
+}
+
+// reshuffle shuffles the discards and burn cards into a new stub behind
+// the cards still in play, returning how many cards it now holds. The deck
+// keeps all its cards, so the next Shuffle deals from a full deck.
+func (d *Deck) reshuffle() int {
+	stub := append(append([]Card{}, d.discards...), d.burned...)
+	if len(stub) == 0 {
+		return 0
+	}
+	d.discards, d.burned = nil, nil
+	if d.rng == nil {
+		d.rng = NewCryptoRNG()
+	}
+
+	for i := len(stub) - 1; i > 0; i-- {
+		j := d.rng.Intn(i + 1)
+		stub[i], stub[j] = stub[j], stub[i]
+	}
+
+	out := make(map[Card]bool, len(stub))
+	for _, c := range stub {
+		out[c] = true
+	}
+	cards := make([]Card, 0, len(d.cards))
+	for _, c := range d.cards {
+		if !out[c] {
+			cards = append(cards, c)
+		}
+	}
+	d.used = len(cards)
+	d.cards = append(cards, stub...)
+	return len(stub)
+}
+
+// NewStackedDeck creates a deck whose top cards are order, in order, with
+// the rest of the 52 cards below them. Shuffling a stacked deck keeps the
+// order, so a scripted hand plays out exactly.
+func NewStackedDeck(order []Card) (*Deck, error) {
+	seen := make(map[Card]bool, 52)
+	cards := make([]Card, 0, 52)
+	for _, c := range order {
+		if c.Rank < Two || c.Rank > Ace || c.Suit < Clubs || c.Suit > Spades {
+			return nil, fmt.Errorf("invalid card %v", c)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("duplicate card %s", c.ShortString())
+		}
+		seen[c] = true
+		cards = append(cards, c)
+	}
+
+	for _, c := range NewDeck().cards {
+		if !seen[c] {
+			cards = append(cards, c)
+		}
+	}
+
+	return &Deck{cards: cards, stacked: true}, nil
 }
 
 // CardsRemaining returns the number of cards left
@@ -91,6 +213,65 @@ func (d *Deck) CardsRemaining() int {
 // Reset resets the deck without shuffling
 func (d *Deck) Reset() {
 	d.used = 0
+	d.burned, d.discards = nil, nil
+}
+
+// ParseCard parses a two-character card such as "As", "Td" or "10h"
+func ParseCard(s string) (Card, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+
+	rankPart, suitPart := strings.ToUpper(s[:len(s)-1]), strings.ToLower(s[len(s)-1:])
+	if rankPart == "10" {
+		rankPart = "T"
+	}
+	rank, ok := Rank(0), false
+	if len(rankPart) == 1 {
+		rank, ok = parseRankSymbol(rankPart[0])
+	}
+	if !ok {
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+
+	var suit Suit
+	switch suitPart {
+	case "c":
+		suit = Clubs
+	case "d":
+		suit = Diamonds
+	case "h":
+		suit = Hearts
+	case "s":
+		suit = Spades
+	default:
+		return Card{}, fmt.Errorf("invalid card %q", s)
+	}
+
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+// ParseCards parses a run of cards, with or without spaces ("AsKs", "2h 3h 4h")
+func ParseCards(s string) ([]Card, error) {
+	var cards []Card
+	compact := strings.Join(strings.Fields(s), "")
+	for i := 0; i < len(compact); {
+		size := 2
+		if strings.HasPrefix(compact[i:], "10") {
+			size = 3
+		}
+		if i+size > len(compact) {
+			return nil, fmt.Errorf("invalid card %q", compact[i:])
+		}
+		card, err := ParseCard(compact[i : i+size])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+		i += size
+	}
+	return cards, nil
 }
 
 // String representation methods
@@ -102,21 +283,7 @@ func (c Card) String() string {
 
 // ShortString returns a two-character representation (e.g., "As", "Kh")
 func (c Card) ShortString() string {
-	rank := ""
-	switch c.Rank {
-	case Ace:
-		rank = "A"
-	case King:
-		rank = "K"
-	case Queen:
-		rank = "Q"
-	case Jack:
-		rank = "J"
-	case Ten:
-		rank = "T"
-	default:
-		rank = fmt.Sprintf("%d", c.Rank)
-	}
+	rank := c.Rank.Symbol()
 
 	suit := ""
 	switch c.Suit {
@@ -231,12 +398,46 @@ func (r Rank) String() string {
 	}
 }
 
+// Symbol returns the one-character rank used in hand notation (e.g., "A", "T", "9")
+func (r Rank) Symbol() string {
+	switch r {
+	case Ace:
+		return "A"
+	case King:
+		return "K"
+	case Queen:
+		return "Q"
+	case Jack:
+		return "J"
+	case Ten:
+		return "T"
+	default:
+		return fmt.Sprintf("%d", r)
+	}
+}
+
 // MarshalJSON customizes JSON encoding for Card
 func (c Card) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf(`{"rank":"%s","suit":"%s","display":"%s"}`,
 		c.Rank.String(), c.Suit.String(), c.ShortString())), nil
 }
 
+// UnmarshalJSON decodes a card written by MarshalJSON
+func (c *Card) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Display string `json:"display"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	card, err := ParseCard(v.Display)
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
 // Utility functions for card comparisons
 
 // CompareRank compares two cards by rank only
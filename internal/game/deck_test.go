@@ -0,0 +1,220 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDeckDraw(t *testing.T) {
+	d := NewDeckWithRNG(NewSeededRNG(1))
+	d.Shuffle()
+	seen := make(map[Card]bool)
+	for range 52 {
+		c, err := d.Draw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seen[c] {
+			t.Fatalf("drew %s twice", c.ShortString())
+		}
+		seen[c] = true
+	}
+	if _, err := d.Draw(); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("draw from an empty deck: got %v, want %v", err, ErrDeckEmpty)
+	}
+	if _, err := d.Burn(); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("burn from an empty deck: got %v, want %v", err, ErrDeckEmpty)
+	}
+
+	// With nothing discarded there is nothing to reshuffle
+	d.SetReshuffleDiscards(true)
+	if _, err := d.Draw(); !errors.Is(err, ErrDeckEmpty) {
+		t.Errorf("reshuffle with no discards: got %v, want %v", err, ErrDeckEmpty)
+	}
+
+	d.Shuffle()
+	if d.CardsRemaining() != 52 {
+		t.Errorf("%d cards after a shuffle, want 52", d.CardsRemaining())
+	}
+}
+
+func TestDeckBurn(t *testing.T) {
+	d, err := NewStackedDeck(mustCards(t, "2c3d4h5s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	burned, err := d.Burn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := d.Draw()
+	d.Burn()
+	if want := mustCards(t, "2c4h"); !reflect.DeepEqual(d.Burned(), want) || burned != want[0] {
+		t.Errorf("burned %v, want %v", d.Burned(), want)
+	}
+	if c != mustCards(t, "3d")[0] {
+		t.Errorf("drew %s after the burn, want 3d", c.ShortString())
+	}
+	if d.CardsRemaining() != 49 {
+		t.Errorf("%d cards remaining, want 49", d.CardsRemaining())
+	}
+
+	d.Shuffle()
+	if len(d.Burned()) != 0 {
+		t.Errorf("burns %v kept after a shuffle", d.Burned())
+	}
+}
+
+// Discards and burn cards fold back into a new stub once the deck runs
+// out, and none of the cards still in players' hands come round again
+func TestDeckReshuffleDiscards(t *testing.T) {
+	d := NewDeckWithRNG(NewSeededRNG(7))
+	d.Shuffle()
+	d.SetReshuffleDiscards(true)
+
+	live := make(map[Card]bool)
+	draw := func() Card {
+		t.Helper()
+		c, err := d.Draw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if live[c] {
+			t.Fatalf("drew %s, which is still in a hand", c.ShortString())
+		}
+		live[c] = true
+		return c
+	}
+
+	// Six players hold five cards each and draw three at a time, with a
+	// burn before each round of draws
+	hands := make([][]Card, 6)
+	for i := range hands {
+		for range 5 {
+			hands[i] = append(hands[i], draw())
+		}
+	}
+	// 30 cards stay live, so the other 22 go round several times
+	for round := 0; round < 10; round++ {
+		burned, err := d.Burn()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if live[burned] {
+			t.Fatalf("burned %s, which is still in a hand", burned.ShortString())
+		}
+		for i, h := range hands {
+			d.Discard(h[:3]...)
+			for _, c := range h[:3] {
+				delete(live, c)
+			}
+			hands[i] = append([]Card{draw(), draw(), draw()}, h[3:]...)
+		}
+	}
+	if len(live) != 30 {
+		t.Errorf("%d cards in hands, want 30", len(live))
+	}
+
+	d.Shuffle()
+	if d.CardsRemaining() != 52 {
+		t.Errorf("%d cards after a shuffle, want 52", d.CardsRemaining())
+	}
+}
+
+func TestNewStackedDeck(t *testing.T) {
+	order := mustCards(t, "AsKsQsJsTs")
+	d, err := NewStackedDeck(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Shuffling keeps the stacked order
+	d.Shuffle()
+
+	seen := make(map[Card]bool)
+	for i := range 52 {
+		c, err := d.Draw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i < len(order) && c != order[i] {
+			t.Errorf("card %d is %s, want %s", i, c.ShortString(), order[i].ShortString())
+		}
+		if seen[c] {
+			t.Fatalf("%s is in the deck twice", c.ShortString())
+		}
+		seen[c] = true
+	}
+	if d.CardsRemaining() != 0 {
+		t.Errorf("%d cards past 52", d.CardsRemaining())
+	}
+
+	if _, err := NewStackedDeck(mustCards(t, "AsKsAs")); err == nil {
+		t.Error("stacked a card twice")
+	}
+	if _, err := NewStackedDeck([]Card{{Suit: Spades, Rank: 15}}); err == nil {
+		t.Error("stacked an invalid card")
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Card
+		wantErr bool
+	}{
+		{in: "As", want: Card{Suit: Spades, Rank: Ace}},
+		{in: "td", want: Card{Suit: Diamonds, Rank: Ten}},
+		{in: "10h", want: Card{Suit: Hearts, Rank: Ten}},
+		{in: " 2C ", want: Card{Suit: Clubs, Rank: Two}},
+		{in: "1s", wantErr: true},
+		{in: "Ax", wantErr: true},
+		{in: "A", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseCard(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "AsKs", want: "As Ks"},
+		{in: "2h 3h  4h", want: "2h 3h 4h"},
+		{in: "10c10d", want: "Tc Td"},
+		{in: "", want: ""},
+		{in: "AsK", wantErr: true},
+		{in: "AsKx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			cards, err := ParseCards(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, c := range cards {
+				got = append(got, c.ShortString())
+			}
+			if tt.wantErr {
+				return
+			}
+			if s := strings.Join(got, " "); s != tt.want {
+				t.Errorf("got %q, want %q", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package game
+
+import (
+	"fmt"
+)
+
+// DrawType classifies an unfinished hand
+type DrawType int
+
+const (
+	FlushDraw DrawType = iota
+	OpenEndedStraightDraw
+	GutshotStraightDraw
+	BackdoorFlushDraw
+	BackdoorStraightDraw
+	DoubleGutshotStraightDraw
+)
+
+// Out is an unseen card that would improve a hand
+type Out struct {
+	Card      Card     `json:"card"`
+	Improves  HandRank `json:"improves"`  // Hand rank made with this card
+	MakesNuts bool     `json:"makesNuts"` // Best possible hand on the new board
+}
+
+// DrawAnalysis describes a player's outs and draws on the current board
+type DrawAnalysis struct {
+	CurrentHand  HandRank   `json:"currentHand"`
+	Description  string     `json:"description"`
+	Outs         []Out      `json:"outs"`
+	Draws        []DrawType `json:"draws"`
+	CardsToCome  int        `json:"cardsToCome"`
+	RuleOdds     float64    `json:"ruleOdds"`     // Rule of 2/4 estimate, percentage
+	NextCardOdds float64    `json:"nextCardOdds"` // Exact chance of hitting on the next card, percentage
+}
+
+// AnalyzeDraws lists the outs and draws for hole cards on a flop or turn.
+// Only the player's own cards are treated as seen. A card is an out when it
+// improves the player's hand by more than it improves the board, so a card
+// pairing the board is not an out for a hand that was already a pair.
+func AnalyzeDraws(holeCards []Card, communityCards []Card) DrawAnalysis {
+	analysis := DrawAnalysis{CardsToCome: 5 - len(communityCards)}
+	cards := append(append([]Card{}, holeCards...), communityCards...)
+
+	current := EvaluateBestHand(cards)
+	analysis.CurrentHand = current.Rank
+	analysis.Description = current.Description
+
+	if len(communityCards) < 3 || len(communityCards) >= 5 {
+		return analysis
+	}
+
+	currentValue := EvaluateHandValue(cards)
+	currentNuts := currentValue >= nutValue(communityCards, holeCards)
+	gain := current.Rank - boardOnlyRank(communityCards)
+	unseen := remainingCards(cards)
+	for _, c := range unseen {
+		board := append(append([]Card{}, communityCards...), c)
+		improved := EvaluateBestHand(append(append([]Card{}, holeCards...), board...))
+
+		// Improving only the board (e.g. pairing it) helps everyone
+		betterRank := improved.Rank > current.Rank && improved.Rank-boardOnlyRank(board) > gain
+		if !betterRank && currentNuts {
+			continue
+		}
+		value := EvaluateHandValue(append(append([]Card{}, holeCards...), board...))
+		nuts := value > currentValue && value >= nutValue(board, holeCards)
+		if betterRank || nuts {
+			analysis.Outs = append(analysis.Outs, Out{
+				Card:      c,
+				Improves:  improved.Rank,
+				MakesNuts: nuts,
+			})
+		}
+	}
+
+	analysis.Draws = classifyDraws(holeCards, communityCards, current.Rank)
+	analysis.RuleOdds = CalculateOutsOdds(len(analysis.Outs), analysis.CardsToCome)
+	analysis.NextCardOdds = float64(len(analysis.Outs)) / float64(len(unseen)) * 100
+
+	return analysis
+}
+
+// AnalyzeDraws returns the draw analysis for a player still in the hand
+func (g *PokerGame) AnalyzeDraws(playerID string) (*DrawAnalysis, error) {
+	cards := g.GetPlayerCards(playerID)
+	if cards == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	analysis := AnalyzeDraws(cards, g.CommunityCards)
+	return &analysis, nil
+}
+
+// classifyDraws finds flush and straight draws that use a hole card
+func classifyDraws(holeCards []Card, communityCards []Card, made HandRank) []DrawType {
+	var draws []DrawType
+	cards := append(append([]Card{}, holeCards...), communityCards...)
+	onFlop := len(communityCards) == 3
+
+	if made < Flush {
+		var suitCounts [Spades + 1]int
+		for _, c := range cards {
+			suitCounts[c.Suit]++
+		}
+		best := 0
+		for _, h := range holeCards {
+			best = max(best, suitCounts[h.Suit])
+		}
+		if best == 4 {
+			draws = append(draws, FlushDraw)
+		} else if best == 3 && onFlop {
+			draws = append(draws, BackdoorFlushDraw)
+		}
+	}
+
+	if made < Straight {
+		present := rankSet(cards)
+		var completes [Ace + 1]bool
+		completing := 0
+		for r := Two; r <= Ace; r++ {
+			if present[r] {
+				continue
+			}
+			present[r] = true
+			if hasStraight(present) && usesHoleCard(holeCards, present, r) {
+				completes[r] = true
+				completing++
+			}
+			present[r] = false
+		}
+
+		switch {
+		case openEnded(present, completes):
+			draws = append(draws, OpenEndedStraightDraw)
+		case completing >= 2:
+			draws = append(draws, DoubleGutshotStraightDraw)
+		case completing == 1:
+			draws = append(draws, GutshotStraightDraw)
+		case onFlop && hasBackdoorStraight(holeCards, present):
+			draws = append(draws, BackdoorStraightDraw)
+		}
+	}
+
+	return draws
+}
+
+// openEnded reports four ranks in a row that either end completes, as
+// opposed to two gutshots. The ace counts low and high, so A234 and JQKA
+// are one-ended.
+func openEnded(present, completes [Ace + 1]bool) bool {
+	// Position 1 is the low ace, 2 to 14 are Two to Ace
+	rankAt := func(pos int) Rank {
+		if pos == 1 {
+			return Ace
+		}
+		return Rank(pos)
+	}
+	for low := 2; low+4 <= 14; low++ {
+		run := true
+		for pos := low; pos < low+4; pos++ {
+			run = run && present[rankAt(pos)]
+		}
+		if run && completes[rankAt(low-1)] && completes[rankAt(low+4)] {
+			return true
+		}
+	}
+	return false
+}
+
+// rankSet marks the ranks present in cards
+func rankSet(cards []Card) [Ace + 1]bool {
+	var present [Ace + 1]bool
+	for _, c := range cards {
+		present[c.Rank] = true
+	}
+	return present
+}
+
+// straightWindows returns the low rank of every five-rank straight, the
+// wheel first (its ace is looked up as Ace)
+func straightWindows() []Rank {
+	return []Rank{Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten}
+}
+
+// windowRanks returns the ranks of the straight starting at low
+func windowRanks(low Rank) []Rank {
+	if low == Ace {
+		return []Rank{Ace, Two, Three, Four, Five}
+	}
+	return []Rank{low, low + 1, low + 2, low + 3, low + 4}
+}
+
+func hasStraight(present [Ace + 1]bool) bool {
+	for _, low := range straightWindows() {
+		if countInWindow(present, low) == 5 {
+			return true
+		}
+	}
+	return false
+}
+
+func countInWindow(present [Ace + 1]bool, low Rank) int {
+	count := 0
+	for _, r := range windowRanks(low) {
+		if present[r] {
+			count++
+		}
+	}
+	return count
+}
+
+// usesHoleCard reports whether a straight completed by r needs a hole card
+func usesHoleCard(holeCards []Card, present [Ace + 1]bool, r Rank) bool {
+	for _, low := range straightWindows() {
+		ranks := windowRanks(low)
+		if countInWindow(present, low) != 5 || !containsRank(ranks, r) {
+			continue
+		}
+		for _, h := range holeCards {
+			if containsRank(ranks, h.Rank) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasBackdoorStraight reports three ranks of a straight, including a hole card
+func hasBackdoorStraight(holeCards []Card, present [Ace + 1]bool) bool {
+	for _, low := range straightWindows() {
+		ranks := windowRanks(low)
+		if countInWindow(present, low) < 3 {
+			continue
+		}
+		for _, h := range holeCards {
+			if containsRank(ranks, h.Rank) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsRank(ranks []Rank, r Rank) bool {
+	for _, v := range ranks {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// boardOnlyRank returns the rank the community cards make by themselves
+func boardOnlyRank(board []Card) HandRank {
+	if len(board) >= 5 {
+		return EvaluateBestHand(board).Rank
+	}
+
+	var counts [Ace + 1]int
+	pairs, trips := 0, 0
+	for _, c := range board {
+		counts[c.Rank]++
+		switch counts[c.Rank] {
+		case 2:
+			pairs++
+		case 3:
+			pairs--
+			trips++
+		case 4:
+			return FourOfAKind
+		}
+	}
+
+	switch {
+	case trips > 0:
+		return ThreeOfAKind
+	case pairs >= 2:
+		return TwoPair
+	case pairs == 1:
+		return OnePair
+	default:
+		return HighCard
+	}
+}
+
+// nutValue scores the best hand any two unseen cards make on board, with
+// the dead cards out of play. It is computed once per board, with the fast
+// evaluator, so hands are checked for the nuts by comparing values.
+func nutValue(board []Card, dead []Card) HandValue {
+	stub := remainingCards(append(append([]Card{}, board...), dead...))
+	cards := append(make([]Card, 2, 2+len(board)), board...)
+	best := HandValue(0)
+	for i := 0; i < len(stub); i++ {
+		for j := i + 1; j < len(stub); j++ {
+			cards[0], cards[1] = stub[i], stub[j]
+			best = max(best, EvaluateHandValue(cards))
+		}
+	}
+	return best
+}
+
+// String returns the string representation of a draw type
+func (d DrawType) String() string {
+	switch d {
+	case FlushDraw:
+		return "flush draw"
+	case OpenEndedStraightDraw:
+		return "open-ended straight draw"
+	case GutshotStraightDraw:
+		return "gutshot"
+	case BackdoorFlushDraw:
+		return "backdoor flush draw"
+	case BackdoorStraightDraw:
+		return "backdoor straight draw"
+	case DoubleGutshotStraightDraw:
+		return "double gutshot"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON encodes a draw type as its name
+func (d DrawType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", d.String())), nil
+}
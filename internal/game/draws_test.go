@@ -0,0 +1,79 @@
+package game
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDraws(t *testing.T) {
+	tests := []struct {
+		name  string
+		hole  string
+		board string
+		draws []DrawType
+		outs  int
+		nuts  string // Outs that make the nuts
+	}{
+		// Fives and tens, plus six cards pairing the hole cards
+		{name: "open-ended", hole: "9c8d", board: "7h6s2c", draws: []DrawType{OpenEndedStraightDraw}, outs: 14, nuts: "5cTc5dTd5hTh5sTs"},
+		{name: "gutshot", hole: "9c8d", board: "6h5s2c", draws: []DrawType{GutshotStraightDraw}, outs: 10, nuts: "7c7d7h7s"},
+		// A ten gives a straight that loses to Q-8
+		{name: "double gutshot", hole: "8c7d", board: "5h9sJd", draws: []DrawType{DoubleGutshotStraightDraw}, outs: 14, nuts: "6c6d6h6s"},
+		// Four to Broadway only fills one way
+		{name: "one-ended", hole: "AcKd", board: "QhJs2c", draws: []DrawType{GutshotStraightDraw}, outs: 10, nuts: "TcTdThTs"},
+		// Every heart but the one pairing the board makes the nut flush
+		{name: "nut flush draw", hole: "Ah5h", board: "Kh8h2c", draws: []DrawType{FlushDraw, BackdoorStraightDraw}, outs: 15, nuts: "3h4h6h7h9hThJhQh"},
+		{name: "backdoor draws", hole: "Ah5h", board: "Kh8c2s", draws: []DrawType{BackdoorFlushDraw, BackdoorStraightDraw}, outs: 6},
+		// Only the board draws to the straight
+		{name: "straight on board", hole: "Ac2d", board: "9h8s7c6d", outs: 6},
+		// The hero holds the jack and eight of spades, so the queen makes a
+		// straight flush nobody can beat
+		{name: "hero's cards are out of play", hole: "Js8s", board: "Ts9s2d", draws: []DrawType{FlushDraw, OpenEndedStraightDraw}, outs: 21, nuts: "7c7d7h7sQs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := mustCards(t, tt.board)
+			a := AnalyzeDraws(mustCards(t, tt.hole), board)
+			if !reflect.DeepEqual(a.Draws, tt.draws) {
+				t.Errorf("draws = %v, want %v", a.Draws, tt.draws)
+			}
+			if len(a.Outs) != tt.outs {
+				t.Errorf("%d outs, want %d: %v", len(a.Outs), tt.outs, a.Outs)
+			}
+			var nuts strings.Builder
+			for _, o := range a.Outs {
+				if o.MakesNuts {
+					nuts.WriteString(o.Card.ShortString())
+				}
+			}
+			if nuts.String() != tt.nuts {
+				t.Errorf("nut outs = %s, want %s", nuts.String(), tt.nuts)
+			}
+			unseen := 52 - 2 - len(board)
+			if want := 100 * float64(tt.outs) / float64(unseen); math.Abs(a.NextCardOdds-want) > 1e-9 {
+				t.Errorf("next card odds = %g, want %g", a.NextCardOdds, want)
+			}
+		})
+	}
+}
+
+// The nuts are judged with the hero's cards out of play
+func TestNutValueExcludesHero(t *testing.T) {
+	board := mustCards(t, "Ts9s2dQs")
+	hero := mustCards(t, "Js8s")
+	if nutValue(board, hero) >= nutValue(board, nil) {
+		t.Error("holding the jack of spades does not block the king-high straight flush")
+	}
+	if got := EvaluateHandValue(append(hero, board...)); got < nutValue(board, hero) {
+		t.Error("another hand beats the queen-high straight flush")
+	}
+}
+
+func TestAnalyzeDrawsRiver(t *testing.T) {
+	a := AnalyzeDraws(mustCards(t, "9c8d"), mustCards(t, "7h6s2cKdKs"))
+	if a.CardsToCome != 0 || len(a.Outs) != 0 || len(a.Draws) != 0 {
+		t.Errorf("river analysis = %+v, want no outs or draws", a)
+	}
+}
@@ -0,0 +1,240 @@
+package game
+
+import (
+	"math/rand"
+)
+
+// EquityTrials is the number of random run-outs used when there are too
+// many boards left to enumerate (i.e. preflop)
+const EquityTrials = 5000
+
+// StreetEquity records each contender's equity at a point in the hand
+type StreetEquity struct {
+	Street   string             `json:"street"`
+	Board    []Card             `json:"board"`
+	Equities map[string]float64 `json:"equities"` // Player ID -> equity percentage
+}
+
+// CalculateEquity returns each hand's share of the pot as a percentage,
+// given the known board and any dead cards. Boards with two or fewer cards
+// to come are enumerated exactly, anything earlier is sampled.
+func CalculateEquity(hands [][]Card, board []Card, dead []Card) []float64 {
+	return CalculateEquityWithRNG(hands, board, dead, globalRNG{})
+}
+
+// CalculateEquityWithRNG is CalculateEquity sampling run-outs from rng
+func CalculateEquityWithRNG(hands [][]Card, board []Card, dead []Card, rng RNG) []float64 {
+	return calculateEquity(hands, board, dead, rng, addShowdownShares)
+}
+
+// CalculateHiLoEquity is CalculateEquity for hi-lo games, where the best
+// eight-or-better low takes half of the pot and the high hand scoops when
+// there is none
+func CalculateHiLoEquity(hands [][]Card, board []Card, dead []Card) []float64 {
+	return CalculateHiLoEquityWithRNG(hands, board, dead, globalRNG{})
+}
+
+// CalculateHiLoEquityWithRNG is CalculateHiLoEquity sampling run-outs
+// from rng
+func CalculateHiLoEquityWithRNG(hands [][]Card, board []Card, dead []Card, rng RNG) []float64 {
+	return calculateEquity(hands, board, dead, rng, addHiLoShares)
+}
+
+// calculateEquity runs out the board, crediting each hand's share of every
+// showdown with addShares
+func calculateEquity(hands [][]Card, board []Card, dead []Card, rng RNG, addShares func(shares []float64, hands [][]Card, board []Card)) []float64 {
+	equities := make([]float64, len(hands))
+	if len(hands) == 0 {
+		return equities
+	}
+	if len(hands) == 1 {
+		equities[0] = 100
+		return equities
+	}
+
+	known := append(append([]Card{}, board...), dead...)
+	for _, h := range hands {
+		known = append(known, h...)
+	}
+	stub := remainingCards(known)
+
+	toCome := 5 - len(board)
+	runs := 0
+	if toCome <= 0 {
+		addShares(equities, hands, board)
+		runs = 1
+	} else if toCome <= 2 {
+		for _, runout := range generateCombinations(stub, toCome) {
+			addShares(equities, hands, append(append([]Card{}, board...), runout...))
+			runs++
+		}
+	} else {
+		runout := make([]Card, len(stub))
+		for ; runs < EquityTrials; runs++ {
+			copy(runout, stub)
+			// Partial Fisher-Yates, only the cards we need
+			for i := 0; i < toCome; i++ {
+				j := i + rng.Intn(len(runout)-i)
+				runout[i], runout[j] = runout[j], runout[i]
+			}
+			addShares(equities, hands, append(append([]Card{}, board...), runout[:toCome]...))
+		}
+	}
+
+	for i := range equities {
+		equities[i] = equities[i] / float64(runs) * 100
+	}
+	return equities
+}
+
+// addShowdownShares credits each winning hand with its share of one pot
+func addShowdownShares(shares []float64, hands [][]Card, board []Card) {
+	winners := showdownWinners(hands, board)
+	for _, w := range winners {
+		shares[w] += 1 / float64(len(winners))
+	}
+}
+
+// addHiLoShares credits the high and low winners with their shares of one
+// pot
+func addHiLoShares(shares []float64, hands [][]Card, board []Card) {
+	lows := lowWinners(hands, board)
+	high := 1.0
+	if len(lows) > 0 {
+		high = 0.5
+		for _, w := range lows {
+			shares[w] += 0.5 / float64(len(lows))
+		}
+	}
+	winners := showdownWinners(hands, board)
+	for _, w := range winners {
+		shares[w] += high / float64(len(winners))
+	}
+}
+
+// lowWinners returns the indexes of the best eight-or-better lows on a
+// complete board, none if no hand qualifies
+func lowWinners(hands [][]Card, board []Card) []int {
+	var winners []int
+	var best LowHandResult
+	cards := make([]Card, 0, 7)
+	for i, h := range hands {
+		cards = append(append(cards[:0], h...), board...)
+		low := EvaluateLowHand(cards, AceToFiveEightOrBetter)
+		if !low.Qualified {
+			continue
+		}
+		switch {
+		case len(winners) == 0 || CompareLowHands(low, best) > 0:
+			winners, best = []int{i}, low
+		case CompareLowHands(low, best) == 0:
+			winners = append(winners, i)
+		}
+	}
+	return winners
+}
+
+// showdownWinners returns the indexes of the best hands on a complete board
+func showdownWinners(hands [][]Card, board []Card) []int {
+	var winners []int
+	var best HandValue
+	cards := make([]Card, 0, 7)
+	for i, h := range hands {
+		cards = append(append(cards[:0], h...), board...)
+		value := EvaluateHandValue(cards)
+		switch {
+		case len(winners) == 0 || value > best:
+			winners, best = []int{i}, value
+		case value == best:
+			winners = append(winners, i)
+		}
+	}
+	return winners
+}
+
+// globalRNG samples from math/rand's shared source
+type globalRNG struct{}
+
+func (globalRNG) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+// remainingCards returns the cards of a full deck not in known
+func remainingCards(known []Card) []Card {
+	used := make(map[Card]bool, len(known))
+	for _, c := range known {
+		used[c] = true
+	}
+
+	var cards []Card
+	for _, c := range NewDeck().cards {
+		if !used[c] {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// streetEquity calculates the contenders' equity at the current street
+func (g *PokerGame) streetEquity() StreetEquity {
+	contenders := g.contenders()
+	hands := make([][]Card, len(contenders))
+	for i, p := range contenders {
+		hands[i] = p.HoleCards
+	}
+
+	equities := g.equity(hands)
+	street := StreetEquity{
+		Street:   g.getBettingRoundString(),
+		Board:    append([]Card{}, g.CommunityCards...),
+		Equities: make(map[string]float64, len(contenders)),
+	}
+	for i, p := range contenders {
+		street.Equities[p.ID] = equities[i]
+	}
+	return street
+}
+
+// equity calculates the hands' equity on the board so far, splitting the
+// pots high and low in hi-lo games
+func (g *PokerGame) equity(hands [][]Card) []float64 {
+	if g.HiLo {
+		return CalculateHiLoEquityWithRNG(hands, g.CommunityCards, nil, g.RNG)
+	}
+	return CalculateEquityWithRNG(hands, g.CommunityCards, nil, g.RNG)
+}
+
+// allInPotShares calculates each player's expected share of the pots at
+// the moment the hand went all-in, for EV-adjusted results. The board is
+// run out, so the pots are shared after the rake a hand that sees the flop
+// pays.
+func (g *PokerGame) allInPotShares() map[string]float64 {
+	shares := make(map[string]float64)
+	for _, pot := range deductRake(g.pots(), g.rakeDue(true)) {
+		var eligible []*PokerPlayer
+		for _, p := range g.contenders() {
+			if containsID(pot.EligiblePlayers, p.ID) {
+				eligible = append(eligible, p)
+			}
+		}
+
+		hands := make([][]Card, len(eligible))
+		for i, p := range eligible {
+			hands[i] = p.HoleCards
+		}
+		equities := g.equity(hands)
+		for i, p := range eligible {
+			shares[p.ID] += float64(pot.Amount) * equities[i] / 100
+		}
+	}
+	return shares
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
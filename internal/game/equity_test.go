@@ -0,0 +1,73 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateHiLoEquity(t *testing.T) {
+	tests := []struct {
+		name  string
+		hands []string
+		board string
+		want  []float64
+	}{
+		{"high and low split", []string{"As 3d", "Kd Kh"}, "2c 5d 7h Kc Qs", []float64{50, 50}},
+		{"no low qualifies", []string{"As 3d", "Kd Kc"}, "9c Td Kh Qs 2c", []float64{0, 100}},
+		{"scoop", []string{"As 3d", "Kd Kh"}, "2c 5d 7h 4c Qs", []float64{100, 0}},
+		{"low quartered", []string{"As 3d", "Kd Kh", "Ac 3h"}, "2c 5d 7h Kc Qs", []float64{25, 50, 25}},
+		// The low is locked up and only a five, 4 of the 44 rivers, makes
+		// the wheel for the high half too
+		{"one card to come", []string{"As 2c", "Kd Kh"}, "3c 4d 7h Kc", []float64{50 + 50*4.0/44, 50 * 40.0 / 44}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands [][]Card
+			for _, h := range tt.hands {
+				hands = append(hands, mustCards(t, h))
+			}
+			got := CalculateHiLoEquity(hands, mustCards(t, tt.board), nil)
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("equity = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// In a hi-lo game the all-in EV counts the half of the pot a locked low
+// is sure of
+func TestHiLoAllInEV(t *testing.T) {
+	g := newTestGame(t, 6, "a", "b")
+	g.HiLo = true
+	deal, err := ParseScriptedDeal("As2c KdKh | 3c4d7h Kc 9s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g,
+		BotAction{Action: Call}, BotAction{Action: Check},
+		BotAction{Action: Check}, BotAction{Action: Check},
+		BotAction{Action: AllIn}, BotAction{Action: Call},
+	)
+	if !g.HandComplete {
+		t.Fatal("hand not complete")
+	}
+
+	want := map[string]float64{"a": 2000*(0.5+0.5*4/44) - 1000, "b": 2000*0.5*40/44 - 1000}
+	for _, r := range g.History[len(g.History)-1].Results {
+		if r.Net != 0 {
+			t.Errorf("%s: net %d, want the pot split", r.PlayerID, r.Net)
+		}
+		if math.Abs(r.EVNet-want[r.PlayerID]) > 1e-6 {
+			t.Errorf("%s: EV net %.2f, want %.2f", r.PlayerID, r.EVNet, want[r.PlayerID])
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package game
+
+import (
+	"sort"
+	"sync"
+)
+
+// Observer is a bot that watches the table between its turns
+type Observer interface {
+	Observe(e Event)
+}
+
+// ShowBots passes an event to each bot that observes the table, as the
+// bot's seat sees it
+func ShowBots(bots map[string]Bot, e Event) {
+	for id, bot := range bots {
+		if o, ok := bot.(Observer); ok {
+			o.Observe(EventFor(e, id))
+		}
+	}
+}
+
+// DefaultEquitySamples is how many deals an EquityBot simulates per
+// decision
+const DefaultEquitySamples = 400
+
+// Opponent model settings. Until an opponent has played a few hands their
+// stats lean on a typical player's.
+const (
+	priorVPIP   = 30.0 // Percent
+	priorPFR    = 15.0
+	priorWeight = 10.0 // Hands the prior counts as
+	minRange    = 3.0  // Narrowest range, percent of hands
+)
+
+// EquityBot plays by its equity against the hands each opponent is likely
+// to hold. It puts an opponent on the best VPIP% of starting hands, or the
+// best PFR% once they raise before the flop, learning both from the hands
+// it watches. Set it to observe the table with ShowBots; without that it
+// uses the stats in GameState, if the game keeps them.
+type EquityBot struct {
+	RNG     RNG
+	Samples int // Deals simulated per decision, DefaultEquitySamples if 0
+
+	stats   *StatsTracker
+	raisers map[string]bool // Players who raised this hand before the flop
+	street  BettingRound
+}
+
+// NewEquityBot returns an equity bot that has seen nothing yet
+func NewEquityBot(rng RNG) *EquityBot {
+	return &EquityBot{
+		RNG:     rng,
+		stats:   NewStatsTracker(),
+		raisers: make(map[string]bool),
+	}
+}
+
+// Observe updates the opponent model with a table event
+func (b *EquityBot) Observe(e Event) {
+	b.stats.Record(e)
+	switch e.Type {
+	case EventHandStarted:
+		clear(b.raisers)
+		b.street = PreFlop
+	case EventStreetDealt:
+		if round, err := parseBettingRound(e.Street); err == nil {
+			b.street = round
+		}
+	case EventActionTaken:
+		// An all-in that raises counts too, but telling one from a call
+		// needs the bets; a shove is a raise often enough
+		if b.street == PreFlop && (e.Action == Raise.String() || e.Action == AllIn.String()) {
+			b.raisers[e.PlayerID] = true
+		}
+	}
+}
+
+// Decide calls when its equity beats the price, counting what a draw
+// could win later, and bets or raises when well ahead of its share
+func (b *EquityBot) Decide(state *GameState, holeCards []Card) BotAction {
+	me := statePlayer(state, state.CurrentPlayerID)
+	if me == nil || len(holeCards) != 2 {
+		return BotAction{Action: Fold}
+	}
+
+	var ranges [][][2]Card
+	stackBehind := 0 // Most any opponent can still put in
+	for _, p := range state.Players {
+		if p.ID == me.ID || p.IsFolded || !p.IsActive {
+			continue
+		}
+		ranges = append(ranges, rangeCombos(b.rangePercent(p)))
+		stackBehind = max(stackBehind, p.Chips)
+	}
+	if len(ranges) == 0 {
+		return BotAction{Action: Check}
+	}
+
+	samples := b.Samples
+	if samples <= 0 {
+		samples = DefaultEquitySamples
+	}
+	equity := 100 * rangeEquity(holeCards, state.CommunityCards, ranges, samples, state.HiLo, b.RNG)
+	toCall := min(state.CurrentBet-me.CurrentBet, me.Chips)
+
+	// Value bet when clearly ahead of an even share of the pot
+	share := 100 / float64(len(ranges)+1)
+	if equity >= share+(100-share)*0.35 {
+		return sizedBet(state, me, 75)
+	}
+
+	if toCall <= 0 {
+		// Semi-bluff a good draw some of the time
+		if drawOuts(holeCards, state.CommunityCards) >= 8 && b.RNG.Intn(3) == 0 {
+			return sizedBet(state, me, 60)
+		}
+		return BotAction{Action: Check}
+	}
+
+	// Implied odds: a draw that gets there should win some of what is
+	// still behind, up to the size of the pot
+	pot := state.Pot
+	if len(state.CommunityCards) < 5 && drawOuts(holeCards, state.CommunityCards) > 0 {
+		pot += min(stackBehind, min(me.Chips-toCall, state.Pot)) / 2
+	}
+	if equity >= CalculatePotOdds(toCall, pot) {
+		return BotAction{Action: Call}
+	}
+	return BotAction{Action: Fold}
+}
+
+// rangePercent is the share of starting hands an opponent is put on
+func (b *EquityBot) rangePercent(p PlayerState) float64 {
+	hands, vpip, pfr := 0.0, 0.0, 0.0
+	if s := b.stats.players[p.ID]; s != nil && s.Hands > 0 {
+		hands, vpip, pfr = float64(s.Hands), 100*float64(s.VPIP), 100*float64(s.PFR)
+	} else if p.Stats != nil {
+		hands = float64(p.Stats.Hands)
+		vpip, pfr = p.Stats.VPIP*hands, p.Stats.PFR*hands
+	}
+	vpip = (vpip + priorVPIP*priorWeight) / (hands + priorWeight)
+	pfr = (pfr + priorPFR*priorWeight) / (hands + priorWeight)
+
+	if b.raisers[p.ID] {
+		return max(pfr, minRange)
+	}
+	return max(vpip, minRange)
+}
+
+var (
+	rangeOnce  sync.Once
+	rangeOrder [][2]Card // Every starting hand, best heads-up equity first
+)
+
+// rangeCombos returns the hole cards in the best percent of starting
+// hands
+func rangeCombos(percent float64) [][2]Card {
+	rangeOnce.Do(func() {
+		hands := PreflopHands()
+		sort.SliceStable(hands, func(i, j int) bool { return hands[i].EquityVs(1) > hands[j].EquityVs(1) })
+		for _, h := range hands {
+			for _, c := range HandCombos(h.Notation) {
+				rangeOrder = append(rangeOrder, [2]Card{c[0], c[1]})
+			}
+		}
+	})
+	n := int(float64(len(rangeOrder))*percent/100 + 0.5)
+	return rangeOrder[:min(max(n, 1), len(rangeOrder))]
+}
+
+// rangeEquity estimates the share of the pot hole cards win against an
+// opponent holding a hand from each range, by dealing samples hands. In
+// hi-lo the best eight-or-better low wins half.
+func rangeEquity(holeCards, board []Card, ranges [][][2]Card, samples int, hiLo bool, rng RNG) float64 {
+	var known [52]bool
+	for _, c := range append(append([]Card{}, holeCards...), board...) {
+		known[cardIndex(c)] = true
+	}
+	deck := NewDeck().cards
+
+	mine := make([]Card, 0, 7)
+	theirs := make([]Card, 0, 7)
+	runout := make([]Card, 0, 5)
+	won := 0.0
+	for s := 0; s < samples; s++ {
+		used := known
+		opponents := make([][2]Card, 0, len(ranges))
+		for _, r := range ranges {
+			// Cards we hold or the board shows rule out some of a range
+			var h [2]Card
+			ok := false
+			for try := 0; try < 20 && !ok; try++ {
+				h = r[rng.Intn(len(r))]
+				ok = !used[cardIndex(h[0])] && !used[cardIndex(h[1])]
+			}
+			// A range they rule out all of is no guide, so any two
+			// cards will do
+			for !ok {
+				h = [2]Card{deck[rng.Intn(len(deck))], deck[rng.Intn(len(deck))]}
+				ok = h[0] != h[1] && !used[cardIndex(h[0])] && !used[cardIndex(h[1])]
+			}
+			used[cardIndex(h[0])], used[cardIndex(h[1])] = true, true
+			opponents = append(opponents, h)
+		}
+
+		runout = append(runout[:0], board...)
+		for len(runout) < 5 {
+			c := deck[rng.Intn(len(deck))]
+			if !used[cardIndex(c)] {
+				used[cardIndex(c)] = true
+				runout = append(runout, c)
+			}
+		}
+
+		if hiLo {
+			hands := [][]Card{holeCards}
+			for _, h := range opponents {
+				hands = append(hands, []Card{h[0], h[1]})
+			}
+			shares := make([]float64, len(hands))
+			addHiLoShares(shares, hands, runout)
+			won += shares[0]
+			continue
+		}
+
+		best := EvaluateHandValue(append(append(mine[:0], holeCards...), runout...))
+		ties := 1
+		beaten := false
+		for _, h := range opponents {
+			v := EvaluateHandValue(append(append(theirs[:0], h[0], h[1]), runout...))
+			if v > best {
+				beaten = true
+				break
+			}
+			if v == best {
+				ties++
+			}
+		}
+		if !beaten {
+			won += 1 / float64(ties)
+		}
+	}
+	if samples == 0 {
+		return 0
+	}
+	return won / float64(samples)
+}
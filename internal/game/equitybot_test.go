@@ -0,0 +1,107 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRangePercent(t *testing.T) {
+	tests := []struct {
+		name   string
+		stats  *HUDStats
+		raised bool
+		want   float64
+	}{
+		{name: "prior", want: priorVPIP},
+		{name: "prior raiser", raised: true, want: priorPFR},
+		// 90 hands at 50% weigh nine to one against the prior's 30%
+		{name: "loose", stats: &HUDStats{Hands: 90, VPIP: 50, PFR: 5}, want: 48},
+		{name: "loose raiser", stats: &HUDStats{Hands: 90, VPIP: 50, PFR: 5}, raised: true, want: 6},
+		{name: "never narrower than the minimum", stats: &HUDStats{Hands: 990, VPIP: 1}, want: minRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewEquityBot(fixedRNG(0))
+			b.raisers["them"] = tt.raised
+			got := b.rangePercent(PlayerState{ID: "them", Stats: tt.stats})
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("rangePercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Hands it watched count ahead of the stats in GameState
+	b := NewEquityBot(fixedRNG(0))
+	b.stats.Load(PlayerStats{PlayerID: "them", StatCounts: StatCounts{Hands: 10, VPIP: 10}})
+	if got := b.rangePercent(PlayerState{ID: "them", Stats: &HUDStats{Hands: 1000}}); got != 65 {
+		t.Errorf("watched: rangePercent() = %v, want 65", got)
+	}
+}
+
+func TestRangeCombos(t *testing.T) {
+	if got := len(rangeCombos(100)); got != 1326 {
+		t.Errorf("every hand: %d combos, want 1326", got)
+	}
+	if got := len(rangeCombos(50)); got != 663 {
+		t.Errorf("half: %d combos, want 663", got)
+	}
+	// The narrowest range is still a hand, the best there is
+	for _, percent := range []float64{0, 0.4} {
+		combos := rangeCombos(percent)
+		if len(combos) == 0 {
+			t.Fatalf("%v%%: no combos", percent)
+		}
+		for _, c := range combos {
+			if c[0].Rank != Ace || c[1].Rank != Ace {
+				t.Errorf("%v%%: %v is not aces", percent, c)
+			}
+		}
+	}
+}
+
+func TestRangeEquity(t *testing.T) {
+	tests := []struct {
+		name  string
+		hiLo  bool
+		hole  string
+		board string
+		them  string
+	}{
+		{"overpair against a set", false, "As Ah", "Kd 8c 3s", "Ks Kh"},
+		{"flush draw", false, "Ah 5h", "Kh 8h 3c", "Ks Qd"},
+		{"preflop", false, "As Kd", "", "Qs Qh"},
+		{"hi-lo", true, "As 2c", "3c 4d 7h", "Kd Kh"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hole, them := mustCards(t, tt.hole), mustCards(t, tt.them)
+			var board []Card
+			if tt.board != "" {
+				board = mustCards(t, tt.board)
+			}
+			calculate := CalculateEquityWithRNG
+			if tt.hiLo {
+				calculate = CalculateHiLoEquityWithRNG
+			}
+			want := calculate([][]Card{hole, them}, board, nil, NewSeededRNG(1))[0]
+
+			ranges := [][][2]Card{{{them[0], them[1]}}}
+			got := 100 * rangeEquity(hole, board, ranges, 20000, tt.hiLo, NewSeededRNG(2))
+			if math.Abs(got-want) > 1.5 {
+				t.Errorf("rangeEquity() = %.2f%%, CalculateEquity %.2f%%", got, want)
+			}
+		})
+	}
+}
+
+// A range made up of cards the bot holds or can see is no help; it plays
+// against any two cards rather than counting no equity at all
+func TestRangeEquityBlockedRange(t *testing.T) {
+	hole := mustCards(t, "As Ah")
+	ranges := [][][2]Card{{{hole[0], mustCards(t, "Kd")[0]}}}
+	got := 100 * rangeEquity(hole, nil, ranges, 20000, false, NewSeededRNG(3))
+	// Aces win about 85% against a random hand
+	if got < 82 || got > 88 {
+		t.Errorf("rangeEquity() = %.2f%%, want about 85%%", got)
+	}
+}
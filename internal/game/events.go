@@ -0,0 +1,550 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventType identifies a state transition in a game
+type EventType string
+
+const (
+	EventPlayerJoined     EventType = "playerJoined"
+	EventHandStarted      EventType = "handStarted"
+	EventDeckShuffled     EventType = "deckShuffled"
+	EventSmallBlindPosted EventType = "smallBlindPosted"
+	EventBigBlindPosted   EventType = "bigBlindPosted"
+	EventHoleCardsDealt   EventType = "holeCardsDealt"
+	EventActionTaken      EventType = "actionTaken"
+	EventStreetDealt      EventType = "streetDealt"
+	EventAllIn            EventType = "allIn"
+	EventEquityCalculated EventType = "equityCalculated"
+	EventRakeTaken        EventType = "rakeTaken"
+	EventPotAwarded       EventType = "potAwarded"
+	EventHandEnded        EventType = "handEnded"
+	EventRebuy            EventType = "rebuy"
+	EventCashedOut        EventType = "cashedOut"
+)
+
+// Event is a single state transition. Every change to a PokerGame is made
+// by applying an event, so folding a game's events over an empty game
+// rebuilds it exactly. Only the fields for the event's Type are set.
+//
+// Events hold every player's hole cards and the burn cards, so a raw event
+// must never be sent to a client: pass it through EventFor, or use
+// EventsFor, first. The deck order is never in an event; deckShuffled
+// carries only the provably fair commitment.
+type Event struct {
+	Sequence   int       `json:"seq"`
+	Type       EventType `json:"type"`
+	Time       time.Time `json:"time"`
+	HandNumber int       `json:"handNumber"`
+	PlayerID   string    `json:"playerId,omitempty"`
+
+	Name   string `json:"name,omitempty"`   // playerJoined
+	Seat   int    `json:"seat"`             // playerJoined: player's seat; handStarted: dealer's seat
+	Amount int    `json:"amount,omitempty"` // Chips posted, bet, raked, awarded, bought or cashed out; the stack on playerJoined
+	Action string `json:"action,omitempty"` // actionTaken, as accepted by ParseActionType
+	Street string `json:"street,omitempty"` // streetDealt
+
+	SmallBlind int  `json:"smallBlind,omitempty"` // handStarted
+	BigBlind   int  `json:"bigBlind,omitempty"`   // handStarted
+	HiLo       bool `json:"hiLo,omitempty"`       // handStarted: pots split high and low
+
+	Cards []Card `json:"cards,omitempty"` // Hole cards or new community cards
+	Burn  *Card  `json:"burn,omitempty"`  // streetDealt
+
+	Commitment string             `json:"commitment,omitempty"` // deckShuffled, when provably fair
+	Winner     *Winner            `json:"winner,omitempty"`     // potAwarded
+	Equity     *StreetEquity      `json:"equity,omitempty"`     // equityCalculated
+	EV         map[string]float64 `json:"ev,omitempty"`         // allIn: expected pot share per player
+	Fairness   *FairnessProof     `json:"fairness,omitempty"`   // handEnded, when provably fair
+}
+
+// DefaultKeepHands is how many completed hands a new game keeps
+const DefaultKeepHands = 100
+
+// ErrEventSequence is returned when replaying events that are out of order
+var ErrEventSequence = errors.New("events out of sequence")
+
+// ReplayEvents rebuilds a game by folding its events, in sequence order
+// from the first, which is 1 unless older hands were dropped from the log.
+// The undealt cards are not in the events, so a game rebuilt mid-hand
+// cannot deal the rest of it; Restore a Snapshot to carry on playing.
+func ReplayEvents(events []Event) (*PokerGame, error) {
+	g := &PokerGame{
+		RNG:     NewCryptoRNG(),
+		Players: make([]*PokerPlayer, 0),
+	}
+	for i, e := range events {
+		if want := events[0].Sequence + i; e.Sequence != want || want < 1 {
+			return nil, fmt.Errorf("%w: expected %d, got %d", ErrEventSequence, max(want, 1), e.Sequence)
+		}
+		if err := g.apply(e); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", e.Sequence, e.Type, err)
+		}
+		g.Events = append(g.Events, e)
+	}
+	return g, nil
+}
+
+// LastEventSeq returns the sequence number of the latest event, 0 before
+// the first
+func (g *PokerGame) LastEventSeq() int {
+	if len(g.Events) == 0 {
+		return 0
+	}
+	return g.Events[len(g.Events)-1].Sequence
+}
+
+// EventsSince returns the events after sequence number seq, unredacted.
+// Events dropped from the log are skipped, so a seq from before the first
+// hand kept gets the whole log.
+func (g *PokerGame) EventsSince(seq int) []Event {
+	i := seq
+	if len(g.Events) > 0 {
+		i -= g.Events[0].Sequence - 1
+	}
+	if i >= len(g.Events) {
+		return nil
+	}
+	return append([]Event{}, g.Events[max(i, 0):]...)
+}
+
+// EventsFor returns the events after seq as seen by one player, for a
+// client catching up after reconnecting: burn cards and other players'
+// hole cards are hidden
+func (g *PokerGame) EventsFor(playerID string, seq int) []Event {
+	events := g.EventsSince(seq)
+	for i := range events {
+		redactEvent(&events[i], playerID, false)
+	}
+	return events
+}
+
+// EventFor returns an event as one player may see it, e.g. to forward the
+// events OnEvent receives
+func EventFor(e Event, playerID string) Event {
+	redactEvent(&e, playerID, false)
+	return e
+}
+
+// redactEvent hides what playerID may not see: burn cards and, unless
+// holeCards is set, other players' hole cards. Logs written before the deck
+// order was dropped from deckShuffled still have it, so it is hidden too.
+func redactEvent(e *Event, playerID string, holeCards bool) {
+	switch e.Type {
+	case EventDeckShuffled:
+		e.Cards = nil
+	case EventHoleCardsDealt:
+		if e.PlayerID != playerID && !holeCards {
+			e.Cards = nil
+		}
+	case EventStreetDealt:
+		e.Burn = nil
+	}
+}
+
+// emit applies an event to the game and appends it to the event log
+func (g *PokerGame) emit(e Event) error {
+	e.Sequence = g.LastEventSeq() + 1
+	e.Time = time.Now().UTC()
+	if e.HandNumber == 0 {
+		e.HandNumber = g.HandNumber
+	}
+
+	if err := g.apply(e); err != nil {
+		return err
+	}
+	g.Events = append(g.Events, e)
+
+	if g.Stats != nil {
+		g.Stats.Record(e)
+	}
+	if g.OnEvent != nil {
+		g.OnEvent(e)
+	}
+	return nil
+}
+
+// rotateLog drops the oldest hands from History and Events once the game
+// has played twice KeepHands, keeping the last KeepHands. Cutting half the
+// log at a time keeps the cost per hand low. The log then opens with a
+// playerJoined for each player seated at the first hand kept, with the
+// stack they started it with, numbered just before that hand's events in
+// place of some of those dropped, so it still replays by itself.
+func (g *PokerGame) rotateLog() error {
+	if g.KeepHands <= 0 {
+		return nil
+	}
+	first := g.HandNumber + 1
+	for _, e := range g.Events {
+		if e.Type == EventHandStarted {
+			first = e.HandNumber
+			break
+		}
+	}
+	if g.HandNumber-first+1 < 2*g.KeepHands {
+		return nil
+	}
+
+	// Fold the log up to the first hand kept to find who was seated
+	keep := g.HandNumber - g.KeepHands + 1
+	r := &PokerGame{Players: make([]*PokerPlayer, 0)}
+	cut := 0
+	for i, e := range g.Events {
+		if e.Type == EventHandStarted && e.HandNumber == keep {
+			cut = i
+			break
+		}
+		if err := r.apply(e); err != nil {
+			return fmt.Errorf("event %d (%s): %w", e.Sequence, e.Type, err)
+		}
+	}
+
+	start := g.Events[cut]
+	events := make([]Event, 0, len(r.Players)+len(g.Events)-cut)
+	for _, p := range r.Players {
+		events = append(events, Event{
+			Sequence:   start.Sequence - len(r.Players) + len(events),
+			Type:       EventPlayerJoined,
+			Time:       start.Time,
+			HandNumber: keep - 1,
+			PlayerID:   p.ID,
+			Name:       p.Name,
+			Seat:       p.SeatPosition,
+			Amount:     p.Chips,
+		})
+	}
+	g.Events = append(events, g.Events[cut:]...)
+
+	dropped := 0
+	for dropped < len(g.History) && g.History[dropped].HandNumber < keep {
+		dropped++
+	}
+	g.History = append([]HandHistory{}, g.History[dropped:]...)
+	return nil
+}
+
+// apply makes the state change an event describes. Events are validated
+// before they are emitted, so apply only checks what it needs to stay
+// consistent.
+func (g *PokerGame) apply(e Event) error {
+	switch e.Type {
+	case EventPlayerJoined:
+		g.Players = append(g.Players, &PokerPlayer{
+			ID:           e.PlayerID,
+			Name:         e.Name,
+			Chips:        e.Amount,
+			IsActive:     true,
+			SeatPosition: e.Seat,
+		})
+
+	case EventHandStarted:
+		if e.Seat < 0 || e.Seat >= len(g.Players) {
+			return fmt.Errorf("no player in seat %d", e.Seat)
+		}
+		g.SmallBlind, g.BigBlind = e.SmallBlind, e.BigBlind
+		g.HiLo = e.HiLo
+		g.HandNumber = e.HandNumber
+		g.HandComplete = false
+		g.Pot = 0
+		g.SidePots = nil
+		g.CurrentBet = 0
+		g.MinRaise = g.BigBlind
+		g.BettingRound = PreFlop
+		g.CommunityCards = nil
+		g.Winners = nil
+		g.HandRake = 0
+		g.LastAggressor = ""
+		g.AllInEquity = nil
+		g.allInEV = nil
+		g.runningOut = false
+		g.shuffleCommitment = ""
+
+		g.NumActivePlayers = 0
+		for _, p := range g.Players {
+			p.IsActive = p.Chips > 0
+			p.IsFolded = false
+			p.IsAllIn = false
+			p.HasActed = false
+			p.CurrentBet = 0
+			p.TotalBetInHand = 0
+			p.HoleCards = nil
+			if p.IsActive {
+				g.NumActivePlayers++
+			}
+		}
+		g.DealerIndex = e.Seat
+
+	case EventDeckShuffled:
+		// The live game puts its new deck in place once this is applied.
+		// A replay has no deck and takes the cards from the events.
+		g.Deck = nil
+		g.shuffleCommitment = e.Commitment
+
+	case EventSmallBlindPosted, EventBigBlindPosted:
+		p, index, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		g.playerBet(p, e.Amount)
+		if e.Type == EventBigBlindPosted {
+			g.CurrentBet = g.BigBlind
+			// Action starts left of the big blind
+			g.CurrentIndex = g.getNextActivePlayer(index)
+		}
+
+	case EventHoleCardsDealt:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		if !p.IsActive || len(p.HoleCards) > 0 {
+			return fmt.Errorf("%s is not being dealt in", p.ID)
+		}
+		if err := g.checkUnseen(e.Cards); err != nil {
+			return err
+		}
+		if err := g.dealMatching(p, e.Cards); err != nil {
+			return err
+		}
+		p.HoleCards = append([]Card{}, e.Cards...)
+
+	case EventActionTaken:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		action, err := ParseActionType(e.Action)
+		if err != nil {
+			return err
+		}
+		g.applyAction(p, action, e.Amount)
+
+	case EventStreetDealt:
+		round, err := parseBettingRound(e.Street)
+		if err != nil {
+			return err
+		}
+		g.collectBets()
+		if err := g.checkUnseen(e.Cards); err != nil {
+			return err
+		}
+		if e.Burn != nil {
+			if err := g.drawMatching([]Card{*e.Burn}, true); err != nil {
+				return err
+			}
+		}
+		if err := g.drawMatching(e.Cards, false); err != nil {
+			return err
+		}
+		g.CommunityCards = append(g.CommunityCards, e.Cards...)
+		g.BettingRound = round
+		// Nobody acts on streets run out after an all-in
+		if round < Showdown && g.countPlayersToAct() >= 2 {
+			g.CurrentIndex = g.getNextActivePlayer(g.DealerIndex)
+		}
+
+	case EventAllIn:
+		g.collectBets()
+		g.allInEV = e.EV
+		g.runningOut = true
+
+	case EventEquityCalculated:
+		if e.Equity == nil {
+			return errors.New("missing equity")
+		}
+		g.AllInEquity = append(g.AllInEquity, *e.Equity)
+
+	case EventRakeTaken:
+		g.collectBets()
+		g.HandRake = e.Amount
+
+	case EventPotAwarded:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		g.collectBets()
+		winner := Winner{PlayerID: p.ID, Amount: e.Amount}
+		if e.Winner != nil {
+			winner = *e.Winner
+		}
+		g.Winners = append(g.Winners, winner)
+		p.Chips += e.Amount
+
+	case EventHandEnded:
+		g.collectBets()
+		g.HandComplete = true
+		g.runningOut = false
+		g.recordHistory(e.Fairness)
+
+	case EventRebuy:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		p.Chips += e.Amount
+		p.IsActive = true
+
+	case EventCashedOut:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return err
+		}
+		p.Chips -= e.Amount
+		p.IsActive = p.Chips > 0
+
+	default:
+		return fmt.Errorf("unknown event type %q", e.Type)
+	}
+	return nil
+}
+
+// applyAction updates bets and turn order for a validated player action
+func (g *PokerGame) applyAction(p *PokerPlayer, action ActionType, amount int) {
+	switch action {
+	case Call:
+		g.playerBet(p, amount)
+
+	case Bet:
+		g.playerBet(p, amount)
+		g.CurrentBet = amount
+		g.MinRaise = amount
+		g.LastAggressor = p.ID
+		g.resetHasActed()
+
+	case Raise:
+		g.playerBet(p, amount-p.CurrentBet)
+		g.MinRaise = amount - g.CurrentBet
+		g.CurrentBet = amount
+		g.LastAggressor = p.ID
+		g.resetHasActed()
+
+	case Fold:
+		p.IsFolded = true
+		g.NumActivePlayers--
+
+	case AllIn:
+		g.playerBet(p, amount)
+		if p.CurrentBet > g.CurrentBet {
+			g.MinRaise = p.CurrentBet - g.CurrentBet
+			g.CurrentBet = p.CurrentBet
+			g.LastAggressor = p.ID
+			g.resetHasActed()
+		}
+	}
+
+	p.HasActed = true
+
+	// The next street or showdown moves the action once the round is over
+	if !g.isBettingRoundComplete() {
+		g.CurrentIndex = g.getNextActivePlayer(g.CurrentIndex)
+	}
+}
+
+// collectBets closes the betting round, gathering bets into the pots
+func (g *PokerGame) collectBets() {
+	for _, p := range g.Players {
+		p.CurrentBet = 0
+		p.HasActed = false
+	}
+	g.CurrentBet = 0
+	g.createSidePots()
+}
+
+// checkUnseen rejects dealing a card that is not a real card or is already
+// in a player's hand or on the board, so a replay without a deck still
+// catches a log that deals a card twice
+func (g *PokerGame) checkUnseen(cards []Card) error {
+	var seen [52]bool
+	for _, p := range g.Players {
+		for _, c := range p.HoleCards {
+			seen[cardIndex(c)] = true
+		}
+	}
+	for _, c := range g.CommunityCards {
+		seen[cardIndex(c)] = true
+	}
+	for _, c := range cards {
+		if c.Rank < Two || c.Rank > Ace || c.Suit < Clubs || c.Suit > Spades {
+			return fmt.Errorf("invalid card %v", c)
+		}
+		if seen[cardIndex(c)] {
+			return fmt.Errorf("%s dealt twice", c.ShortString())
+		}
+		seen[cardIndex(c)] = true
+	}
+	return nil
+}
+
+// dealMatching takes a player's hole cards from the deck, checking they are
+// the ones an event says were dealt. Hole cards go round the table a card
+// at a time, so with n players dealt in, the one dealt k-th gets the
+// cards k and n+k from where the deal began. A replay has no deck to deal
+// from.
+func (g *PokerGame) dealMatching(p *PokerPlayer, cards []Card) error {
+	if g.Deck == nil {
+		return nil
+	}
+	var dealtIn []*PokerPlayer
+	dealt := 0
+	for _, q := range g.Players {
+		if q.IsActive {
+			dealtIn = append(dealtIn, q)
+			if len(q.HoleCards) > 0 {
+				dealt++
+			}
+		}
+	}
+	if dealt >= len(dealtIn) || dealtIn[dealt] != p {
+		return fmt.Errorf("%s dealt out of turn", p.ID)
+	}
+
+	start := g.Deck.used - len(cards)*dealt
+	for i, want := range cards {
+		pos := start + i*len(dealtIn) + dealt
+		if pos >= len(g.Deck.cards) {
+			return ErrDeckEmpty
+		}
+		if got := g.Deck.cards[pos]; got != want {
+			return fmt.Errorf("dealt %s but the deck has %s", want.ShortString(), got.ShortString())
+		}
+	}
+	g.Deck.used += len(cards)
+	return nil
+}
+
+// drawMatching draws cards from the deck, checking they are the ones an
+// event says were dealt. A replay has no deck to draw from.
+func (g *PokerGame) drawMatching(cards []Card, burn bool) error {
+	if g.Deck == nil {
+		return nil
+	}
+	for _, want := range cards {
+		draw := g.Deck.Draw
+		if burn {
+			draw = g.Deck.Burn
+		}
+		got, err := draw()
+		if err != nil {
+			return err
+		}
+		if got != want {
+			return fmt.Errorf("dealt %s but the deck has %s", want.ShortString(), got.ShortString())
+		}
+	}
+	return nil
+}
+
+// findPlayer finds a player and their index by ID
+func (g *PokerGame) findPlayer(id string) (*PokerPlayer, int, error) {
+	for i, p := range g.Players {
+		if p.ID == id {
+			return p, i, nil
+		}
+	}
+	return nil, -1, fmt.Errorf("unknown player %q", id)
+}
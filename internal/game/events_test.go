@@ -0,0 +1,406 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// newTestGame seats players with 1000 chips each at 5/10 blinds
+func newTestGame(t *testing.T, seed int64, ids ...string) *PokerGame {
+	t.Helper()
+	g := NewPokerGameWithRNG(5, 10, NewSeededRNG(seed))
+	for _, id := range ids {
+		if err := g.AddPlayer(id, id, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// playHands plays up to n hands with rule-based bots, stopping early once
+// one player has every chip
+func playHands(t *testing.T, g *PokerGame, n int, seed int64) {
+	t.Helper()
+	styles := BotStyles()
+	bots := make(map[string]Bot)
+	for i, p := range g.Players {
+		bot, err := NewRuleBot(styles[i%len(styles)], NewSeededRNG(seed+int64(i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bots[p.ID] = bot
+	}
+
+	for h := 0; h < n; h++ {
+		withChips := 0
+		for _, p := range g.Players {
+			if p.Chips > 0 {
+				withChips++
+			}
+		}
+		if withChips < 2 {
+			return
+		}
+		if err := g.StartNewHand(); err != nil {
+			t.Fatalf("hand %d: %v", g.HandNumber, err)
+		}
+		if err := g.PlayBots(bots); err != nil {
+			t.Fatalf("hand %d: %v", g.HandNumber, err)
+		}
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestReplayEventsMatchesLiveGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []string
+		seed    int64
+		hands   int
+		fair    bool
+		hiLo    bool
+		keep    int
+	}{
+		{name: "heads up", players: []string{"a", "b"}, seed: 1, hands: 30},
+		{name: "six handed", players: []string{"a", "b", "c", "d", "e", "f"}, seed: 2, hands: 50},
+		{name: "provably fair", players: []string{"a", "b", "c"}, seed: 3, hands: 20, fair: true},
+		{name: "hi-lo", players: []string{"a", "b", "c", "d"}, seed: 4, hands: 30, hiLo: true},
+		{name: "old hands dropped", players: []string{"a", "b", "c", "d"}, seed: 5, hands: 30, keep: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, tt.seed, tt.players...)
+			g.ProvablyFair = tt.fair
+			g.HiLo = tt.hiLo
+			if tt.keep > 0 {
+				g.KeepHands = tt.keep
+			}
+			g.SkipAllInEquity = true
+			playHands(t, g, tt.hands, tt.seed)
+
+			replayed, err := ReplayEvents(g.Events)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := mustJSON(t, replayed.GetState()), mustJSON(t, g.GetState()); got != want {
+				t.Errorf("state differs\n got %s\nwant %s", got, want)
+			}
+			if got, want := mustJSON(t, replayed.History), mustJSON(t, g.History); got != want {
+				t.Errorf("history differs\n got %s\nwant %s", got, want)
+			}
+			for i, p := range g.Players {
+				if got := replayed.Players[i].HoleCards; mustJSON(t, got) != mustJSON(t, p.HoleCards) {
+					t.Errorf("%s hole cards = %v, want %v", p.ID, got, p.HoleCards)
+				}
+			}
+		})
+	}
+}
+
+func TestReplayEventsMidHand(t *testing.T) {
+	g := newTestGame(t, 5, "a", "b", "c")
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range []ActionType{Call, Call, Check} {
+		if err := g.ProcessAction(g.Players[g.CurrentIndex].ID, action, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	replayed, err := ReplayEvents(g.Events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, replayed.GetState()), mustJSON(t, g.GetState()); got != want {
+		t.Errorf("state differs\n got %s\nwant %s", got, want)
+	}
+
+	// The undealt cards are not in the log, so checking round the flop
+	// cannot deal the turn
+	for i := 0; i < 3 && err == nil; i++ {
+		err = replayed.ProcessAction(replayed.Players[replayed.CurrentIndex].ID, Check, 0)
+	}
+	if !errors.Is(err, ErrNoDeck) {
+		t.Errorf("dealing the turn after a replay: got %v, want %v", err, ErrNoDeck)
+	}
+}
+
+func TestReplayEventsOutOfSequence(t *testing.T) {
+	g := newTestGame(t, 6, "a", "b")
+	playHands(t, g, 1, 6)
+
+	events := append([]Event{}, g.Events...)
+	events[2], events[3] = events[3], events[2]
+	if _, err := ReplayEvents(events); !errors.Is(err, ErrEventSequence) {
+		t.Errorf("got %v, want %v", err, ErrEventSequence)
+	}
+}
+
+func TestRotateLog(t *testing.T) {
+	g := newTestGame(t, 8, "a", "b", "c")
+	g.KeepHands = 3
+	for hand := 1; hand <= 20; hand++ {
+		playHands(t, g, 1, int64(hand))
+		for _, p := range g.Players {
+			if p.Chips == 0 {
+				if err := g.Rebuy(p.ID, 1000); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+
+		// Between three and six hands are kept, and the log starts with
+		// the first of them
+		if hand <= 6 {
+			continue
+		}
+		if n := len(g.History); n < 4 || n > 6 || g.History[n-1].HandNumber != hand {
+			t.Fatalf("after hand %d, history has %d hands, the last %d", hand, n, g.History[n-1].HandNumber)
+		}
+		first := g.History[0].HandNumber
+		for i, e := range g.Events {
+			if e.Sequence != g.Events[0].Sequence+i {
+				t.Fatalf("after hand %d, event %d has seq %d", hand, i, e.Sequence)
+			}
+			if e.Type == EventHandStarted {
+				if e.HandNumber != first {
+					t.Fatalf("after hand %d, the log starts at hand %d, history at %d", hand, e.HandNumber, first)
+				}
+				break
+			}
+			if e.Type != EventPlayerJoined {
+				t.Fatalf("after hand %d, the log starts with %s", hand, e.Type)
+			}
+		}
+	}
+
+	// The log still replays by itself, and so do its hands
+	replayed, err := ReplayEvents(g.Events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mustJSON(t, replayed.GetState()), mustJSON(t, g.GetState()); got != want {
+		t.Errorf("state differs\n got %s\nwant %s", got, want)
+	}
+	if got, want := mustJSON(t, replayed.History), mustJSON(t, g.History); got != want {
+		t.Errorf("history differs\n got %s\nwant %s", got, want)
+	}
+	if _, err := HandLog(g.Events, g.History[0].HandNumber); err != nil {
+		t.Errorf("first hand kept: %v", err)
+	}
+
+	// Sequence numbers carry on from the dropped events
+	last := g.LastEventSeq()
+	if last <= len(g.Events) {
+		t.Errorf("last seq %d with %d events kept", last, len(g.Events))
+	}
+	if got := g.EventsFor("a", last-2); len(got) != 2 || got[1].Sequence != last {
+		t.Errorf("events after %d: %v", last-2, got)
+	}
+	if got := g.EventsFor("a", 1); len(got) != len(g.Events) {
+		t.Errorf("catching up from dropped events got %d, want the %d kept", len(got), len(g.Events))
+	}
+
+	restored, err := Restore(g.Snapshot(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.KeepHands != 3 {
+		t.Errorf("restored game keeps %d hands, want 3", restored.KeepHands)
+	}
+}
+
+func TestKeepAllHands(t *testing.T) {
+	g := newTestGame(t, 9, "a", "b")
+	g.KeepHands = 0
+	playHands(t, g, 10, 9)
+	if g.Events[0].Sequence != 1 || len(g.History) != g.HandNumber {
+		t.Errorf("kept %d of %d hands, the log from seq %d", len(g.History), g.HandNumber, g.Events[0].Sequence)
+	}
+}
+
+// Replays catch hole cards that were already dealt, and a game with a deck
+// checks them against it
+func TestHoleCardsChecked(t *testing.T) {
+	// The hand sees a flop
+	g := newTestGame(t, 10, "a", "b", "c")
+	playHands(t, g, 1, 10)
+	dealt := make(map[string]int)
+	flop := 0
+	for i, e := range g.Events {
+		switch {
+		case e.Type == EventHoleCardsDealt:
+			dealt[e.PlayerID] = i
+		case e.Type == EventStreetDealt && flop == 0:
+			flop = i
+		}
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(events []Event)
+	}{
+		{name: "another player's card", tamper: func(events []Event) {
+			events[dealt["b"]].Cards = []Card{events[dealt["a"]].Cards[1], events[dealt["b"]].Cards[1]}
+		}},
+		{name: "a pair of the same card", tamper: func(events []Event) {
+			events[dealt["c"]].Cards = []Card{events[dealt["c"]].Cards[0], events[dealt["c"]].Cards[0]}
+		}},
+		{name: "dealt twice", tamper: func(events []Event) {
+			events[dealt["b"]].PlayerID = "a"
+		}},
+		{name: "an invalid card", tamper: func(events []Event) {
+			events[dealt["a"]].Cards = []Card{{Suit: Spades, Rank: 1}, events[dealt["a"]].Cards[1]}
+		}},
+		{name: "a hole card on the board", tamper: func(events []Event) {
+			events[flop].Cards[0] = events[dealt["a"]].Cards[0]
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := append([]Event{}, g.Events...)
+			for i := range events {
+				events[i].Cards = append([]Card{}, events[i].Cards...)
+			}
+			tt.tamper(events)
+			if _, err := ReplayEvents(events); err == nil {
+				t.Error("replayed")
+			}
+		})
+	}
+
+	// Put the hole cards back in the deck and deal them again
+	g = newTestGame(t, 10, "a", "b", "c")
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	hole := make(map[string][]Card)
+	for _, p := range g.Players {
+		hole[p.ID], p.HoleCards = p.HoleCards, nil
+	}
+	g.Deck.used -= 6
+	deal := func(id string, cards []Card) error {
+		return g.apply(Event{Type: EventHoleCardsDealt, PlayerID: id, Cards: cards})
+	}
+	if err := deal("b", hole["b"]); err == nil {
+		t.Error("dealt b before a")
+	}
+	if err := deal("a", hole["b"]); err == nil {
+		t.Error("dealt a b's cards")
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if err := deal(id, hole[id]); err != nil {
+			t.Fatalf("%s: %v", id, err)
+		}
+	}
+	if g.Deck.CardsRemaining() != 46 {
+		t.Errorf("%d cards left after the deal, want 46", g.Deck.CardsRemaining())
+	}
+}
+
+func TestEventsForHidesCards(t *testing.T) {
+	g := newTestGame(t, 7, "a", "b", "c")
+	g.ProvablyFair = true
+	playHands(t, g, 5, 7)
+
+	// Catch up from the start of the last hand, as one of its players
+	lastHand, lastPlayer := 0, ""
+	for i, e := range g.Events {
+		switch {
+		case e.Type == EventHandStarted:
+			lastHand, lastPlayer = i, ""
+		case e.Type == EventHoleCardsDealt && lastPlayer == "":
+			lastPlayer = e.PlayerID
+		}
+	}
+
+	tests := []struct {
+		name     string
+		playerID string
+		since    int
+	}{
+		{name: "player", playerID: "a"},
+		{name: "another player", playerID: "c"},
+		{name: "spectator", playerID: ""},
+		{name: "catching up", playerID: lastPlayer, since: lastHand},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := g.EventsFor(tt.playerID, tt.since)
+			if len(events) != len(g.Events)-tt.since {
+				t.Fatalf("got %d events, want %d", len(events), len(g.Events)-tt.since)
+			}
+			if events[0].Sequence != tt.since+1 {
+				t.Errorf("first event is %d, want %d", events[0].Sequence, tt.since+1)
+			}
+
+			ownCards := 0
+			for _, e := range events {
+				switch e.Type {
+				case EventDeckShuffled:
+					if len(e.Cards) > 0 {
+						t.Errorf("event %d shows the deck order", e.Sequence)
+					}
+				case EventHoleCardsDealt:
+					if e.PlayerID != tt.playerID && len(e.Cards) > 0 {
+						t.Errorf("event %d shows %s's hole cards", e.Sequence, e.PlayerID)
+					}
+					if e.PlayerID == tt.playerID && len(e.Cards) == 2 {
+						ownCards++
+					}
+				case EventStreetDealt:
+					if e.Burn != nil {
+						t.Errorf("event %d shows the burn card", e.Sequence)
+					}
+				}
+			}
+			if tt.playerID != "" && ownCards == 0 {
+				t.Error("player's own hole cards are hidden")
+			}
+		})
+	}
+
+	// Redacting a copy leaves the game's own log untouched
+	for _, e := range g.Events {
+		if e.Type == EventHoleCardsDealt && len(e.Cards) != 2 {
+			t.Fatalf("event %d lost its hole cards", e.Sequence)
+		}
+	}
+}
+
+func TestEventFor(t *testing.T) {
+	card := Card{Rank: Ace, Suit: Spades}
+	tests := []struct {
+		name      string
+		event     Event
+		playerID  string
+		wantCards bool
+		wantBurn  bool
+	}{
+		{"own hole cards", Event{Type: EventHoleCardsDealt, PlayerID: "a", Cards: []Card{card}}, "a", true, false},
+		{"other hole cards", Event{Type: EventHoleCardsDealt, PlayerID: "b", Cards: []Card{card}}, "a", false, false},
+		{"deck order in an old log", Event{Type: EventDeckShuffled, Cards: []Card{card}}, "a", false, false},
+		{"street", Event{Type: EventStreetDealt, Cards: []Card{card}, Burn: &card}, "a", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EventFor(tt.event, tt.playerID)
+			if (len(got.Cards) > 0) != tt.wantCards {
+				t.Errorf("cards = %v, want shown %v", got.Cards, tt.wantCards)
+			}
+			if (got.Burn != nil) != tt.wantBurn {
+				t.Errorf("burn = %v, want shown %v", got.Burn, tt.wantBurn)
+			}
+		})
+	}
+}
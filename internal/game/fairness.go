@@ -0,0 +1,197 @@
+package game
+
+import (
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServerSeedSize is the number of random bytes in a server seed
+const ServerSeedSize = 32
+
+// Fairness errors
+var (
+	ErrSeedsLocked        = NewGameError("client seeds are locked once the deck is shuffled")
+	ErrCommitmentMismatch = NewGameError("server seed does not match commitment")
+	ErrNotRevealed        = NewGameError("server seed not revealed until the hand is over")
+)
+
+// ClientSeed is a player's contribution to a hand's shuffle
+type ClientSeed struct {
+	PlayerID string `json:"playerId"`
+	Seed     string `json:"seed"`
+}
+
+// FairShuffle is the commit-reveal state for one hand. The server publishes
+// Commitment before the hand, players add their own seeds, and the deck
+// order is derived from all of them. The server seed is revealed afterwards
+// so anyone can check it against the commitment and rebuild the deck.
+type FairShuffle struct {
+	HandNumber  int
+	Commitment  string // Hex SHA-256 of the server seed
+	ClientSeeds []ClientSeed
+
+	serverSeed []byte
+	locked     bool
+}
+
+// FairnessProof is everything needed to verify a hand's deck order
+type FairnessProof struct {
+	HandNumber  int          `json:"handNumber"`
+	Commitment  string       `json:"commitment"`
+	ServerSeed  string       `json:"serverSeed"` // Hex
+	ClientSeeds []ClientSeed `json:"clientSeeds"`
+}
+
+// NewFairShuffle draws a fresh server seed and commits to it
+func NewFairShuffle(handNumber int) (*FairShuffle, error) {
+	seed := make([]byte, ServerSeedSize)
+	if _, err := crand.Read(seed); err != nil {
+		return nil, fmt.Errorf("generating server seed: %w", err)
+	}
+
+	digest := sha256.Sum256(seed)
+	return &FairShuffle{
+		HandNumber: handNumber,
+		Commitment: hex.EncodeToString(digest[:]),
+		serverSeed: seed,
+	}, nil
+}
+
+// AddClientSeed adds or replaces a player's seed before the shuffle
+func (f *FairShuffle) AddClientSeed(playerID, seed string) error {
+	if f.locked {
+		return ErrSeedsLocked
+	}
+
+	for i := range f.ClientSeeds {
+		if f.ClientSeeds[i].PlayerID == playerID {
+			f.ClientSeeds[i].Seed = seed
+			return nil
+		}
+	}
+	f.ClientSeeds = append(f.ClientSeeds, ClientSeed{PlayerID: playerID, Seed: seed})
+	return nil
+}
+
+// RNG locks the client seeds and returns the deterministic RNG the deck
+// is shuffled with
+func (f *FairShuffle) RNG() RNG {
+	f.locked = true
+	return newSeedStream(f.serverSeed, f.HandNumber, f.ClientSeeds)
+}
+
+// Proof reveals the server seed. Only call it once the hand is over.
+func (f *FairShuffle) Proof() FairnessProof {
+	return FairnessProof{
+		HandNumber:  f.HandNumber,
+		Commitment:  f.Commitment,
+		ServerSeed:  hex.EncodeToString(f.serverSeed),
+		ClientSeeds: append([]ClientSeed{}, f.ClientSeeds...),
+	}
+}
+
+// VerifyShuffle checks the revealed server seed against its commitment and
+// returns the deck order, top card first, that the seeds produce
+func VerifyShuffle(proof FairnessProof) ([]Card, error) {
+	seed, err := hex.DecodeString(proof.ServerSeed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding server seed: %w", err)
+	}
+
+	digest := sha256.Sum256(seed)
+	if !strings.EqualFold(hex.EncodeToString(digest[:]), proof.Commitment) {
+		return nil, ErrCommitmentMismatch
+	}
+
+	deck := NewDeckWithRNG(newSeedStream(seed, proof.HandNumber, proof.ClientSeeds))
+	deck.Shuffle()
+	return append([]Card{}, deck.cards...), nil
+}
+
+// seedStream is an HMAC-SHA256 counter-mode generator keyed by the server
+// seed over the hand number and every client seed
+type seedStream struct {
+	key     []byte
+	message []byte
+	counter uint64
+	buf     []byte
+}
+
+func newSeedStream(serverSeed []byte, handNumber int, clientSeeds []ClientSeed) *seedStream {
+	// Sort so the order seeds arrived in doesn't matter
+	seeds := append([]ClientSeed{}, clientSeeds...)
+	sort.Slice(seeds, func(i, j int) bool {
+		return seeds[i].PlayerID < seeds[j].PlayerID
+	})
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "hand:%d", handNumber)
+	for _, s := range seeds {
+		fmt.Fprintf(&msg, "\n%q:%q", s.PlayerID, s.Seed)
+	}
+
+	return &seedStream{
+		key:     append([]byte{}, serverSeed...),
+		message: []byte(msg.String()),
+	}
+}
+
+func (s *seedStream) next() uint64 {
+	if len(s.buf) < 8 {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write(s.message)
+		var counter [8]byte
+		binary.BigEndian.PutUint64(counter[:], s.counter)
+		mac.Write(counter[:])
+		s.buf = mac.Sum(nil)
+		s.counter++
+	}
+
+	v := binary.BigEndian.Uint64(s.buf[:8])
+	s.buf = s.buf[8:]
+	return v
+}
+
+// Intn returns a uniform random number in [0, n)
+func (s *seedStream) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(uniformUint64(s.next, uint64(n)))
+}
+
+// CommitNextHand prepares a provably fair shuffle for the next hand and
+// returns the commitment to publish to players before it starts
+func (g *PokerGame) CommitNextHand() (string, error) {
+	fair, err := NewFairShuffle(g.HandNumber + 1)
+	if err != nil {
+		return "", err
+	}
+	g.nextFairShuffle = fair
+	return fair.Commitment, nil
+}
+
+// AddClientSeed contributes a player's seed to the next hand's shuffle
+func (g *PokerGame) AddClientSeed(playerID, seed string) error {
+	if g.nextFairShuffle == nil {
+		if _, err := g.CommitNextHand(); err != nil {
+			return err
+		}
+	}
+	return g.nextFairShuffle.AddClientSeed(playerID, seed)
+}
+
+// FairnessProof returns the revealed seeds for a completed hand
+func (g *PokerGame) FairnessProof() (*FairnessProof, error) {
+	if g.fairShuffle == nil || !g.HandComplete {
+		return nil, ErrNotRevealed
+	}
+	proof := g.fairShuffle.Proof()
+	return &proof, nil
+}
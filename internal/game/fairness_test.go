@@ -0,0 +1,119 @@
+package game
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// fairDeck shuffles a deck from a fair shuffle's RNG, top card first
+func fairDeck(f *FairShuffle) []Card {
+	d := NewDeckWithRNG(f.RNG())
+	d.Shuffle()
+	return d.cards
+}
+
+func TestVerifyShuffle(t *testing.T) {
+	f, err := NewFairShuffle(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.AddClientSeed("a", "apple")
+	f.AddClientSeed("b", "pear")
+	f.AddClientSeed("a", "plum") // Replaces a's seed
+	dealt := fairDeck(f)
+	if err := f.AddClientSeed("c", "fig"); !errors.Is(err, ErrSeedsLocked) {
+		t.Errorf("seed added after the shuffle: got %v, want %v", err, ErrSeedsLocked)
+	}
+
+	proof := f.Proof()
+	got, err := VerifyShuffle(proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, dealt) {
+		t.Fatal("verified deck differs from the one dealt")
+	}
+
+	// The order the seeds arrived in doesn't matter
+	reordered := proof
+	reordered.ClientSeeds = []ClientSeed{proof.ClientSeeds[1], proof.ClientSeeds[0]}
+	if got, err := VerifyShuffle(reordered); err != nil || !reflect.DeepEqual(got, dealt) {
+		t.Errorf("reordered client seeds gave a different deck (err %v)", err)
+	}
+
+	seed, _ := hex.DecodeString(proof.ServerSeed)
+	seed[0] ^= 1
+	tests := []struct {
+		name    string
+		tamper  func(p *FairnessProof)
+		wantErr error
+	}{
+		{name: "wrong server seed", tamper: func(p *FairnessProof) { p.ServerSeed = hex.EncodeToString(seed) }, wantErr: ErrCommitmentMismatch},
+		{name: "server seed not hex", tamper: func(p *FairnessProof) { p.ServerSeed = "zz" }, wantErr: hex.InvalidByteError('z')},
+		{name: "other hand", tamper: func(p *FairnessProof) { p.HandNumber++ }},
+		{name: "changed client seed", tamper: func(p *FairnessProof) { p.ClientSeeds[0].Seed = "apple" }},
+		{name: "seeds swapped between players", tamper: func(p *FairnessProof) {
+			p.ClientSeeds[0].Seed, p.ClientSeeds[1].Seed = p.ClientSeeds[1].Seed, p.ClientSeeds[0].Seed
+		}},
+		{name: "client seed dropped", tamper: func(p *FairnessProof) { p.ClientSeeds = p.ClientSeeds[:1] }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := proof
+			p.ClientSeeds = append([]ClientSeed{}, proof.ClientSeeds...)
+			tt.tamper(&p)
+			got, err := VerifyShuffle(p)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reflect.DeepEqual(got, dealt) {
+				t.Error("tampered proof verified the dealt deck")
+			}
+		})
+	}
+}
+
+// A provably fair hand is dealt from the deck its proof rebuilds
+func TestFairnessProof(t *testing.T) {
+	g := newTestGame(t, 1, "a", "b")
+	g.ProvablyFair = true
+	commitment, err := g.CommitNextHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddClientSeed("b", "lucky"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.FairnessProof(); !errors.Is(err, ErrNotRevealed) {
+		t.Errorf("proof during the hand: got %v, want %v", err, ErrNotRevealed)
+	}
+	act(t, g, BotAction{Action: Fold})
+
+	proof, err := g.FairnessProof()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proof.Commitment != commitment || proof.HandNumber != 1 {
+		t.Errorf("proof for hand %d committed to %s, want hand 1 and %s", proof.HandNumber, proof.Commitment, commitment)
+	}
+	order, err := VerifyShuffle(*proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range g.Players {
+		if want := []Card{order[i], order[2+i]}; !reflect.DeepEqual(p.HoleCards, want) {
+			t.Errorf("%s was dealt %v, proof deals %v", p.ID, p.HoleCards, want)
+		}
+	}
+}
@@ -96,18 +96,18 @@ func evaluateFiveCards(cards []Card) HandResult {
 	isStraight = isStraight || isWheelStraight
 
 	// Count ranks
-	rankCounts := make(map[Rank]int)
+	var rankCounts [Ace + 1]int
 	for _, card := range hand {
 		rankCounts[card.Rank]++
 	}
 
-	// Find pairs, trips, quads
+	// Find pairs, trips, quads (highest first)
 	var pairs []Rank
 	var trips []Rank
 	var quads []Rank
 
-	for rank, count := range rankCounts {
-		switch count {
+	for rank := Ace; rank >= Two; rank-- {
+		switch rankCounts[rank] {
 		case 2:
 			pairs = append(pairs, rank)
 		case 3:
@@ -215,18 +215,59 @@ func checkStraight(cards []Card) bool {
 
 // compareHands compares two hands of the same rank
 func compareHands(a, b HandResult) int {
-	// For now, simplified comparison
-	// In a full implementation, we'd compare kickers properly
-	for i := 0; i < len(a.Cards) && i < len(b.Cards); i++ {
-		if a.Cards[i].Rank > b.Cards[i].Rank {
+	ka, kb := rankKey(a), rankKey(b)
+	for i := range ka {
+		if ka[i] > kb[i] {
 			return 1
-		} else if a.Cards[i].Rank < b.Cards[i].Rank {
+		} else if ka[i] < kb[i] {
 			return -1
 		}
 	}
 	return 0
 }
 
+// CompareHands compares two evaluated hands, including kickers.
+// Returns 1 if a beats b, -1 if b beats a and 0 for a tie.
+func CompareHands(a, b HandResult) int {
+	if a.Rank > b.Rank {
+		return 1
+	} else if a.Rank < b.Rank {
+		return -1
+	}
+	return compareHands(a, b)
+}
+
+// rankKey orders a hand's ranks for tie breaking: grouped cards first
+// (quads, trips, pairs), then kickers, each group highest first
+func rankKey(h HandResult) [5]Rank {
+	var key [5]Rank
+	if h.Rank == Straight || h.Rank == StraightFlush || h.Rank == RoyalFlush {
+		// Straights are ordered already, with the wheel as 5-4-3-2-A
+		for i := 0; i < len(h.Cards) && i < 5; i++ {
+			key[i] = h.Cards[i].Rank
+		}
+		return key
+	}
+
+	var counts [Ace + 1]int
+	for _, c := range h.Cards {
+		counts[c.Rank]++
+	}
+
+	n := 0
+	for count := 4; count >= 1; count-- {
+		for r := Ace; r >= Two; r-- {
+			if counts[r] == count {
+				for i := 0; i < count && n < 5; i++ {
+					key[n] = r
+					n++
+				}
+			}
+		}
+	}
+	return key
+}
+
 // generateCombinations generates all combinations of k cards from n cards
 func generateCombinations(cards []Card, k int) [][]Card {
 	var result [][]Card
@@ -247,7 +288,7 @@ func generateCombinations(cards []Card, k int) [][]Card {
 		}
 	}
 
-	generate(0, []Card{})
+	generate(0, make([]Card, 0, k))
 	return result
 }
 
@@ -0,0 +1,64 @@
+package game
+
+import "testing"
+
+func TestCompareHands(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"pair kicker", "Ah Ad Kc 9s 4d", "As Ac Qh 9d 4c", 1},
+		{"pair third kicker", "8h 8d Ac 9s 3d", "8s 8c Ah 9d 4c", -1},
+		{"two pair kicker", "Kh Kd 7c 7s Qd", "Ks Kc 7h 7d Jc", 1},
+		{"two pair second pair", "Kh Kd 6c 6s Ad", "Ks Kc 7h 7d 2c", -1},
+		{"trips kicker", "9h 9d 9c As 2d", "9s 9c 9h Ks Qd", 1},
+		{"quads kicker", "5h 5d 5c 5s Kd", "5s 5c 5h 5d Qd", 1},
+		{"full house trips first", "Kh Kd Kc 2s 2d", "Qs Qc Qh As Ad", 1},
+		{"flush second card", "Ah Jh 9h 6h 3h", "As Ts 9s 6s 3s", 1},
+		{"flush last card", "Kd Jd 9d 6d 3d", "Kc Jc 9c 6c 2c", 1},
+		{"high card kickers", "Ah Jd 9c 6s 3d", "As Jc 9h 6d 3c", 0},
+		{"wheel is lowest straight", "5h 4d 3c 2s Ad", "6s 5c 4h 3d 2c", -1},
+		{"broadway beats king high", "Ah Kd Qc Js Td", "Ks Qc Jh Td 9c", 1},
+		{"same straight", "9h 8d 7c 6s 5d", "9s 8c 7h 6d 5c", 0},
+		{"higher rank beats kickers", "2h 2d 3c 4s 6d", "Ah Kd Qc Js 9h", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := EvaluateBestHand(mustCards(t, tt.a))
+			b := EvaluateBestHand(mustCards(t, tt.b))
+			if got := CompareHands(a, b); got != tt.want {
+				t.Errorf("CompareHands(%s, %s) = %d, want %d", a.Description, b.Description, got, tt.want)
+			}
+			if got := CompareHands(b, a); got != -tt.want {
+				t.Errorf("CompareHands(%s, %s) = %d, want %d", b.Description, a.Description, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareHandsSevenCards(t *testing.T) {
+	tests := []struct {
+		name  string
+		board string
+		holeA string
+		holeB string
+		want  int
+	}{
+		{"board plays", "As Ks Qd Jc 9h", "2c 3d", "4h 5s", 0},
+		{"kicker plays over the board", "Ah 9d 7c 4s 2h", "Ad Kc", "Ac Qc", 1},
+		{"kicker below the board", "Ah Kd Qc Js 9h", "Ad 3c", "Ac 2c", 0},
+		{"counterfeited two pair", "Kh Kd 7c 7s Qd", "2c 2d", "3c 3d", 0},
+		{"better sixth card", "Kh Kd 7c 7s 2d", "Ac 3d", "Qc 3c", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := mustCards(t, tt.board)
+			a := EvaluateBestHand(append(mustCards(t, tt.holeA), board...))
+			b := EvaluateBestHand(append(mustCards(t, tt.holeB), board...))
+			if got := CompareHands(a, b); got != tt.want {
+				t.Errorf("CompareHands(%s, %s) = %d, want %d", a.Description, b.Description, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+// HandHistory records the outcome of a completed hand
+type HandHistory struct {
+	HandNumber     int            `json:"handNumber"`
+	CommunityCards []Card         `json:"communityCards"`
+	BurnCards      []Card         `json:"burnCards"`
+	Winners        []Winner       `json:"winners"`
+	Results        []PlayerResult `json:"results"`
+	AllInEquity    []StreetEquity `json:"allInEquity,omitempty"`
+	Rake           int            `json:"rake,omitempty"`
+	Fairness       *FairnessProof `json:"fairness,omitempty"`
+}
+
+// PlayerResult is a single player's result for a hand
+type PlayerResult struct {
+	PlayerID string  `json:"playerId"`
+	Invested int     `json:"invested"` // Chips put into the pot
+	Won      int     `json:"won"`      // Chips awarded from the pot
+	Net      int     `json:"net"`      // Won minus invested
+	EVNet    float64 `json:"evNet"`    // Net using pot equity at the all-in instead of the actual run-out
+}
+
+// recordHistory appends the finished hand to the game's history
+func (g *PokerGame) recordHistory(fairness *FairnessProof) {
+	won := make(map[string]int)
+	for _, w := range g.Winners {
+		won[w.PlayerID] += w.Amount
+	}
+
+	results := make([]PlayerResult, 0, len(g.Players))
+	for _, p := range g.Players {
+		if p.TotalBetInHand == 0 && won[p.ID] == 0 && !p.IsActive {
+			continue
+		}
+
+		result := PlayerResult{
+			PlayerID: p.ID,
+			Invested: p.TotalBetInHand,
+			Won:      won[p.ID],
+			Net:      won[p.ID] - p.TotalBetInHand,
+		}
+		if g.allInEV != nil {
+			result.EVNet = g.allInEV[p.ID] - float64(p.TotalBetInHand)
+		} else {
+			result.EVNet = float64(result.Net)
+		}
+		results = append(results, result)
+	}
+
+	history := HandHistory{
+		HandNumber:     g.HandNumber,
+		CommunityCards: g.CommunityCards,
+		BurnCards:      g.handBurns(),
+		Winners:        g.Winners,
+		Results:        results,
+		AllInEquity:    g.AllInEquity,
+		Rake:           g.HandRake,
+		Fairness:       fairness,
+	}
+	g.History = append(g.History, history)
+}
+
+// handBurns returns the current hand's burn cards, from its events
+func (g *PokerGame) handBurns() []Card {
+	var burns []Card
+	for i := len(g.Events) - 1; i >= 0 && g.Events[i].HandNumber == g.HandNumber; i-- {
+		if e := g.Events[i]; e.Type == EventStreetDealt && e.Burn != nil {
+			burns = append([]Card{*e.Burn}, burns...)
+		}
+	}
+	return burns
+}
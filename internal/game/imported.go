@@ -0,0 +1,207 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Import errors
+var (
+	ErrUnsupportedHand = errors.New("unsupported hand")
+	ErrReplayMismatch  = errors.New("replay does not match the hand history")
+)
+
+// ImportedHand is a hand parsed from a third-party hand history. Amounts are
+// in chips, or in cents when Currency is set.
+type ImportedHand struct {
+	Format   string    `json:"format"` // "pokerstars" or "phh"
+	ID       string    `json:"id"`
+	Table    string    `json:"table,omitempty"`
+	Time     time.Time `json:"time,omitzero"`
+	Currency string    `json:"currency,omitempty"`
+
+	SmallBlind       int              `json:"smallBlind"`
+	BigBlind         int              `json:"bigBlind"`
+	Button           int              `json:"button"` // Seat number, 0 if unknown
+	Seats            []ImportedSeat   `json:"seats"`
+	SmallBlindPlayer string           `json:"smallBlindPlayer"`
+	BigBlindPlayer   string           `json:"bigBlindPlayer"`
+	Actions          []ImportedAction `json:"actions"` // After the blinds, in order
+	Board            []Card           `json:"board"`
+
+	Collected map[string]int `json:"collected"`          // Won from the pots, after rake
+	Uncalled  map[string]int `json:"uncalled,omitempty"` // Uncalled bets returned
+	TotalPot  int            `json:"totalPot"`
+	Rake      int            `json:"rake"`
+}
+
+// ImportedSeat is a player dealt into an imported hand
+type ImportedSeat struct {
+	Seat      int    `json:"seat"`
+	Name      string `json:"name"`
+	Chips     int    `json:"chips"`               // Stack at the start of the hand
+	HoleCards []Card `json:"holeCards,omitempty"` // Nil when never shown
+}
+
+// ImportedAction is a player action, with Action and Amount as
+// ProcessAction takes them: the bet for Bet and the total bet on the street
+// for Raise. AllIn marks an action that put the player's last chips in.
+type ImportedAction struct {
+	Player string       `json:"player"`
+	Street BettingRound `json:"street"`
+	Action ActionType   `json:"action"`
+	Amount int          `json:"amount"`
+	AllIn  bool         `json:"allIn,omitempty"`
+}
+
+// ParseHandHistory parses PokerStars or PHH hand histories, detecting the
+// format from the text
+func ParseHandHistory(r io.Reader) ([]ImportedHand, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if strings.Contains(text, "PokerStars ") {
+		return ParsePokerStars(strings.NewReader(text))
+	}
+	return ParsePHH(strings.NewReader(text))
+}
+
+// Replay plays the hand through a new PokerGame with the same seats, cards
+// and actions, and checks the engine pays out what the history says. The
+// game is returned along with any mismatch, for inspection.
+func (h *ImportedHand) Replay() (*PokerGame, error) {
+	seats := append([]ImportedSeat{}, h.Seats...)
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Seat < seats[j].Seat })
+
+	n := len(seats)
+	sb, bb := -1, -1
+	for i, s := range seats {
+		switch s.Name {
+		case h.SmallBlindPlayer:
+			sb = i
+		case h.BigBlindPlayer:
+			bb = i
+		}
+	}
+	if sb < 0 || bb != (sb+1)%n {
+		return nil, fmt.Errorf("%w: hand %s has blinds out of seat order", ErrUnsupportedHand, h.ID)
+	}
+	// Heads-up the engine puts the small blind on the button
+	if n == 2 && h.Button != 0 && seats[sb].Seat != h.Button {
+		return nil, fmt.Errorf("%w: hand %s is heads-up with the small blind off the button", ErrUnsupportedHand, h.ID)
+	}
+
+	g := NewPokerGame(h.SmallBlind, h.BigBlind)
+	script := &ScriptedDeal{Board: h.Board}
+	for _, s := range seats {
+		if err := g.AddPlayer(s.Name, s.Name, s.Chips); err != nil {
+			return nil, fmt.Errorf("hand %s: %w", h.ID, err)
+		}
+		script.Hole = append(script.Hole, s.HoleCards)
+	}
+	// StartNewHand moves the button on one seat, to the small blind
+	// heads-up and to the seat before it otherwise
+	g.DealerIndex = (sb - 2 + n) % n
+	if n == 2 {
+		g.DealerIndex = (sb + 1) % n
+	}
+	if err := g.ScriptNextHand(script); err != nil {
+		return nil, fmt.Errorf("hand %s: %w", h.ID, err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		return nil, fmt.Errorf("hand %s: %w", h.ID, err)
+	}
+
+	for i, a := range h.Actions {
+		if g.HandComplete {
+			return g, fmt.Errorf("%w: hand %s is over before action %d", ErrReplayMismatch, h.ID, i+1)
+		}
+		action := a.Action
+		if a.AllIn {
+			action = AllIn
+		}
+		if err := g.ProcessAction(a.Player, action, a.Amount); err != nil {
+			return g, fmt.Errorf("%w: hand %s action %d (%s %s): %v", ErrReplayMismatch, h.ID, i+1, a.Player, a.Action, err)
+		}
+	}
+	if !g.HandComplete {
+		return g, fmt.Errorf("%w: hand %s is not over after the last action", ErrReplayMismatch, h.ID)
+	}
+	return g, h.checkResults(g)
+}
+
+// checkResults compares the engine's payouts with the history's. The
+// engine awards uncalled bets as winnings and takes no rake.
+func (h *ImportedHand) checkResults(g *PokerGame) error {
+	if h.Collected == nil {
+		return nil
+	}
+	results := g.History[len(g.History)-1].Results
+
+	total, expectedTotal := 0, h.Rake
+	for _, r := range results {
+		expected := h.Collected[r.PlayerID] + h.Uncalled[r.PlayerID]
+		total += r.Won
+		expectedTotal += expected
+		if h.Rake == 0 && r.Won != expected {
+			return fmt.Errorf("%w: hand %s: %s won %d, history says %d", ErrReplayMismatch, h.ID, r.PlayerID, r.Won, expected)
+		}
+		if h.Rake > 0 && (r.Won > 0) != (expected > 0) {
+			return fmt.Errorf("%w: hand %s: %s won %d, history says %d", ErrReplayMismatch, h.ID, r.PlayerID, r.Won, expected)
+		}
+	}
+	if total != expectedTotal {
+		return fmt.Errorf("%w: hand %s: pot of %d, history says %d", ErrReplayMismatch, h.ID, total, expectedTotal)
+	}
+	return nil
+}
+
+// seat finds a player's seat by name
+func (h *ImportedHand) seat(name string) *ImportedSeat {
+	for i := range h.Seats {
+		if h.Seats[i].Name == name {
+			return &h.Seats[i]
+		}
+	}
+	return nil
+}
+
+// parseAmount parses a chip count or money amount such as "1,500", "$0.25"
+// or "2.5". Money is returned in cents when cents is set.
+func parseAmount(s string, cents bool) (int, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, "$€£")
+	s = strings.ReplaceAll(s, ",", "")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	frac = strings.TrimRight(frac, "0")
+	if !cents {
+		if frac != "" {
+			return 0, fmt.Errorf("fractional chip amount %q", s)
+		}
+		return strconv.Atoi(whole)
+	}
+
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("amount %q has fractions of a cent", s)
+	}
+	w, err := strconv.Atoi(whole)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	f, err := strconv.Atoi((frac + "00")[:2])
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	return w*100 + f, nil
+}
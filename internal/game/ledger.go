@@ -0,0 +1,304 @@
+package game
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LedgerEntryType is the reason chips moved
+type LedgerEntryType string
+
+const (
+	LedgerBuyIn        LedgerEntryType = "buyIn"
+	LedgerRebuy        LedgerEntryType = "rebuy"
+	LedgerCashOut      LedgerEntryType = "cashOut"
+	LedgerContribution LedgerEntryType = "potContribution"
+	LedgerPotWin       LedgerEntryType = "potWin"
+	LedgerRake         LedgerEntryType = "rake"
+
+	LedgerTournamentEntry  LedgerEntryType = "tournamentEntry"
+	LedgerTournamentPayout LedgerEntryType = "tournamentPayout"
+)
+
+// Ledger accounts. Each player has a stack of chips on the table and a
+// cashier account their buy-ins come from and cash-outs go back to, so a
+// negative cashier balance is what the player has put in.
+const (
+	PotAccount   = "pot"
+	HouseAccount = "house"
+)
+
+// StackAccount is the ledger account for a player's chips on the table
+func StackAccount(playerID string) string { return "stack:" + playerID }
+
+// CashierAccount is the ledger account for a player's money off the table
+func CashierAccount(playerID string) string { return "cashier:" + playerID }
+
+// ErrLedgerMismatch is returned when the ledger does not reconcile
+var ErrLedgerMismatch = errors.New("ledger does not reconcile")
+
+// Posting is one side of a transaction, positive for a debit and negative
+// for a credit. The postings of a transaction sum to zero.
+type Posting struct {
+	Account string `json:"account"`
+	Amount  int    `json:"amount"`
+}
+
+// LedgerTransaction is a movement of chips, recorded for the event that
+// caused it
+type LedgerTransaction struct {
+	ID         int             `json:"id"`
+	Time       time.Time       `json:"time"`
+	Table      string          `json:"table"`
+	HandNumber int             `json:"handNumber"`
+	PlayerID   string          `json:"playerId"`
+	Type       LedgerEntryType `json:"type"`
+	EventSeq   int             `json:"eventSeq"`
+	Postings   []Posting       `json:"postings"`
+}
+
+// Ledger is a double-entry record of every chip movement at a table. It
+// follows a game's events: set its Follow method as OnEvent or build it
+// from a log with NewLedgerFromEvents.
+type Ledger struct {
+	Table        string
+	Transactions []LedgerTransaction
+
+	balances map[string]int
+	replica  *PokerGame // The table as of the last event recorded
+	err      error      // First error Follow met
+}
+
+// NewLedger returns an empty ledger for a table
+func NewLedger(table string) *Ledger {
+	return &Ledger{
+		Table:    table,
+		balances: make(map[string]int),
+		replica:  &PokerGame{RNG: NewCryptoRNG(), Players: make([]*PokerPlayer, 0)},
+	}
+}
+
+// NewLedgerFromEvents builds a table's ledger from its event log
+func NewLedgerFromEvents(table string, events []Event) (*Ledger, error) {
+	l := NewLedger(table)
+	for _, e := range events {
+		if err := l.Record(e); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Record posts the chip movements an event makes. The movements are read
+// from the change in stacks, so they are exactly what the engine did.
+func (l *Ledger) Record(e Event) error {
+	before := make(map[string]int, len(l.replica.Players))
+	for _, p := range l.replica.Players {
+		before[p.ID] = p.Chips
+	}
+	if err := l.replica.apply(e); err != nil {
+		return fmt.Errorf("ledger event %d (%s): %w", e.Sequence, e.Type, err)
+	}
+	// The replica is only the table as it stands; the hands it has seen
+	// would pile up for as long as the table runs
+	l.replica.History = nil
+	if e.Type == EventRakeTaken {
+		l.post(e, "", LedgerRake, Posting{HouseAccount, e.Amount}, Posting{PotAccount, -e.Amount})
+		return nil
+	}
+
+	for _, p := range l.replica.Players {
+		delta := p.Chips - before[p.ID]
+		if delta == 0 {
+			continue
+		}
+
+		var kind LedgerEntryType
+		counter := PotAccount
+		switch {
+		case e.Type == EventPlayerJoined:
+			kind, counter = LedgerBuyIn, CashierAccount(p.ID)
+		case e.Type == EventRebuy:
+			kind, counter = LedgerRebuy, CashierAccount(p.ID)
+		case e.Type == EventCashedOut:
+			kind, counter = LedgerCashOut, CashierAccount(p.ID)
+		case e.Type == EventPotAwarded:
+			kind = LedgerPotWin
+		case delta < 0:
+			kind = LedgerContribution
+		default:
+			return fmt.Errorf("%w: event %d (%s) added %d chips to %s", ErrLedgerMismatch, e.Sequence, e.Type, delta, p.ID)
+		}
+		l.post(e, p.ID, kind, Posting{StackAccount(p.ID), delta}, Posting{counter, -delta})
+	}
+	return nil
+}
+
+// Follow records an event like Record, for use as a game's OnEvent hook.
+// Once an event fails to record the ledger stops following, and Err
+// returns the failure.
+func (l *Ledger) Follow(e Event) {
+	if l.err == nil {
+		l.err = l.Record(e)
+	}
+}
+
+// Err returns the error that stopped Follow, if any
+func (l *Ledger) Err() error {
+	return l.err
+}
+
+// post appends a transaction and updates the balances
+func (l *Ledger) post(e Event, playerID string, kind LedgerEntryType, postings ...Posting) {
+	for _, p := range postings {
+		l.balances[p.Account] += p.Amount
+	}
+	l.Transactions = append(l.Transactions, LedgerTransaction{
+		ID:         len(l.Transactions) + 1,
+		Time:       e.Time,
+		Table:      l.Table,
+		HandNumber: e.HandNumber,
+		PlayerID:   playerID,
+		Type:       kind,
+		EventSeq:   e.Sequence,
+		Postings:   postings,
+	})
+}
+
+// Balance returns an account's balance
+func (l *Ledger) Balance(account string) int {
+	return l.balances[account]
+}
+
+// Reconcile checks the ledger balances and matches the game: every
+// transaction and the ledger as a whole sum to zero, each stack account
+// holds the player's chips, and the pot holds what is still to be awarded
+// once the rake is taken.
+func (l *Ledger) Reconcile(g *PokerGame) error {
+	computed := make(map[string]int)
+	for _, tx := range l.Transactions {
+		sum := 0
+		for _, p := range tx.Postings {
+			sum += p.Amount
+			computed[p.Account] += p.Amount
+		}
+		if sum != 0 {
+			return fmt.Errorf("%w: transaction %d sums to %d", ErrLedgerMismatch, tx.ID, sum)
+		}
+	}
+	for account, balance := range l.balances {
+		if computed[account] != balance {
+			return fmt.Errorf("%w: %s balance is %d, transactions sum to %d", ErrLedgerMismatch, account, balance, computed[account])
+		}
+	}
+
+	toAward := 0
+	for _, p := range g.Players {
+		if got := l.balances[StackAccount(p.ID)]; got != p.Chips {
+			return fmt.Errorf("%w: %s has %d chips, ledger says %d", ErrLedgerMismatch, p.ID, p.Chips, got)
+		}
+		toAward += p.TotalBetInHand
+	}
+	for _, w := range g.Winners {
+		toAward -= w.Amount
+	}
+	toAward -= g.HandRake
+	for _, p := range l.replica.Players {
+		if _, _, err := g.findPlayer(p.ID); err != nil {
+			return fmt.Errorf("%w: %s is not at the table", ErrLedgerMismatch, p.ID)
+		}
+	}
+	if l.balances[PotAccount] != toAward {
+		return fmt.Errorf("%w: pot has %d, %d left to award", ErrLedgerMismatch, l.balances[PotAccount], toAward)
+	}
+	return nil
+}
+
+// PlayerSettlement is a player's money in and out of a table
+type PlayerSettlement struct {
+	PlayerID  string `json:"playerId"`
+	Name      string `json:"name"`
+	BuyIn     int    `json:"buyIn"`
+	Rebuys    int    `json:"rebuys"`
+	CashedOut int    `json:"cashedOut"`
+	Stack     int    `json:"stack"` // Still on the table
+	Net       int    `json:"net"`   // Cashed out plus stack, less buy-ins and rebuys
+}
+
+// Settlement totals each player's buy-ins, rebuys and cash-outs, ordered
+// by player ID
+func (l *Ledger) Settlement() []PlayerSettlement {
+	byPlayer := make(map[string]*PlayerSettlement)
+	for _, tx := range l.Transactions {
+		if tx.PlayerID == "" {
+			continue
+		}
+		s := byPlayer[tx.PlayerID]
+		if s == nil {
+			s = &PlayerSettlement{PlayerID: tx.PlayerID}
+			byPlayer[tx.PlayerID] = s
+		}
+
+		// Money in and out is what moved through the cashier account
+		cashier := 0
+		for _, p := range tx.Postings {
+			if p.Account == CashierAccount(tx.PlayerID) {
+				cashier += p.Amount
+			}
+		}
+		switch tx.Type {
+		case LedgerBuyIn, LedgerTournamentEntry:
+			s.BuyIn -= cashier
+		case LedgerRebuy:
+			s.Rebuys -= cashier
+		case LedgerCashOut, LedgerTournamentPayout:
+			s.CashedOut += cashier
+		}
+	}
+
+	settlements := make([]PlayerSettlement, 0, len(byPlayer))
+	for id, s := range byPlayer {
+		if p, _, err := l.replica.findPlayer(id); err == nil {
+			s.Name = p.Name
+		}
+		s.Stack = l.balances[StackAccount(id)]
+		s.Net = s.CashedOut + s.Stack - s.BuyIn - s.Rebuys
+		settlements = append(settlements, *s)
+	}
+	sort.Slice(settlements, func(i, j int) bool { return settlements[i].PlayerID < settlements[j].PlayerID })
+	return settlements
+}
+
+// WriteCSV writes the ledger as CSV, a row per posting
+func (l *Ledger) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"transaction", "time", "table", "hand", "player", "type", "event", "account", "amount"})
+	if err != nil {
+		return err
+	}
+	for _, tx := range l.Transactions {
+		for _, p := range tx.Postings {
+			err := cw.Write([]string{
+				strconv.Itoa(tx.ID),
+				tx.Time.Format(time.RFC3339),
+				tx.Table,
+				strconv.Itoa(tx.HandNumber),
+				tx.PlayerID,
+				string(tx.Type),
+				strconv.Itoa(tx.EventSeq),
+				p.Account,
+				strconv.Itoa(p.Amount),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// ledgerGame starts a raked game with a ledger following it from the
+// first buy-in
+func ledgerGame(t *testing.T, ids ...string) (*PokerGame, *Ledger) {
+	t.Helper()
+	g := NewPokerGameWithRNG(5, 10, NewSeededRNG(11))
+	g.Rake = RakeRules{Percent: 5, Cap: 15}
+	l := NewLedger("table")
+	g.OnEvent = l.Follow
+	for _, id := range ids {
+		if err := g.AddPlayer(id, "Player "+id, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g, l
+}
+
+func TestLedgerReconciles(t *testing.T) {
+	g, l := ledgerGame(t, "a", "b", "c")
+	for hand := 0; hand < 20; hand++ {
+		playHands(t, g, 1, int64(hand))
+		if err := l.Reconcile(g); err != nil {
+			t.Fatalf("hand %d: %v", g.HandNumber, err)
+		}
+	}
+	for _, p := range g.Players {
+		if p.Chips == 0 {
+			if err := g.Rebuy(p.ID, 500); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if _, err := g.CashOut("a"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Mid-hand the pot holds what has been bet
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g, BotAction{Action: Call})
+	if err := l.Reconcile(g); err != nil {
+		t.Fatalf("mid-hand: %v", err)
+	}
+	if err := l.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(l.replica.History) != 0 {
+		t.Errorf("replica kept %d hand histories", len(l.replica.History))
+	}
+
+	// A ledger built from the log is the same
+	rebuilt, err := NewLedgerFromEvents("table", g.Events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rebuilt.Transactions, l.Transactions) {
+		t.Error("ledger built from the events differs")
+	}
+
+	// A chip the ledger did not see is caught
+	g.Players[1].Chips++
+	if err := l.Reconcile(g); !errors.Is(err, ErrLedgerMismatch) {
+		t.Errorf("extra chip: got %v, want %v", err, ErrLedgerMismatch)
+	}
+	g.Players[1].Chips--
+	l.Transactions[0].Postings[0].Amount++
+	if err := l.Reconcile(g); !errors.Is(err, ErrLedgerMismatch) {
+		t.Errorf("unbalanced transaction: got %v, want %v", err, ErrLedgerMismatch)
+	}
+}
+
+func TestLedgerSettlement(t *testing.T) {
+	g, l := ledgerGame(t, "a", "b")
+	// a wins b's big blind, then b rebuys after losing an all-in
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	for !g.HandComplete {
+		act(t, g, BotAction{Action: AllIn})
+	}
+	loser := "a"
+	if g.Players[0].Chips > 0 {
+		loser = "b"
+	}
+	if err := g.Rebuy(loser, 400); err != nil {
+		t.Fatal(err)
+	}
+	winner := map[string]string{"a": "b", "b": "a"}[loser]
+	cashed, err := g.CashOut(winner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rake := l.Balance(HouseAccount)
+	if rake != 15 {
+		t.Errorf("house has %d, want the 15 cap", rake)
+	}
+	want := map[string]PlayerSettlement{
+		winner: {PlayerID: winner, Name: "Player " + winner, BuyIn: 1000, CashedOut: cashed, Net: cashed - 1000},
+		loser:  {PlayerID: loser, Name: "Player " + loser, BuyIn: 1000, Rebuys: 400, Stack: 400, Net: -1000},
+	}
+	net := 0
+	for _, s := range l.Settlement() {
+		if s != want[s.PlayerID] {
+			t.Errorf("got %+v, want %+v", s, want[s.PlayerID])
+		}
+		net += s.Net
+	}
+	if net != -rake {
+		t.Errorf("players net %d, want -%d", net, rake)
+	}
+}
+
+func TestLedgerWriteCSV(t *testing.T) {
+	g, l := ledgerGame(t, "a", "b")
+	playHands(t, g, 3, 1)
+
+	var buf bytes.Buffer
+	if err := l.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	postings := 0
+	for _, tx := range l.Transactions {
+		postings += len(tx.Postings)
+	}
+	if len(records) != postings+1 {
+		t.Fatalf("%d rows, want a header and %d postings", len(records), postings)
+	}
+	if want := []string{"transaction", "time", "table", "hand", "player", "type", "event", "account", "amount"}; !reflect.DeepEqual(records[0], want) {
+		t.Errorf("header = %v", records[0])
+	}
+	// The first buy-in, from a's cashier to their stack
+	first := l.Transactions[0]
+	want := []string{"1", first.Time.Format("2006-01-02T15:04:05Z07:00"), "table", "0", "a", "buyIn", "1", "stack:a", "1000"}
+	if !reflect.DeepEqual(records[1], want) {
+		t.Errorf("first row = %v, want %v", records[1], want)
+	}
+	if records[2][7] != "cashier:a" || records[2][8] != "-1000" {
+		t.Errorf("second row = %v", records[2])
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LowType selects how low hands are ranked
+type LowType int
+
+const (
+	AceToFive              LowType = iota // Aces low, straights and flushes ignored
+	AceToFiveEightOrBetter                // Ace-to-five, only five cards eight or lower qualify
+	DeuceToSeven                          // Aces high, straights and flushes count against the hand
+)
+
+// LowHandResult represents the result of low hand evaluation
+type LowHandResult struct {
+	Type        LowType
+	Qualified   bool   // False when no five cards meet the qualifier
+	Cards       []Card // The 5 cards that make up the low, highest first
+	Description string // Human-readable description
+	value       uint32 // Lower is better
+}
+
+// EvaluateLowHand finds the best five-card low hand from available cards
+func EvaluateLowHand(cards []Card, lowType LowType) LowHandResult {
+	best := LowHandResult{Type: lowType, Description: "No low"}
+	if len(cards) < 5 {
+		return best
+	}
+
+	found := false
+	for _, combo := range generateCombinations(cards, 5) {
+		value := lowValue(combo, lowType)
+		if !found || value < best.value {
+			best.value = value
+			best.Cards = combo
+			found = true
+		}
+	}
+
+	best.Qualified = lowType != AceToFiveEightOrBetter || qualifiesEightOrBetter(best.Cards)
+	if !best.Qualified {
+		best.Cards = nil
+		return best
+	}
+
+	sortLowCards(best.Cards, lowType)
+	best.Description = describeLow(best.Cards, lowType)
+	return best
+}
+
+// CompareLowHands compares two low hands of the same type.
+// Returns 1 if a is the better (lower) hand, -1 if b is and 0 for a tie.
+// A qualifying hand always beats one that does not qualify.
+func CompareLowHands(a, b LowHandResult) int {
+	if a.Qualified != b.Qualified {
+		if a.Qualified {
+			return 1
+		}
+		return -1
+	}
+	if a.value < b.value {
+		return 1
+	} else if a.value > b.value {
+		return -1
+	}
+	return 0
+}
+
+// lowValue scores exactly five cards, lower values being better lows
+func lowValue(cards []Card, lowType LowType) uint32 {
+	if lowType == DeuceToSeven {
+		// The worst high hand is the best low, with the ace always high
+		value := EvaluateHandValue(cards)
+		if high := straightHigh(handRankMask(cards)); high == Five {
+			// A-2-3-4-5 is ace high, not a straight
+			rank := HighCard
+			if value.Rank() == StraightFlush {
+				rank = Flush
+			}
+			value = makeValue(rank, Ace, Five, Four, Three, Two)
+		}
+		return uint32(value)
+	}
+
+	// Ace-to-five: pairs count against the hand, then the highest card
+	var counts [Ace + 1]int
+	for _, c := range cards {
+		counts[lowRank(c.Rank)]++
+	}
+
+	var ranks []int
+	maxCount, distinct := 0, 0
+	for n := 4; n >= 1; n-- {
+		for r := int(King); r >= 1; r-- {
+			if counts[r] == n {
+				maxCount = max(maxCount, n)
+				distinct++
+				for i := 0; i < n; i++ {
+					ranks = append(ranks, r)
+				}
+			}
+		}
+	}
+
+	// No pair, one pair, two pair, trips, full house, quads
+	var category uint32
+	switch {
+	case maxCount == 4:
+		category = 5
+	case maxCount == 3 && distinct == 2:
+		category = 4
+	case maxCount == 3:
+		category = 3
+	case maxCount == 2 && distinct == 3:
+		category = 2
+	case maxCount == 2:
+		category = 1
+	}
+
+	value := category << 20
+	for i, r := range ranks {
+		value |= uint32(r) << (16 - 4*i)
+	}
+	return value
+}
+
+// lowRank returns the ace-to-five rank, with the ace as one
+func lowRank(r Rank) int {
+	if r == Ace {
+		return 1
+	}
+	return int(r)
+}
+
+func handRankMask(cards []Card) uint16 {
+	var mask uint16
+	for _, c := range cards {
+		mask |= 1 << c.Rank
+	}
+	return mask
+}
+
+// qualifiesEightOrBetter checks for five different ranks, eight or lower
+func qualifiesEightOrBetter(cards []Card) bool {
+	var seen [Ace + 1]bool
+	for _, c := range cards {
+		r := lowRank(c.Rank)
+		if r > 8 || seen[r] {
+			return false
+		}
+		seen[r] = true
+	}
+	return len(cards) == 5
+}
+
+// sortLowCards orders a low hand highest card first
+func sortLowCards(cards []Card, lowType LowType) {
+	key := func(c Card) int {
+		if lowType == DeuceToSeven {
+			return int(c.Rank)
+		}
+		return lowRank(c.Rank)
+	}
+	for i := 1; i < len(cards); i++ {
+		for j := i; j > 0 && key(cards[j]) > key(cards[j-1]); j-- {
+			cards[j], cards[j-1] = cards[j-1], cards[j]
+		}
+	}
+}
+
+// describeLow names a low hand, e.g. "Seven-Five low (7-5-4-3-2)"
+func describeLow(cards []Card, lowType LowType) string {
+	symbols := make([]string, len(cards))
+	for i, c := range cards {
+		symbols[i] = c.Rank.Symbol()
+	}
+	ranks := strings.Join(symbols, "-")
+
+	if lowType == DeuceToSeven {
+		high := EvaluateHandValue(cards)
+		if straightHigh(handRankMask(cards)) == Five {
+			high = makeValue(HighCard)
+		}
+		if high.Rank() != HighCard {
+			return fmt.Sprintf("%s (%s)", high.Rank(), ranks)
+		}
+	} else if hasPair(cards) {
+		return fmt.Sprintf("Paired low (%s)", ranks)
+	}
+
+	return fmt.Sprintf("%s-%s low (%s)", cards[0].rankString(), cards[1].rankString(), ranks)
+}
+
+func hasPair(cards []Card) bool {
+	var seen [Ace + 1]bool
+	for _, c := range cards {
+		if seen[c.Rank] {
+			return true
+		}
+		seen[c.Rank] = true
+	}
+	return false
+}
+
+// String returns the string representation of a low type
+func (l LowType) String() string {
+	switch l {
+	case AceToFive:
+		return "ace-to-five"
+	case AceToFiveEightOrBetter:
+		return "ace-to-five eight or better"
+	case DeuceToSeven:
+		return "deuce-to-seven"
+	default:
+		return "unknown"
+	}
+}
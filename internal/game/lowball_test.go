@@ -0,0 +1,69 @@
+package game
+
+import "testing"
+
+func TestEvaluateLowHand(t *testing.T) {
+	tests := []struct {
+		name      string
+		cards     string
+		lowType   LowType
+		qualified bool
+		want      string
+	}{
+		{"wheel", "5h 4d 3c 2s Ah", AceToFive, true, "Five-Four low (5-4-3-2-A)"},
+		{"flush ignored", "7h 5h 4h 3h 2h", AceToFive, true, "Seven-Five low (7-5-4-3-2)"},
+		{"pair counts against", "Kc 3d 3c 2s Ah", AceToFive, true, "Paired low (K-3-3-2-A)"},
+		{"best five of seven", "Kc Qd 8c 6s 4h 2d Ac", AceToFive, true, "Eight-Six low (8-6-4-2-A)"},
+		{"eight qualifies", "Kc Qd 8c 6s 4h 2d Ac", AceToFiveEightOrBetter, true, "Eight-Six low (8-6-4-2-A)"},
+		{"nine does not qualify", "9c 6s 4h 2d Ac", AceToFiveEightOrBetter, false, "No low"},
+		{"pair does not qualify", "8c 6s 4h 4d Ac", AceToFiveEightOrBetter, false, "No low"},
+		{"unpaired five of seven qualify", "8c 6s 4h 4d Ac 4c 2h", AceToFiveEightOrBetter, true, "Eight-Six low (8-6-4-2-A)"},
+		{"too few cards", "4h 3d 2c Ac", AceToFiveEightOrBetter, false, "No low"},
+		{"number one", "7h 5d 4c 3s 2h", DeuceToSeven, true, "Seven-Five low (7-5-4-3-2)"},
+		{"ace plays high", "Ah 5d 4c 3s 2h", DeuceToSeven, true, "Ace-Five low (A-5-4-3-2)"},
+		{"straight counts against", "6h 5d 4c 3s 2h", DeuceToSeven, true, "Straight (6-5-4-3-2)"},
+		{"flush counts against", "8h 5h 4h 3h 2h", DeuceToSeven, true, "Flush (8-5-4-3-2)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EvaluateLowHand(mustCards(t, tt.cards), tt.lowType)
+			if got.Qualified != tt.qualified || got.Description != tt.want {
+				t.Errorf("got %v %q, want %v %q", got.Qualified, got.Description, tt.qualified, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareLowHands(t *testing.T) {
+	// Each pair is listed better hand first
+	tests := []struct {
+		name          string
+		lowType       LowType
+		better, worse string
+	}{
+		{"wheel beats six low", AceToFive, "5h 4d 3c 2s Ah", "6h 4d 3c 2s Ah"},
+		{"second card decides", AceToFive, "6h 4d 3c 2s Ah", "6h 5d 3c 2s Ah"},
+		{"unpaired beats paired", AceToFive, "Kh Qd Jc 9s 8h", "3h 3d 2c 2s Ah"},
+		{"wheel with a flush", AceToFive, "5h 4h 3h 2h Ah", "6h 4d 3c 2s Ah"},
+		{"qualified beats unqualified", AceToFiveEightOrBetter, "8h 7d 6c 5s 4h", "9h 4d 3c 2s Ah"},
+		{"seven-five beats seven-six", DeuceToSeven, "7h 5d 4c 3s 2h", "7h 6d 4c 3s 2h"},
+		{"seven low beats the ace", DeuceToSeven, "7h 5d 4c 3s 2h", "Ah 5d 4c 3s 2h"},
+		{"eight low beats a straight", DeuceToSeven, "8h 6d 4c 3s 2h", "6h 5d 4c 3s 2h"},
+		{"king low beats a pair", DeuceToSeven, "Kh Qd Jc 9s 8h", "3h 3d 5c 4s 2h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			better := EvaluateLowHand(mustCards(t, tt.better), tt.lowType)
+			worse := EvaluateLowHand(mustCards(t, tt.worse), tt.lowType)
+			if got := CompareLowHands(better, worse); got != 1 {
+				t.Errorf("CompareLowHands(%s, %s) = %d, want 1", better.Description, worse.Description, got)
+			}
+			if got := CompareLowHands(worse, better); got != -1 {
+				t.Errorf("CompareLowHands(%s, %s) = %d, want -1", worse.Description, better.Description, got)
+			}
+			if got := CompareLowHands(better, better); got != 0 {
+				t.Errorf("CompareLowHands(%s, itself) = %d, want 0", better.Description, got)
+			}
+		})
+	}
+}
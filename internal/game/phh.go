@@ -0,0 +1,445 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePHH parses hands in the Poker Hand History format, a TOML file per
+// hand or several under [section] headers. Only no-limit hold'em ("NT")
+// without antes or straddles is supported.
+func ParsePHH(r io.Reader) ([]ImportedHand, error) {
+	docs, err := readPHH(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("no PHH hands found")
+	}
+
+	hands := make([]ImportedHand, 0, len(docs))
+	for i, doc := range docs {
+		hand, err := parsePHHHand(doc)
+		if err != nil {
+			return nil, fmt.Errorf("phh hand %d: %w", i+1, err)
+		}
+		hands = append(hands, *hand)
+	}
+	return hands, nil
+}
+
+// phhDoc maps each key of a hand to its raw TOML value
+type phhDoc map[string]string
+
+// readPHH splits the TOML into hands and keys. It handles the subset PHH
+// uses: strings, numbers, booleans and arrays of them, arrays spanning
+// several lines, and comments.
+func readPHH(r io.Reader) ([]phhDoc, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var docs []phhDoc
+	doc := phhDoc{}
+	key, value := "", ""
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(stripTOMLComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+
+		if key != "" {
+			value += " " + line
+		} else if strings.HasPrefix(line, "[") {
+			// A new hand in a .phhs file
+			if len(doc) > 0 {
+				docs = append(docs, doc)
+			}
+			doc = phhDoc{}
+			continue
+		} else {
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("phh line %d: expected key = value", n)
+			}
+			key, value = strings.TrimSpace(k), strings.TrimSpace(v)
+		}
+
+		if strings.Count(value, "[") > strings.Count(value, "]") {
+			continue
+		}
+		doc[key] = value
+		key = ""
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return nil, fmt.Errorf("phh: unterminated value for %s", key)
+	}
+	if len(doc) > 0 {
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// stripTOMLComment removes a # comment outside of any string
+func stripTOMLComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '#':
+			if !inString {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// array splits a TOML array into its raw elements
+func (d phhDoc) array(key string) []string {
+	v, ok := d[key]
+	if !ok {
+		return nil
+	}
+	v = strings.TrimSpace(v)
+	v = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+
+	var elems []string
+	inString, start := false, 0
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case ',':
+			if !inString {
+				elems = append(elems, v[start:i])
+				start = i + 1
+			}
+		}
+	}
+	elems = append(elems, v[start:])
+
+	out := elems[:0]
+	for _, e := range elems {
+		if e = strings.TrimSpace(e); e != "" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (d phhDoc) string(key string) string {
+	return tomlString(d[key])
+}
+
+func tomlString(v string) string {
+	if s, err := strconv.Unquote(strings.TrimSpace(v)); err == nil {
+		return s
+	}
+	return strings.Trim(strings.TrimSpace(v), `"'`)
+}
+
+// amounts parses an array of amounts
+func (d phhDoc) amounts(key string, cents bool) ([]int, error) {
+	var out []int
+	for _, e := range d.array(key) {
+		v, err := parseAmount(e, cents)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func parsePHHHand(d phhDoc) (*ImportedHand, error) {
+	if variant := d.string("variant"); variant != "NT" {
+		return nil, fmt.Errorf("%w: variant %q", ErrUnsupportedHand, variant)
+	}
+
+	h := &ImportedHand{
+		Format:    "phh",
+		ID:        tomlString(d["hand"]),
+		Table:     tomlString(d["table"]),
+		Currency:  d.string("currency"),
+		Collected: make(map[string]int),
+		Uncalled:  make(map[string]int),
+	}
+	cents := h.Currency != ""
+
+	stacks, err := d.amounts("starting_stacks", cents)
+	if err != nil {
+		return nil, err
+	}
+	blinds, err := d.amounts("blinds_or_straddles", cents)
+	if err != nil {
+		return nil, err
+	}
+	antes, err := d.amounts("antes", cents)
+	if err != nil {
+		return nil, err
+	}
+	n := len(stacks)
+	if n < 2 || len(blinds) != n {
+		return nil, fmt.Errorf("need starting_stacks and blinds_or_straddles for every player")
+	}
+	for _, a := range antes {
+		if a != 0 {
+			return nil, fmt.Errorf("%w: antes", ErrUnsupportedHand)
+		}
+	}
+
+	// Players are listed in position order from the small blind
+	names := d.array("players")
+	seats := d.array("seats")
+	for i := 0; i < n; i++ {
+		seat := ImportedSeat{Seat: i + 1, Name: fmt.Sprintf("p%d", i+1), Chips: stacks[i]}
+		if i < len(names) {
+			seat.Name = tomlString(names[i])
+		}
+		if i < len(seats) {
+			if seat.Seat, err = strconv.Atoi(seats[i]); err != nil {
+				return nil, fmt.Errorf("seats: %w", err)
+			}
+		}
+		h.Seats = append(h.Seats, seat)
+	}
+
+	// The smaller blind is the small blind, heads-up lists it second
+	sb, bb := -1, -1
+	for i, b := range blinds {
+		switch {
+		case b == 0:
+		case sb < 0:
+			sb = i
+		case bb < 0:
+			bb = i
+		default:
+			return nil, fmt.Errorf("%w: straddles", ErrUnsupportedHand)
+		}
+	}
+	if bb < 0 {
+		return nil, fmt.Errorf("need a small and big blind")
+	}
+	if blinds[sb] > blinds[bb] {
+		sb, bb = bb, sb
+	}
+	h.SmallBlind, h.BigBlind = blinds[sb], blinds[bb]
+	h.SmallBlindPlayer, h.BigBlindPlayer = h.Seats[sb].Name, h.Seats[bb].Name
+	if n == 2 {
+		h.Button = h.Seats[sb].Seat
+	} else {
+		h.Button = h.Seats[n-1].Seat
+	}
+
+	if year, err := strconv.Atoi(d["year"]); err == nil {
+		month, _ := strconv.Atoi(d["month"])
+		day, _ := strconv.Atoi(d["day"])
+		clock, _ := time.Parse("15:04:05", d.string("time"))
+		h.Time = time.Date(year, time.Month(month), day, clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+	}
+
+	t := newPHHTracker(stacks)
+	t.post(sb, blinds[sb])
+	t.post(bb, blinds[bb])
+	for _, raw := range d.array("actions") {
+		if err := t.apply(h, tomlString(raw), cents); err != nil {
+			return nil, fmt.Errorf("action %q: %w", tomlString(raw), err)
+		}
+	}
+
+	// Winnings are the change in stacks after what each player put in
+	if final, err := d.amounts("finishing_stacks", cents); err == nil && len(final) == n {
+		for i, f := range final {
+			h.Collected[h.Seats[i].Name] = f - t.stacks[i]
+		}
+	} else {
+		h.Collected = nil
+	}
+	t.returnUncalled(h)
+
+	if rake, ok := d["rake"]; ok {
+		if h.Rake, err = parseAmount(rake, cents); err != nil {
+			return nil, fmt.Errorf("rake: %w", err)
+		}
+	}
+	for _, v := range t.invested {
+		h.TotalPot += v
+	}
+	for _, v := range h.Uncalled {
+		h.TotalPot -= v
+	}
+	return h, nil
+}
+
+// phhTracker follows the bets through a PHH action list, which only
+// records "cc" and "cbr" amounts, to classify each as a check, call, bet or
+// raise
+type phhTracker struct {
+	stacks   []int
+	invested []int
+	bets     []int // This street
+	folded   []bool
+	current  int
+	street   BettingRound
+}
+
+func newPHHTracker(stacks []int) *phhTracker {
+	n := len(stacks)
+	return &phhTracker{
+		stacks:   append([]int{}, stacks...),
+		invested: make([]int, n),
+		bets:     make([]int, n),
+		folded:   make([]bool, n),
+	}
+}
+
+func (t *phhTracker) post(i, amount int) {
+	amount = min(amount, t.stacks[i])
+	t.stacks[i] -= amount
+	t.bets[i] += amount
+	t.invested[i] += amount
+	t.current = max(t.current, t.bets[i])
+}
+
+// apply parses one action such as "d dh p1 AsKs", "d db 7h8h9h", "p2 cbr
+// 300", "p3 cc", "p1 f" or "p2 sm AsKs"
+func (t *phhTracker) apply(h *ImportedHand, action string, cents bool) error {
+	fields := strings.Fields(action)
+	if len(fields) < 2 {
+		return fmt.Errorf("too short")
+	}
+
+	if fields[0] == "d" {
+		switch {
+		case fields[1] == "dh" && len(fields) == 4:
+			i, err := t.player(fields[2])
+			if err != nil {
+				return err
+			}
+			if !strings.Contains(fields[3], "?") {
+				cards, err := ParseCards(fields[3])
+				if err != nil {
+					return err
+				}
+				h.Seats[i].HoleCards = cards
+			}
+		case fields[1] == "db" && len(fields) == 3:
+			cards, err := ParseCards(fields[2])
+			if err != nil {
+				return err
+			}
+			h.Board = append(h.Board, cards...)
+			t.street++
+			t.current = 0
+			for i := range t.bets {
+				t.bets[i] = 0
+			}
+		default:
+			return fmt.Errorf("%w: dealer action", ErrUnsupportedHand)
+		}
+		return nil
+	}
+
+	i, err := t.player(fields[0])
+	if err != nil {
+		return err
+	}
+	a := ImportedAction{Player: h.Seats[i].Name, Street: t.street}
+	switch fields[1] {
+	case "f":
+		a.Action = Fold
+		t.folded[i] = true
+
+	case "cc":
+		toCall := min(t.current-t.bets[i], t.stacks[i])
+		if toCall == 0 {
+			a.Action = Check
+			break
+		}
+		a.Action, a.Amount, a.AllIn = Call, toCall, toCall == t.stacks[i]
+		t.post(i, toCall)
+
+	case "cbr":
+		if len(fields) != 3 {
+			return fmt.Errorf("missing amount")
+		}
+		to, err := parseAmount(fields[2], cents)
+		if err != nil {
+			return err
+		}
+		a.Action, a.Amount = Raise, to
+		if t.current == 0 {
+			a.Action = Bet
+		}
+		a.AllIn = to-t.bets[i] >= t.stacks[i]
+		t.post(i, to-t.bets[i])
+
+	case "sm":
+		if len(fields) == 3 && !strings.Contains(fields[2], "?") {
+			cards, err := ParseCards(fields[2])
+			if err != nil {
+				return err
+			}
+			h.Seats[i].HoleCards = cards
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: player action %q", ErrUnsupportedHand, fields[1])
+	}
+	h.Actions = append(h.Actions, a)
+	return nil
+}
+
+// player converts "p3" to a player index
+func (t *phhTracker) player(s string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimPrefix(s, "p"))
+	if err != nil || !strings.HasPrefix(s, "p") || n < 1 || n > len(t.stacks) {
+		return 0, fmt.Errorf("invalid player %q", s)
+	}
+	return n - 1, nil
+}
+
+// returnUncalled takes back the part of the largest investment nobody
+// matched. PHH winnings include it, so it moves out of Collected.
+func (t *phhTracker) returnUncalled(h *ImportedHand) {
+	top, second := -1, 0
+	for i, v := range t.invested {
+		switch {
+		case top < 0 || v > t.invested[top]:
+			if top >= 0 {
+				second = t.invested[top]
+			}
+			top = i
+		case v > second:
+			second = v
+		}
+	}
+	if top < 0 || t.folded[top] || t.invested[top] <= second {
+		return
+	}
+
+	excess := t.invested[top] - second
+	name := h.Seats[top].Name
+	h.Uncalled[name] = excess
+	if h.Collected != nil {
+		h.Collected[name] -= excess
+	}
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// phhHand is the first hand of starsGame in PHH, players listed from the
+// small blind
+const phhHand = `# The three-way all-in
+variant = "NT"
+hand = 1
+table = "Golden"
+players = ["c", "a", "b"]
+seats = [3, 1, 2]
+antes = [0, 0, 0]
+blinds_or_straddles = [5, 10, 0]
+starting_stacks = [300, 1000, 100]
+actions = [
+  "d dh p1 KsKh",
+  "d dh p2 7c2d",
+  "d dh p3 AsAh",
+  "p3 cbr 100",
+  "p1 cbr 300",
+  "p2 cbr 1000",
+  "d db Qd9c5s",
+  "d db 3h",
+  "d db 8d",
+  "p1 sm KsKh",
+  "p2 sm 7c2d",
+  "p3 sm AsAh",
+]
+finishing_stacks = [400, 700, 300]
+`
+
+func TestParsePHHReplays(t *testing.T) {
+	hands, err := ParsePHH(strings.NewReader(phhHand))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hands) != 1 {
+		t.Fatalf("parsed %d hands, want 1", len(hands))
+	}
+	h := hands[0]
+	if h.Button != 2 || h.SmallBlindPlayer != "c" || h.BigBlindPlayer != "a" {
+		t.Errorf("button %d, blinds %s and %s", h.Button, h.SmallBlindPlayer, h.BigBlindPlayer)
+	}
+	if want := map[string]int{"a": 700}; !reflect.DeepEqual(h.Uncalled, want) {
+		t.Errorf("uncalled = %v, want %v", h.Uncalled, want)
+	}
+
+	g, err := h.Replay()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, want := handEvents(g.Events, 1), handEvents(starsGame(t), 1)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replays as\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestParsePHHHeadsUp(t *testing.T) {
+	// Heads-up the big blind is listed first and the button posts the
+	// small blind
+	hands, err := ParsePHH(strings.NewReader(`variant = "NT"
+players = ["bb", "sb"]
+blinds_or_straddles = [2, 1]
+starting_stacks = [100, 100]
+actions = ["d dh p1 7c2d", "d dh p2 AsAh", "p2 cbr 6", "p1 f"]
+finishing_stacks = [98, 102]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := hands[0]
+	if h.SmallBlindPlayer != "sb" || h.Button != 2 {
+		t.Errorf("small blind %s, button seat %d", h.SmallBlindPlayer, h.Button)
+	}
+	if _, err := h.Replay(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParsePHHRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		unsupported bool
+	}{
+		{"empty", "", false},
+		{"not toml", "hello", false},
+		{"unterminated array", strings.Replace(phhHand, `"p3 sm AsAh",
+]`, `"p3 sm AsAh",`, 1), false},
+		{"omaha", strings.Replace(phhHand, `"NT"`, `"PO"`, 1), true},
+		{"antes", strings.Replace(phhHand, "antes = [0, 0, 0]", "antes = [1, 1, 1]", 1), true},
+		{"straddle", strings.Replace(phhHand, "[5, 10, 0]", "[5, 10, 20]", 1), true},
+		{"one blind", strings.Replace(phhHand, "[5, 10, 0]", "[0, 10, 0]", 1), false},
+		{"missing stacks", strings.Replace(phhHand, "starting_stacks = [300, 1000, 100]", "", 1), false},
+		{"bad stack", strings.Replace(phhHand, "[300, 1000, 100]", "[300, lots, 100]", 1), false},
+		{"bad seat", strings.Replace(phhHand, "seats = [3, 1, 2]", "seats = [3, x, 2]", 1), false},
+		{"unknown player", strings.Replace(phhHand, `"p3 cbr 100"`, `"p7 cbr 100"`, 1), false},
+		{"bad card", strings.Replace(phhHand, `"d db 3h"`, `"d db 3x"`, 1), false},
+		{"raise without amount", strings.Replace(phhHand, `"p3 cbr 100"`, `"p3 cbr"`, 1), false},
+		{"short action", strings.Replace(phhHand, `"p3 cbr 100"`, `"p3"`, 1), false},
+		{"dealer action", strings.Replace(phhHand, `"d db 3h"`, `"d xx 3h"`, 1), true},
+		{"player action", strings.Replace(phhHand, `"p1 sm KsKh"`, `"p1 sd KsKh"`, 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePHH(strings.NewReader(tt.text))
+			if err == nil {
+				t.Fatal("parsed")
+			}
+			if got := errors.Is(err, ErrUnsupportedHand); got != tt.unsupported {
+				t.Errorf("unsupported = %v, want %v: %v", got, tt.unsupported, err)
+			}
+		})
+	}
+}
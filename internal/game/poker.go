@@ -3,8 +3,6 @@ package game
 import (
 	"errors"
 	"fmt"
-	"math/rand"
-	"time"
 )
 
 // PokerGame represents a poker game instance
@@ -12,6 +10,19 @@ type PokerGame struct {
 	// Game configuration
 	SmallBlind int
 	BigBlind   int
+	HiLo       bool      // Split pots with the best eight-or-better low
+	RNG        RNG       // Shuffles the deck for each hand
+	Rake       RakeRules // House cut of each pot
+
+	// Provably fair shuffling, used instead of RNG when enabled
+	ProvablyFair      bool
+	fairShuffle       *FairShuffle // Current hand
+	nextFairShuffle   *FairShuffle // Committed for the next hand
+	shuffleCommitment string       // Published commitment for the current hand
+
+	// Scripted deal for the next hand, for tests and tutorials
+	nextScript      *ScriptedDeal
+	nextStackedDeck []Card
 
 	// Players
 	Players      []*PokerPlayer
@@ -35,6 +46,38 @@ type PokerGame struct {
 	HandNumber   int
 	HandComplete bool
 	Winners      []Winner
+	HandRake     int // Taken from this hand's pot
+
+	// All-in tracking
+	AllInEquity []StreetEquity     // Contenders' equity on each street once all-in
+	allInEV     map[string]float64 // Expected pot share per player at the all-in
+	runningOut  bool               // The betting is over and the board is being dealt
+
+	// Deal an all-in run-out a street at a time, one for each call to
+	// RunOutStreet, so GameState shows the equity as each street comes.
+	// Otherwise the whole board is dealt as soon as the betting ends and
+	// only OnEvent sees the equity street by street.
+	StepRunOut bool
+
+	// Skip the equity calculations when players are all-in, which are slow,
+	// e.g. to simulate hands quickly
+	SkipAllInEquity bool
+
+	// Completed hands
+	History []HandHistory
+
+	// Every state transition since the first hand kept, and a hook called
+	// as each happens. Events are unredacted, see Event.
+	Events  []Event
+	OnEvent func(Event)
+
+	// Completed hands kept in History and Events, so a long-running game
+	// doesn't grow without bound: once twice as many have been played, the
+	// older ones are dropped as the next hand starts. 0 keeps them all.
+	KeepHands int
+
+	// HUD statistics, updated as each hand ends; nil to not keep them
+	Stats *StatsTracker
 }
 
 // PokerPlayer represents a player in the game
@@ -65,6 +108,10 @@ type Winner struct {
 	HandRank    HandRank
 	BestHand    []Card
 	Description string
+
+	// Set when the player won a low half in a hi-lo game
+	LowHand        []Card
+	LowDescription string
 }
 
 // BettingRound represents the current betting round
@@ -90,16 +137,22 @@ const (
 	AllIn
 )
 
-// NewPokerGame creates a new poker game
+// NewPokerGame creates a new poker game shuffled with crypto/rand
 func NewPokerGame(smallBlind, bigBlind int) *PokerGame {
-	rand.Seed(time.Now().UnixNano())
+	return NewPokerGameWithRNG(smallBlind, bigBlind, NewCryptoRNG())
+}
 
+// NewPokerGameWithRNG creates a new poker game shuffled with rng, e.g. a
+// seeded RNG to reproduce every hand
+func NewPokerGameWithRNG(smallBlind, bigBlind int, rng RNG) *PokerGame {
 	return &PokerGame{
 		SmallBlind:  smallBlind,
 		BigBlind:    bigBlind,
+		RNG:         rng,
 		Players:     make([]*PokerPlayer, 0),
 		DealerIndex: 0,
-		Deck:        NewDeck(),
+		Deck:        NewDeckWithRNG(rng),
+		KeepHands:   DefaultKeepHands,
 	}
 }
 
@@ -109,16 +162,49 @@ func (g *PokerGame) AddPlayer(id, name string, chips int) error {
 		return errors.New("maximum 6 players allowed")
 	}
 
-	player := &PokerPlayer{
-		ID:           id,
-		Name:         name,
-		Chips:        chips,
-		IsActive:     true,
-		SeatPosition: len(g.Players),
+	return g.emit(Event{
+		Type:     EventPlayerJoined,
+		PlayerID: id,
+		Name:     name,
+		Seat:     len(g.Players),
+		Amount:   chips,
+	})
+}
+
+// Rebuy adds chips to a player's stack between hands
+func (g *PokerGame) Rebuy(playerID string, chips int) error {
+	if chips <= 0 {
+		return errors.New("rebuy must be for some chips")
+	}
+	if g.handInProgress() {
+		return ErrGameInProgress
 	}
+	if _, _, err := g.findPlayer(playerID); err != nil {
+		return ErrPlayerNotFound
+	}
+	return g.emit(Event{Type: EventRebuy, PlayerID: playerID, Amount: chips})
+}
 
-	g.Players = append(g.Players, player)
-	return nil
+// CashOut takes a player's chips off the table between hands, leaving the
+// seat empty until they rebuy, and returns the chips taken
+func (g *PokerGame) CashOut(playerID string) (int, error) {
+	if g.handInProgress() {
+		return 0, ErrGameInProgress
+	}
+	p, _, err := g.findPlayer(playerID)
+	if err != nil {
+		return 0, ErrPlayerNotFound
+	}
+	chips := p.Chips
+	if chips == 0 {
+		return 0, ErrNoChips
+	}
+	return chips, g.emit(Event{Type: EventCashedOut, PlayerID: playerID, Amount: chips})
+}
+
+// handInProgress reports whether a hand has started and not yet finished
+func (g *PokerGame) handInProgress() bool {
+	return g.HandNumber > 0 && !g.HandComplete
 }
 
 // StartNewHand starts a new hand
@@ -127,62 +213,61 @@ func (g *PokerGame) StartNewHand() error {
 		return errors.New("need at least 2 players to start")
 	}
 
-	// Reset for new hand
-	g.HandNumber++
-	g.HandComplete = false
-	g.Pot = 0
-	g.SidePots = nil
-	g.CurrentBet = 0
-	g.MinRaise = g.BigBlind
-	g.BettingRound = PreFlop
-	g.CommunityCards = nil
-	g.Winners = nil
-	g.LastAggressor = ""
-
-	// Reset deck
-	g.Deck = NewDeck()
-	g.Deck.Shuffle()
-
-	// Reset players
-	activePlayers := 0
+	withChips := 0
 	for _, p := range g.Players {
 		if p.Chips > 0 {
-			p.IsActive = true
-			p.IsFolded = false
-			p.IsAllIn = false
-			p.HasActed = false
-			p.CurrentBet = 0
-			p.TotalBetInHand = 0
-			p.HoleCards = nil
-			activePlayers++
-		} else {
-			p.IsActive = false
+			withChips++
 		}
 	}
+	if withChips < 2 {
+		return errors.New("not enough players with chips")
+	}
 
-	g.NumActivePlayers = activePlayers
+	// Build the deck before anything is emitted, so a bad script or a
+	// failed shuffle leaves the last hand as it was
+	deck, commitment, err := g.nextDeck()
+	if err != nil {
+		return err
+	}
 
-	if activePlayers < 2 {
-		return errors.New("not enough players with chips")
+	if err := g.rotateLog(); err != nil {
+		return err
 	}
 
-	// Move dealer button
-	g.moveDealerButton()
+	// Reset for new hand and move the dealer button
+	err = g.emit(Event{
+		Type:       EventHandStarted,
+		HandNumber: g.HandNumber + 1,
+		Seat:       g.nextSeatWithChips(g.DealerIndex),
+		SmallBlind: g.SmallBlind,
+		BigBlind:   g.BigBlind,
+		HiLo:       g.HiLo,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The deck order stays out of the event log
+	if err := g.emit(Event{Type: EventDeckShuffled, Commitment: commitment}); err != nil {
+		return err
+	}
+	g.Deck = deck
 
 	// Post blinds
-	g.postBlinds()
+	if err := g.postBlinds(); err != nil {
+		return err
+	}
 
 	// Deal hole cards
-	g.dealHoleCards()
-
-	// Set current player (left of big blind)
-	g.CurrentIndex = g.getNextActivePlayer(g.getBigBlindIndex())
-
-	return nil
+	return g.dealHoleCards()
 }
 
 // ProcessAction processes a player action
 func (g *PokerGame) ProcessAction(playerID string, action ActionType, amount int) error {
+	if g.runningOut {
+		return ErrRunningOut
+	}
+
 	// Validate it's the player's turn
 	currentPlayer := g.Players[g.CurrentIndex]
 	if currentPlayer.ID != playerID {
@@ -193,19 +278,24 @@ func (g *PokerGame) ProcessAction(playerID string, action ActionType, amount int
 		return errors.New("player cannot act")
 	}
 
-	// Process the action
+	// Betting more than the stack puts the player all-in
+	if (action == Bet || action == Raise) && amount-currentPlayer.CurrentBet >= currentPlayer.Chips {
+		action = AllIn
+	}
+
+	// Validate the action and work out the chips it puts in
 	switch action {
 	case Check:
 		if g.CurrentBet > currentPlayer.CurrentBet {
 			return errors.New("cannot check, must call or fold")
 		}
+		amount = 0
 
 	case Call:
-		callAmount := g.CurrentBet - currentPlayer.CurrentBet
-		if callAmount <= 0 {
+		amount = g.CurrentBet - currentPlayer.CurrentBet
+		if amount <= 0 {
 			return errors.New("nothing to call")
 		}
-		g.playerBet(currentPlayer, callAmount)
 
 	case Bet:
 		if g.CurrentBet > 0 {
@@ -214,51 +304,41 @@ func (g *PokerGame) ProcessAction(playerID string, action ActionType, amount int
 		if amount < g.BigBlind {
 			return errors.New("bet must be at least big blind")
 		}
-		g.playerBet(currentPlayer, amount)
-		g.CurrentBet = amount
-		g.MinRaise = amount
-		g.LastAggressor = playerID
-		g.resetHasActed()
 
 	case Raise:
 		if g.CurrentBet == 0 {
 			return errors.New("cannot raise, must bet")
 		}
-		raiseAmount := amount - currentPlayer.CurrentBet
+		// The raise is measured from the bet being faced
+		raiseAmount := amount - g.CurrentBet
 		if raiseAmount < g.MinRaise {
 			return fmt.Errorf("raise must be at least %d", g.MinRaise)
 		}
-		g.playerBet(currentPlayer, amount-currentPlayer.CurrentBet)
-		g.MinRaise = amount - g.CurrentBet
-		g.CurrentBet = amount
-		g.LastAggressor = playerID
-		g.resetHasActed()
 
 	case Fold:
-		currentPlayer.IsFolded = true
-		g.NumActivePlayers--
+		amount = 0
 
 	case AllIn:
-		allInAmount := currentPlayer.Chips
-		g.playerBet(currentPlayer, allInAmount)
-		if currentPlayer.CurrentBet > g.CurrentBet {
-			g.MinRaise = currentPlayer.CurrentBet - g.CurrentBet
-			g.CurrentBet = currentPlayer.CurrentBet
-			g.LastAggressor = playerID
-			g.resetHasActed()
-		}
+		amount = currentPlayer.Chips
+
+	default:
+		return fmt.Errorf("unknown action: %d", action)
 	}
 
-	currentPlayer.HasActed = true
+	err := g.emit(Event{
+		Type:     EventActionTaken,
+		PlayerID: playerID,
+		Action:   action.String(),
+		Amount:   amount,
+	})
+	if err != nil {
+		return err
+	}
 
 	// Check if betting round is complete
 	if g.isBettingRoundComplete() {
-		g.endBettingRound()
-	} else {
-		// Move to next player
-		g.CurrentIndex = g.getNextActivePlayer(g.CurrentIndex)
+		return g.endBettingRound()
 	}
-
 	return nil
 }
 
@@ -277,13 +357,18 @@ func (g *PokerGame) GetState() *GameState {
 			SeatPosition: p.SeatPosition,
 			HasActed:     p.HasActed,
 		}
+		if g.Stats != nil {
+			players[i].Stats = g.Stats.HUD(p.ID)
+		}
 	}
 
-	return &GameState{
+	state := &GameState{
 		Players:         players,
 		CurrentPlayerID: g.Players[g.CurrentIndex].ID,
 		DealerIndex:     g.DealerIndex,
 		Pot:             g.Pot,
+		SmallBlind:      g.SmallBlind,
+		BigBlind:        g.BigBlind,
 		CurrentBet:      g.CurrentBet,
 		MinRaise:        g.MinRaise,
 		CommunityCards:  g.CommunityCards,
@@ -292,7 +377,15 @@ func (g *PokerGame) GetState() *GameState {
 		Winners:         g.Winners,
 		HandNumber:      g.HandNumber,
 		SidePots:        g.SidePots,
+		AllInEquity:     g.AllInEquity,
+		RunningOut:      g.runningOut,
+		HiLo:            g.HiLo,
+		Rake:            g.HandRake,
+		LastEventSeq:    g.LastEventSeq(),
+
+		ShuffleCommitment: g.shuffleCommitment,
 	}
+	return state
 }
 
 // GetPlayerCards returns a player's hole cards (only for that player)
@@ -307,12 +400,63 @@ func (g *PokerGame) GetPlayerCards(playerID string) []Card {
 
 // Private helper methods
 
-func (g *PokerGame) moveDealerButton() {
-	// Find next active player to be dealer
-	g.DealerIndex = g.getNextActivePlayer(g.DealerIndex)
+// nextDeck builds and shuffles the deck for the next hand: from a script
+// or stacked order if one is pending, from the committed seeds when the
+// game is provably fair, and from RNG otherwise. It returns the provably
+// fair commitment, if any.
+func (g *PokerGame) nextDeck() (*Deck, string, error) {
+	if g.RNG == nil {
+		g.RNG = NewCryptoRNG()
+	}
+
+	deck, err := g.scriptedDeck()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var fair *FairShuffle
+	switch {
+	case deck != nil:
+	case g.ProvablyFair:
+		if g.nextFairShuffle == nil {
+			if _, err := g.CommitNextHand(); err != nil {
+				return nil, "", err
+			}
+		}
+		fair, g.nextFairShuffle = g.nextFairShuffle, nil
+		fair.HandNumber = g.HandNumber + 1
+		deck = NewDeckWithRNG(fair.RNG())
+		deck.Shuffle()
+	default:
+		deck = NewDeckWithRNG(g.RNG)
+		deck.Shuffle()
+	}
+
+	g.fairShuffle = fair
+	if fair == nil {
+		return deck, "", nil
+	}
+	return deck, fair.Commitment, nil
 }
 
+// nextSeatWithChips finds the next seat after from whose player can play
+// the coming hand
+func (g *PokerGame) nextSeatWithChips(from int) int {
+	for i := 1; i <= len(g.Players); i++ {
+		next := (from + i) % len(g.Players)
+		if g.Players[next].Chips > 0 {
+			return next
+		}
+	}
+	return from
+}
+
+// getSmallBlindIndex finds the small blind: the seat after the dealer, or
+// heads-up the dealer, who then acts first before the flop and last after
 func (g *PokerGame) getSmallBlindIndex() int {
+	if g.NumActivePlayers == 2 && g.Players[g.DealerIndex].IsActive {
+		return g.DealerIndex
+	}
 	return g.getNextActivePlayer(g.DealerIndex)
 }
 
@@ -331,38 +475,53 @@ func (g *PokerGame) getNextActivePlayer(from int) int {
 	return from
 }
 
-func (g *PokerGame) postBlinds() {
+func (g *PokerGame) postBlinds() error {
+	// Find both blinds first, a short stack is all-in once it posts
+	sbPlayer := g.Players[g.getSmallBlindIndex()]
+	bbPlayer := g.Players[g.getBigBlindIndex()]
+
 	// Small blind
-	sbIndex := g.getSmallBlindIndex()
-	sbPlayer := g.Players[sbIndex]
-	sbAmount := g.SmallBlind
-	if sbAmount > sbPlayer.Chips {
-		sbAmount = sbPlayer.Chips
+	err := g.emit(Event{
+		Type:     EventSmallBlindPosted,
+		PlayerID: sbPlayer.ID,
+		Amount:   min(g.SmallBlind, sbPlayer.Chips),
+	})
+	if err != nil {
+		return err
 	}
-	g.playerBet(sbPlayer, sbAmount)
 
 	// Big blind
-	bbIndex := g.getBigBlindIndex()
-	bbPlayer := g.Players[bbIndex]
-	bbAmount := g.BigBlind
-	if bbAmount > bbPlayer.Chips {
-		bbAmount = bbPlayer.Chips
-	}
-	g.playerBet(bbPlayer, bbAmount)
-
-	g.CurrentBet = g.BigBlind
+	return g.emit(Event{
+		Type:     EventBigBlindPosted,
+		PlayerID: bbPlayer.ID,
+		Amount:   min(g.BigBlind, bbPlayer.Chips),
+	})
 }
 
-func (g *PokerGame) dealHoleCards() {
-	// Deal 2 cards to each active player
-	for i := 0; i < 2; i++ {
-		for _, p := range g.Players {
-			if p.IsActive {
-				card := g.Deck.Draw()
-				p.HoleCards = append(p.HoleCards, card)
-			}
+func (g *PokerGame) dealHoleCards() error {
+	var players []*PokerPlayer
+	for _, p := range g.Players {
+		if p.IsActive {
+			players = append(players, p)
 		}
 	}
+
+	// Deal 2 cards to each active player, one at a time round the table
+	cards, err := g.Deck.peek(2 * len(players))
+	if err != nil {
+		return err
+	}
+	for i, p := range players {
+		err := g.emit(Event{
+			Type:     EventHoleCardsDealt,
+			PlayerID: p.ID,
+			Cards:    []Card{cards[i], cards[len(players)+i]},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *PokerGame) playerBet(player *PokerPlayer, amount int) {
@@ -407,50 +566,144 @@ func (g *PokerGame) isBettingRoundComplete() bool {
 	return true
 }
 
-func (g *PokerGame) endBettingRound() {
-	// Reset for next round
-	for _, p := range g.Players {
-		p.CurrentBet = 0
-		p.HasActed = false
-	}
-	g.CurrentBet = 0
-
-	// Create side pots if needed
-	g.createSidePots()
-
+func (g *PokerGame) endBettingRound() error {
 	// Check if hand should end
 	if g.shouldEndHand() {
-		g.endHand()
-		return
+		return g.endHand()
+	}
+
+	// No more betting possible, run out the board
+	if g.countPlayersToAct() < 2 && g.BettingRound < River {
+		return g.runOutBoard()
 	}
 
 	// Deal community cards
+	if err := g.dealNextStreet(); err != nil {
+		return err
+	}
+
+	if g.BettingRound == Showdown {
+		return g.endHand()
+	}
+	return nil
+}
+
+// dealNextStreet burns a card and deals the community cards for the next
+// betting round
+func (g *PokerGame) dealNextStreet() error {
+	count := 0
+	next := g.BettingRound
 	switch g.BettingRound {
 	case PreFlop:
 		// Deal flop (3 cards)
-		for i := 0; i < 3; i++ {
-			g.CommunityCards = append(g.CommunityCards, g.Deck.Draw())
-		}
-		g.BettingRound = Flop
-
+		count, next = 3, Flop
 	case Flop:
 		// Deal turn (1 card)
-		g.CommunityCards = append(g.CommunityCards, g.Deck.Draw())
-		g.BettingRound = Turn
-
+		count, next = 1, Turn
 	case Turn:
 		// Deal river (1 card)
-		g.CommunityCards = append(g.CommunityCards, g.Deck.Draw())
-		g.BettingRound = River
-
+		count, next = 1, River
 	case River:
 		// Go to showdown
-		g.endHand()
-		return
+		next = Showdown
+	}
+
+	e := Event{Type: EventStreetDealt, Street: next.String()}
+	if count > 0 {
+		cards, err := g.Deck.peek(1 + count)
+		if err != nil {
+			return err
+		}
+		e.Burn, e.Cards = &cards[0], cards[1:]
+	}
+	return g.emit(e)
+}
+
+// Run-out errors
+var (
+	ErrNoRunOut   = NewGameError("no all-in board to run out")
+	ErrRunningOut = NewGameError("the board is being run out")
+)
+
+// runOutBoard starts dealing the remaining streets when every contender
+// but one is all-in, recording equity as each street comes. With
+// StepRunOut it stops after the equity for the current street.
+func (g *PokerGame) runOutBoard() error {
+	var ev map[string]float64
+	if !g.SkipAllInEquity {
+		ev = g.allInPotShares()
+	}
+	if err := g.emit(Event{Type: EventAllIn, EV: ev}); err != nil {
+		return err
+	}
+	if err := g.emitStreetEquity(); err != nil {
+		return err
+	}
+	for !g.StepRunOut && g.runningOut {
+		if err := g.runOutStreet(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunOutStreet deals the next street of an all-in run-out when StepRunOut
+// is set, e.g. from a timer or the host's controls. The river ends the
+// hand.
+func (g *PokerGame) RunOutStreet() error {
+	if !g.runningOut {
+		return ErrNoRunOut
+	}
+	return g.runOutStreet()
+}
+
+// runOutStreet deals one street of a run-out and the equity after it,
+// going on to the showdown after the river
+func (g *PokerGame) runOutStreet() error {
+	if err := g.dealNextStreet(); err != nil {
+		return err
+	}
+	if g.BettingRound == River {
+		if err := g.dealNextStreet(); err != nil {
+			return err
+		}
+	}
+	if g.BettingRound == Showdown {
+		return g.endHand()
+	}
+	return g.emitStreetEquity()
+}
+
+// emitStreetEquity records the contenders' equity on a run-out street
+// before the river
+func (g *PokerGame) emitStreetEquity() error {
+	if g.BettingRound >= River || g.SkipAllInEquity {
+		return nil
 	}
+	equity := g.streetEquity()
+	return g.emit(Event{Type: EventEquityCalculated, Equity: &equity})
+}
 
-	// Set next player
-	g.CurrentIndex = g.getNextActivePlayer(g.DealerIndex)
+// countPlayersToAct counts players still able to bet
+func (g *PokerGame) countPlayersToAct() int {
+	count := 0
+	for _, p := range g.Players {
+		if p.IsActive && !p.IsFolded && !p.IsAllIn {
+			count++
+		}
+	}
+	return count
+}
+
+// contenders returns the players still in the hand
+func (g *PokerGame) contenders() []*PokerPlayer {
+	var contenders []*PokerPlayer
+	for _, p := range g.Players {
+		if p.IsActive && !p.IsFolded {
+			contenders = append(contenders, p)
+		}
+	}
+	return contenders
 }
 
 func (g *PokerGame) shouldEndHand() bool {
@@ -466,97 +719,230 @@ func (g *PokerGame) shouldEndHand() bool {
 }
 
 func (g *PokerGame) createSidePots() {
-	// Implementation for side pots when players are all-in
-	// This is complex - simplified version for now
+	pots := g.pots()
+	if len(pots) > 1 {
+		g.SidePots = pots[1:]
+	} else {
+		g.SidePots = nil
+	}
 }
 
-func (g *PokerGame) endHand() {
-	g.HandComplete = true
-	g.determineWinners()
-	g.awardPots()
+// pots splits the chips invested this hand into the main pot followed by
+// any side pots, each with the players eligible to win it
+func (g *PokerGame) pots() []SidePot {
+	// Each distinct investment of a contender caps a pot
+	var levels []int
+	for _, p := range g.contenders() {
+		found := false
+		for _, l := range levels {
+			if l == p.TotalBetInHand {
+				found = true
+				break
+			}
+		}
+		if !found {
+			levels = append(levels, p.TotalBetInHand)
+		}
+	}
+	for i := 1; i < len(levels); i++ {
+		for j := i; j > 0 && levels[j] < levels[j-1]; j-- {
+			levels[j], levels[j-1] = levels[j-1], levels[j]
+		}
+	}
+
+	var pots []SidePot
+	allocated := 0
+	prev := 0
+	for _, level := range levels {
+		pot := SidePot{}
+		for _, p := range g.Players {
+			pot.Amount += min(p.TotalBetInHand, level) - min(p.TotalBetInHand, prev)
+			if p.IsActive && !p.IsFolded && p.TotalBetInHand >= level {
+				pot.EligiblePlayers = append(pot.EligiblePlayers, p.ID)
+			}
+		}
+		prev = level
+		if pot.Amount == 0 {
+			continue
+		}
+		allocated += pot.Amount
+		pots = append(pots, pot)
+	}
+
+	// Folded chips above the last contender's level stay in the last pot
+	if len(pots) > 0 {
+		pots[len(pots)-1].Amount += g.Pot - allocated
+	}
+	return pots
 }
 
-func (g *PokerGame) determineWinners() {
-	// Find all non-folded players
-	var contenders []*PokerPlayer
-	for _, p := range g.Players {
-		if p.IsActive && !p.IsFolded {
-			contenders = append(contenders, p)
+func (g *PokerGame) endHand() error {
+	if err := g.takeRake(); err != nil {
+		return err
+	}
+	for _, w := range g.determineWinners() {
+		err := g.emit(Event{
+			Type:     EventPotAwarded,
+			PlayerID: w.PlayerID,
+			Amount:   w.Amount,
+			Winner:   &w,
+		})
+		if err != nil {
+			return err
 		}
 	}
 
+	// The server seed is revealed once the hand is over
+	e := Event{Type: EventHandEnded}
+	if g.fairShuffle != nil {
+		proof := g.fairShuffle.Proof()
+		e.Fairness = &proof
+	}
+	return g.emit(e)
+}
+
+// determineWinners works out who wins the pots and how much, one entry
+// for each winning player
+func (g *PokerGame) determineWinners() []Winner {
+	var winners []Winner
+	for _, pot := range g.potWinners() {
+		for _, pw := range pot {
+			w := winnerEntry(&winners, pw.PlayerID)
+			w.Amount += pw.Amount
+			if pw.Description != "" {
+				w.HandRank, w.BestHand, w.Description = pw.HandRank, pw.BestHand, pw.Description
+			}
+			if pw.LowHand != nil {
+				w.LowHand, w.LowDescription = pw.LowHand, pw.LowDescription
+			}
+		}
+	}
+	return winners
+}
+
+// potWinners works out who wins each of the raked pots, main pot first
+func (g *PokerGame) potWinners() [][]Winner {
+	contenders := g.contenders()
+
 	if len(contenders) == 1 {
 		// Only one player left, they win
 		winner := contenders[0]
-		g.Winners = []Winner{{
+		return [][]Winner{{{
 			PlayerID:    winner.ID,
-			Amount:      g.Pot,
+			Amount:      g.Pot - g.HandRake,
 			Description: "Last player standing",
-		}}
-		return
+		}}}
 	}
 
 	// Evaluate hands at showdown
-	type PlayerHand struct {
-		Player   *PokerPlayer
-		BestHand HandResult
-	}
-
-	var playerHands []PlayerHand
+	hands := make(map[string]HandResult, len(contenders))
+	lows := make(map[string]LowHandResult, len(contenders))
 	for _, p := range contenders {
-		allCards := append(p.HoleCards, g.CommunityCards...)
-		bestHand := EvaluateBestHand(allCards)
-		playerHands = append(playerHands, PlayerHand{
-			Player:   p,
-			BestHand: bestHand,
-		})
+		allCards := append(append([]Card{}, p.HoleCards...), g.CommunityCards...)
+		hands[p.ID] = EvaluateBestHand(allCards)
+		if g.HiLo {
+			lows[p.ID] = EvaluateLowHand(allCards, AceToFiveEightOrBetter)
+		}
 	}
 
-	// Sort by hand rank (best first)
-	// In a real implementation, we'd sort properly
-	// For now, find the best hand
-	var bestRank HandRank = HighCard
-	for _, ph := range playerHands {
-		if ph.BestHand.Rank > bestRank {
-			bestRank = ph.BestHand.Rank
+	// Award each pot to the best hand among its eligible players
+	var pots [][]Winner
+	for _, pot := range g.rakedPots() {
+		var winners []Winner
+		var highWinners []string
+		var best HandResult
+		for _, id := range pot.EligiblePlayers {
+			hand := hands[id]
+			if len(highWinners) == 0 {
+				highWinners, best = []string{id}, hand
+				continue
+			}
+			switch CompareHands(hand, best) {
+			case 1:
+				highWinners, best = []string{id}, hand
+			case 0:
+				highWinners = append(highWinners, id)
+			}
+		}
+
+		var lowWinners []string
+		var bestLow LowHandResult
+		for _, id := range pot.EligiblePlayers {
+			low, ok := lows[id]
+			if !ok || !low.Qualified {
+				continue
+			}
+			if len(lowWinners) == 0 {
+				lowWinners, bestLow = []string{id}, low
+				continue
+			}
+			switch CompareLowHands(low, bestLow) {
+			case 1:
+				lowWinners, bestLow = []string{id}, low
+			case 0:
+				lowWinners = append(lowWinners, id)
+			}
 		}
-	}
 
-	// Find all players with the best rank
-	var winners []PlayerHand
-	for _, ph := range playerHands {
-		if ph.BestHand.Rank == bestRank {
-			winners = append(winners, ph)
+		// Without a qualifying low the high hand scoops
+		highAmount := pot.Amount
+		if len(lowWinners) > 0 {
+			highAmount -= pot.Amount / 2
 		}
+
+		for i, amount := range splitAmount(highAmount, highWinners) {
+			id := highWinners[i]
+			w := winnerEntry(&winners, id)
+			w.Amount += amount
+			w.HandRank = hands[id].Rank
+			w.BestHand = hands[id].Cards
+			w.Description = hands[id].Description
+		}
+		for i, amount := range splitAmount(pot.Amount-highAmount, lowWinners) {
+			id := lowWinners[i]
+			w := winnerEntry(&winners, id)
+			w.Amount += amount
+			w.LowHand = lows[id].Cards
+			w.LowDescription = lows[id].Description
+		}
+		pots = append(pots, winners)
 	}
+	return pots
+}
 
-	// Split pot among winners
-	potShare := g.Pot / len(winners)
-	for _, w := range winners {
-		g.Winners = append(g.Winners, Winner{
-			PlayerID:    w.Player.ID,
-			Amount:      potShare,
-			HandRank:    w.BestHand.Rank,
-			BestHand:    w.BestHand.Cards,
-			Description: w.BestHand.Description,
-		})
+// splitAmount divides chips evenly between winners, odd chips going to
+// the first winner
+func splitAmount(amount int, winners []string) []int {
+	shares := make([]int, len(winners))
+	if len(winners) == 0 {
+		return shares
+	}
+	share := amount / len(winners)
+	for i := range winners {
+		shares[i] = share
 	}
+	shares[0] += amount - share*len(winners)
+	return shares
 }
 
-func (g *PokerGame) awardPots() {
-	// Award pots to winners
-	for _, winner := range g.Winners {
-		for _, p := range g.Players {
-			if p.ID == winner.PlayerID {
-				p.Chips += winner.Amount
-				break
-			}
+// winnerEntry returns a player's entry in winners, adding it if needed
+func winnerEntry(winners *[]Winner, playerID string) *Winner {
+	for i := range *winners {
+		if (*winners)[i].PlayerID == playerID {
+			return &(*winners)[i]
 		}
 	}
+	*winners = append(*winners, Winner{PlayerID: playerID})
+	return &(*winners)[len(*winners)-1]
 }
 
 func (g *PokerGame) getBettingRoundString() string {
-	switch g.BettingRound {
+	return g.BettingRound.String()
+}
+
+// String returns the lowercase name of a betting round
+func (r BettingRound) String() string {
+	switch r {
 	case PreFlop:
 		return "preflop"
 	case Flop:
@@ -572,6 +958,66 @@ func (g *PokerGame) getBettingRoundString() string {
 	}
 }
 
+// MarshalText encodes a betting round as its name
+func (r BettingRound) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText decodes a betting round name
+func (r *BettingRound) UnmarshalText(text []byte) error {
+	round, err := parseBettingRound(string(text))
+	if err != nil {
+		return err
+	}
+	*r = round
+	return nil
+}
+
+// parseBettingRound converts a betting round name back to a BettingRound
+func parseBettingRound(s string) (BettingRound, error) {
+	for r := PreFlop; r <= Showdown; r++ {
+		if r.String() == s {
+			return r, nil
+		}
+	}
+	return -1, fmt.Errorf("unknown betting round: %s", s)
+}
+
+// String returns the action's name as accepted by ParseActionType
+func (a ActionType) String() string {
+	switch a {
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	case Fold:
+		return "fold"
+	case AllIn:
+		return "allin"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes an action as its name
+func (a ActionType) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes an action name
+func (a *ActionType) UnmarshalText(text []byte) error {
+	action, err := ParseActionType(string(text))
+	if err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
 // ParseActionType converts string to ActionType
 func ParseActionType(action string) (ActionType, error) {
 	switch action {
@@ -594,29 +1040,40 @@ func ParseActionType(action string) (ActionType, error) {
 
 // GameState represents the public game state
 type GameState struct {
-	Players         []PlayerState `json:"players"`
-	CurrentPlayerID string        `json:"currentPlayerId"`
-	DealerIndex     int           `json:"dealerIndex"`
-	Pot             int           `json:"pot"`
-	CurrentBet      int           `json:"currentBet"`
-	MinRaise        int           `json:"minRaise"`
-	CommunityCards  []Card        `json:"communityCards"`
-	BettingRound    string        `json:"bettingRound"`
-	HandComplete    bool          `json:"handComplete"`
-	Winners         []Winner      `json:"winners,omitempty"`
-	HandNumber      int           `json:"handNumber"`
-	SidePots        []SidePot     `json:"sidePots,omitempty"`
+	Players         []PlayerState  `json:"players"`
+	CurrentPlayerID string         `json:"currentPlayerId"`
+	DealerIndex     int            `json:"dealerIndex"`
+	Pot             int            `json:"pot"`
+	SmallBlind      int            `json:"smallBlind"`
+	BigBlind        int            `json:"bigBlind"`
+	CurrentBet      int            `json:"currentBet"`
+	MinRaise        int            `json:"minRaise"`
+	CommunityCards  []Card         `json:"communityCards"`
+	BettingRound    string         `json:"bettingRound"`
+	HandComplete    bool           `json:"handComplete"`
+	Winners         []Winner       `json:"winners,omitempty"`
+	HandNumber      int            `json:"handNumber"`
+	SidePots        []SidePot      `json:"sidePots,omitempty"`
+	AllInEquity     []StreetEquity `json:"allInEquity,omitempty"`
+	RunningOut      bool           `json:"runningOut,omitempty"` // Waiting for RunOutStreet
+	HiLo            bool           `json:"hiLo,omitempty"`       // Pots split with the best eight-or-better low
+	Rake            int            `json:"rake,omitempty"`
+	LastEventSeq    int            `json:"lastEventSeq"` // Resume point for EventsFor
+
+	ShuffleCommitment string `json:"shuffleCommitment,omitempty"` // Hash of the server seed for this hand
 }
 
 // PlayerState represents public player state
 type PlayerState struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	Chips        int    `json:"chips"`
-	CurrentBet   int    `json:"currentBet"`
-	IsFolded     bool   `json:"isFolded"`
-	IsAllIn      bool   `json:"isAllIn"`
-	IsActive     bool   `json:"isActive"`
-	SeatPosition int    `json:"seatPosition"`
-	HasActed     bool   `json:"hasActed"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Chips        int       `json:"chips"`
+	CurrentBet   int       `json:"currentBet"`
+	IsFolded     bool      `json:"isFolded"`
+	IsAllIn      bool      `json:"isAllIn"`
+	IsActive     bool      `json:"isActive"`
+	SeatPosition int       `json:"seatPosition"`
+	HasActed     bool      `json:"hasActed"`
+	HoleCards    []Card    `json:"holeCards,omitempty"` // Only in replays
+	Stats        *HUDStats `json:"stats,omitempty"`     // When the game keeps stats
 }
@@ -0,0 +1,344 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustCards(t *testing.T, s string) []Card {
+	t.Helper()
+	cards, err := ParseCards(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cards
+}
+
+// act plays actions in turn order, failing the test on any error
+func act(t *testing.T, g *PokerGame, actions ...BotAction) {
+	t.Helper()
+	for _, a := range actions {
+		id := g.Players[g.CurrentIndex].ID
+		if err := g.ProcessAction(id, a.Action, a.Amount); err != nil {
+			t.Fatalf("%s %s %d: %v", id, a.Action, a.Amount, err)
+		}
+	}
+}
+
+func TestProcessActionRejects(t *testing.T) {
+	// Three handed at 5/10: the dealer acts first before the flop, then
+	// the small blind and the big blind
+	tests := []struct {
+		name    string
+		before  []BotAction
+		out     bool // Play the action as a player who is not to act
+		action  ActionType
+		amount  int
+		wantErr error
+	}{
+		{name: "not your turn", out: true, action: Call},
+		{name: "check facing a bet", action: Check},
+		{name: "bet facing a bet", action: Bet, amount: 50},
+		{name: "raise below the minimum", action: Raise, amount: 15},
+		{name: "raise by less than the last raise", before: []BotAction{{Action: Raise, Amount: 40}}, action: Raise, amount: 60},
+		{name: "call with nothing to call", before: []BotAction{{Action: Call}, {Action: Call}}, action: Call},
+		{name: "raise with no bet", before: []BotAction{{Action: Call}, {Action: Call}, {Action: Check}}, action: Raise, amount: 20},
+		{name: "bet below the big blind", before: []BotAction{{Action: Call}, {Action: Call}, {Action: Check}}, action: Bet, amount: 5},
+		{name: "unknown action", action: ActionType(99)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, 1, "a", "b", "c")
+			if err := g.StartNewHand(); err != nil {
+				t.Fatal(err)
+			}
+			act(t, g, tt.before...)
+
+			id := g.Players[g.CurrentIndex].ID
+			if tt.out {
+				id = g.Players[(g.CurrentIndex+len(g.Players)-1)%len(g.Players)].ID
+			}
+			events := len(g.Events)
+			err := g.ProcessAction(id, tt.action, tt.amount)
+			if err == nil {
+				t.Fatal("action accepted")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("got %v, want %v", err, tt.wantErr)
+			}
+			if len(g.Events) != events {
+				t.Errorf("rejected action emitted %d events", len(g.Events)-events)
+			}
+		})
+	}
+}
+
+func TestProcessActionRejectsDuringRunOut(t *testing.T) {
+	g := newTestGame(t, 2, "a", "b")
+	g.StepRunOut = true
+	g.SkipAllInEquity = true
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g, BotAction{Action: AllIn}, BotAction{Action: Call})
+
+	id := g.Players[g.CurrentIndex].ID
+	if err := g.ProcessAction(id, Check, 0); !errors.Is(err, ErrRunningOut) {
+		t.Errorf("got %v, want %v", err, ErrRunningOut)
+	}
+	for !g.HandComplete {
+		if err := g.RunOutStreet(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := g.RunOutStreet(); !errors.Is(err, ErrNoRunOut) {
+		t.Errorf("got %v, want %v", err, ErrNoRunOut)
+	}
+}
+
+func TestHeadsUpBlinds(t *testing.T) {
+	g := newTestGame(t, 3, "a", "b")
+	for hand := 1; hand <= 3; hand++ {
+		if err := g.StartNewHand(); err != nil {
+			t.Fatal(err)
+		}
+		dealer := g.Players[g.DealerIndex]
+		if dealer.CurrentBet != 5 {
+			t.Errorf("hand %d: dealer posted %d, want the small blind", hand, dealer.CurrentBet)
+		}
+		if g.CurrentIndex != g.DealerIndex {
+			t.Errorf("hand %d: dealer not first to act before the flop", hand)
+		}
+		act(t, g, BotAction{Action: Call}, BotAction{Action: Check})
+		if g.CurrentIndex == g.DealerIndex {
+			t.Errorf("hand %d: dealer first to act after the flop", hand)
+		}
+		act(t, g, BotAction{Action: Bet, Amount: 10}, BotAction{Action: Fold})
+	}
+}
+
+// potPlayer is a player's stake in a hand for the pot tests
+type potPlayer struct {
+	id       string
+	invested int
+	folded   bool
+	hole     string
+}
+
+// potGame sets up a hand's contributions, as if its betting were over
+func potGame(t *testing.T, players []potPlayer, board string) *PokerGame {
+	t.Helper()
+	g := &PokerGame{}
+	for _, p := range players {
+		g.Players = append(g.Players, &PokerPlayer{
+			ID:             p.id,
+			TotalBetInHand: p.invested,
+			IsFolded:       p.folded,
+			IsActive:       true,
+		})
+		if p.hole != "" {
+			g.Players[len(g.Players)-1].HoleCards = mustCards(t, p.hole)
+		}
+		g.Pot += p.invested
+	}
+	if board != "" {
+		g.CommunityCards = mustCards(t, board)
+	}
+	return g
+}
+
+func TestPots(t *testing.T) {
+	tests := []struct {
+		name    string
+		players []potPlayer
+		want    []SidePot
+	}{
+		{
+			name:    "no all-in",
+			players: []potPlayer{{id: "a", invested: 100}, {id: "b", invested: 100}, {id: "c", invested: 100}},
+			want:    []SidePot{{Amount: 300, EligiblePlayers: []string{"a", "b", "c"}}},
+		},
+		{
+			name:    "short all-in",
+			players: []potPlayer{{id: "a", invested: 50}, {id: "b", invested: 200}, {id: "c", invested: 200}},
+			want: []SidePot{
+				{Amount: 150, EligiblePlayers: []string{"a", "b", "c"}},
+				{Amount: 300, EligiblePlayers: []string{"b", "c"}},
+			},
+		},
+		{
+			name: "two all-ins",
+			players: []potPlayer{
+				{id: "a", invested: 30}, {id: "b", invested: 60}, {id: "c", invested: 100}, {id: "d", invested: 100},
+			},
+			want: []SidePot{
+				{Amount: 120, EligiblePlayers: []string{"a", "b", "c", "d"}},
+				{Amount: 90, EligiblePlayers: []string{"b", "c", "d"}},
+				{Amount: 80, EligiblePlayers: []string{"c", "d"}},
+			},
+		},
+		{
+			name: "folded chips above an all-in",
+			players: []potPlayer{
+				{id: "a", invested: 100, folded: true}, {id: "b", invested: 50}, {id: "c", invested: 200},
+			},
+			want: []SidePot{
+				{Amount: 150, EligiblePlayers: []string{"b", "c"}},
+				{Amount: 200, EligiblePlayers: []string{"c"}},
+			},
+		},
+		{
+			name: "folded chips above every contender",
+			players: []potPlayer{
+				{id: "a", invested: 300, folded: true}, {id: "b", invested: 100}, {id: "c", invested: 100},
+			},
+			want: []SidePot{{Amount: 500, EligiblePlayers: []string{"b", "c"}}},
+		},
+		{
+			name: "all-ins for the same amount",
+			players: []potPlayer{
+				{id: "a", invested: 80}, {id: "b", invested: 80}, {id: "c", invested: 150},
+			},
+			want: []SidePot{
+				{Amount: 240, EligiblePlayers: []string{"a", "b", "c"}},
+				{Amount: 70, EligiblePlayers: []string{"c"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := potGame(t, tt.players, "")
+			got := g.pots()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pots() = %+v, want %+v", got, tt.want)
+			}
+
+			total := 0
+			for _, pot := range got {
+				total += pot.Amount
+			}
+			if total != g.Pot {
+				t.Errorf("pots hold %d chips, want %d", total, g.Pot)
+			}
+		})
+	}
+}
+
+func TestDetermineWinners(t *testing.T) {
+	tests := []struct {
+		name    string
+		hiLo    bool
+		board   string
+		players []potPlayer
+		want    map[string]int
+		wantLow []string
+	}{
+		{
+			name:  "kicker decides",
+			board: "As 9d 7h 4c 2s",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "Ad Kc"}, {id: "b", invested: 100, hole: "Ah Qc"},
+			},
+			want: map[string]int{"a": 200},
+		},
+		{
+			name:  "board plays",
+			board: "As Ks Qd Jc 9h",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "2c 3d"}, {id: "b", invested: 100, hole: "4h 5s"},
+				{id: "c", invested: 1, folded: true},
+			},
+			want: map[string]int{"a": 101, "b": 100},
+		},
+		{
+			name:  "side pot to the short stack",
+			board: "Ks 8d 7h 4c 2s",
+			players: []potPlayer{
+				{id: "a", invested: 50, hole: "Kd Kh"}, {id: "b", invested: 200, hole: "Qd Qh"}, {id: "c", invested: 200, hole: "Jd Jh"},
+			},
+			want: map[string]int{"a": 150, "b": 300},
+		},
+		{
+			name:  "high and low split",
+			hiLo:  true,
+			board: "2c 5d 7h Kc Qs",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "As 3d"}, {id: "b", invested: 100, hole: "Kd Kh"},
+			},
+			want:    map[string]int{"a": 100, "b": 100},
+			wantLow: []string{"a"},
+		},
+		{
+			name:  "no low qualifies",
+			hiLo:  true,
+			board: "9c Td Kh Qs 2c",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "As 3d"}, {id: "b", invested: 100, hole: "Kd Kc"},
+			},
+			want: map[string]int{"b": 200},
+		},
+		{
+			name:  "low quartered",
+			hiLo:  true,
+			board: "2c 5d 7h Kc Qs",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "As 3d"}, {id: "b", invested: 100, hole: "Kd Kh"}, {id: "c", invested: 100, hole: "Ac 3h"},
+			},
+			want:    map[string]int{"a": 75, "b": 150, "c": 75},
+			wantLow: []string{"a", "c"},
+		},
+		{
+			name:  "odd chip to the high half",
+			hiLo:  true,
+			board: "2c 5d 7h Kc Qs",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "As 3d"}, {id: "b", invested: 100, hole: "Kd Kh"},
+				{id: "c", invested: 1, folded: true},
+			},
+			want:    map[string]int{"a": 100, "b": 101},
+			wantLow: []string{"a"},
+		},
+		{
+			name:  "hi-lo side pot",
+			hiLo:  true,
+			board: "2c 5d 7h Kc Qs",
+			players: []potPlayer{
+				{id: "a", invested: 50, hole: "As 3d"}, {id: "b", invested: 150, hole: "Kd Kh"}, {id: "c", invested: 150, hole: "Jd Jc"},
+			},
+			want:    map[string]int{"a": 75, "b": 275},
+			wantLow: []string{"a"},
+		},
+		{
+			name:  "scoop with both halves",
+			hiLo:  true,
+			board: "2c 5d 7h 4c Qs",
+			players: []potPlayer{
+				{id: "a", invested: 100, hole: "As 3d"}, {id: "b", invested: 100, hole: "Kd Kh"},
+			},
+			want:    map[string]int{"a": 200},
+			wantLow: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := potGame(t, tt.players, tt.board)
+			g.HiLo = tt.hiLo
+
+			got := make(map[string]int)
+			var lows []string
+			for _, w := range g.determineWinners() {
+				got[w.PlayerID] += w.Amount
+				if w.LowHand != nil {
+					lows = append(lows, w.PlayerID)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("winnings = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(lows, tt.wantLow) {
+				t.Errorf("low winners = %v, want %v", lows, tt.wantLow)
+			}
+		})
+	}
+}
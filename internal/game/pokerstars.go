@@ -0,0 +1,713 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PokerStarsOptions selects the hands WritePokerStars exports
+type PokerStarsOptions struct {
+	Table      string // Table name, "Table 1" if empty
+	HeroID     string // Player whose hole cards are shown; when set, only hands they were dealt into are written
+	HandNumber int    // Write only this hand, 0 for every completed hand
+
+	// HandID gives the number in a hand's header. Tracking software
+	// drops hands whose number it has already seen, so it must be unique
+	// across every table and game. The game's own hand number if nil.
+	HandID func(handNumber int) int64
+}
+
+// WritePokerStars writes the completed hands in a game's event log as
+// PokerStars hand histories, for import into tracking software. The log
+// must start from the game's first event.
+func WritePokerStars(w io.Writer, events []Event, opts PokerStarsOptions) error {
+	if opts.Table == "" {
+		opts.Table = "Table 1"
+	}
+
+	// Fold the events to know the table state behind every line
+	r := &PokerGame{Players: make([]*PokerPlayer, 0)}
+	var hand *starsHand
+	written := 0
+	for _, e := range events {
+		if e.Type == EventHandStarted {
+			hand = &starsHand{opts: opts, folded: make(map[string]string)}
+		}
+		if hand != nil {
+			hand.before(r, e)
+		}
+		if err := r.apply(e); err != nil {
+			return fmt.Errorf("event %d (%s): %w", e.Sequence, e.Type, err)
+		}
+		if hand == nil {
+			continue
+		}
+		hand.after(r, e)
+
+		if e.Type == EventHandEnded {
+			if hand.wanted() {
+				if written > 0 {
+					if _, err := io.WriteString(w, "\n\n\n"); err != nil {
+						return err
+					}
+				}
+				if _, err := io.WriteString(w, hand.b.String()); err != nil {
+					return err
+				}
+				written++
+			}
+			hand = nil
+		}
+	}
+	return nil
+}
+
+// ExportPokerStars writes the session's completed hands as PokerStars hand
+// histories
+func (g *PokerGame) ExportPokerStars(w io.Writer, opts PokerStarsOptions) error {
+	return WritePokerStars(w, g.Events, opts)
+}
+
+// starsHand builds the history of one hand as its events are folded
+type starsHand struct {
+	opts       PokerStarsOptions
+	b          strings.Builder
+	handNumber int
+	heroDealt  bool
+
+	smallBlind, bigBlind string            // Player IDs
+	folded               map[string]string // Player ID -> where they folded
+	uncalled             int
+	uncalledTo           string
+	showdown             bool
+	collectedWritten     bool
+}
+
+func (h *starsHand) wanted() bool {
+	if h.opts.HandNumber != 0 && h.opts.HandNumber != h.handNumber {
+		return false
+	}
+	return h.opts.HeroID == "" || h.heroDealt
+}
+
+func (h *starsHand) line(format string, args ...any) {
+	fmt.Fprintf(&h.b, format+"\n", args...)
+}
+
+// before writes lines that need the state from before the event
+func (h *starsHand) before(g *PokerGame, e Event) {
+	switch e.Type {
+	case EventActionTaken:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return
+		}
+		action, _ := ParseActionType(e.Action)
+		if action == Fold {
+			h.folded[p.ID] = foldedWhere(g.BettingRound, p.TotalBetInHand)
+		}
+		h.line("%s: %s", starsName(p), starsAction(g, p, action, e.Amount))
+
+	case EventPotAwarded:
+		// Every pot is written from the state before the first award
+		if !h.collectedWritten {
+			h.collectedWritten = true
+			h.writeCollected(g)
+		}
+
+	case EventHandEnded:
+		h.writeSummary(g)
+	}
+}
+
+// after writes lines that need the state the event leads to
+func (h *starsHand) after(g *PokerGame, e Event) {
+	switch e.Type {
+	case EventHandStarted:
+		h.handNumber = e.HandNumber
+		id := int64(e.HandNumber)
+		if h.opts.HandID != nil {
+			id = h.opts.HandID(e.HandNumber)
+		}
+		h.line("PokerStars Hand #%d: Hold'em No Limit (%d/%d) - %s UTC",
+			id, e.SmallBlind, e.BigBlind, e.Time.UTC().Format("2006/01/02 15:04:05"))
+		h.line("Table '%s' %d-max Seat #%d is the button", h.opts.Table, MaxPlayers, e.Seat+1)
+		for _, p := range g.Players {
+			if p.IsActive {
+				h.line("Seat %d: %s (%d in chips)", p.SeatPosition+1, starsName(p), p.Chips)
+			}
+		}
+
+	case EventSmallBlindPosted, EventBigBlindPosted:
+		p, _, err := g.findPlayer(e.PlayerID)
+		if err != nil {
+			return
+		}
+		blind := "small"
+		if e.Type == EventSmallBlindPosted {
+			h.smallBlind = p.ID
+		} else {
+			blind = "big"
+			h.bigBlind = p.ID
+		}
+		suffix := ""
+		if p.IsAllIn {
+			suffix = " and is all-in"
+		}
+		h.line("%s: posts %s blind %d%s", starsName(p), blind, e.Amount, suffix)
+		if e.Type == EventBigBlindPosted {
+			h.line("*** HOLE CARDS ***")
+		}
+
+	case EventHoleCardsDealt:
+		if e.PlayerID == h.opts.HeroID {
+			h.heroDealt = true
+			p, _, _ := g.findPlayer(e.PlayerID)
+			h.line("Dealt to %s [%s]", starsName(p), starsCards(e.Cards))
+		}
+
+	case EventActionTaken:
+		if g.isBettingRoundComplete() {
+			h.returnUncalled(g)
+		}
+
+	case EventStreetDealt:
+		board := g.CommunityCards
+		switch g.BettingRound {
+		case Flop:
+			h.line("*** FLOP *** [%s]", starsCards(board))
+		case Turn:
+			h.line("*** TURN *** [%s] [%s]", starsCards(board[:3]), starsCards(board[3:]))
+		case River:
+			h.line("*** RIVER *** [%s] [%s]", starsCards(board[:4]), starsCards(board[4:]))
+		case Showdown:
+			h.showdown = true
+			h.line("*** SHOW DOWN ***")
+			for _, p := range g.contenders() {
+				h.line("%s: shows [%s] (%s)", starsName(p), starsCards(p.HoleCards), showdownHand(g, p).Description)
+			}
+		}
+	}
+}
+
+// writeCollected writes what each player collected from each pot, naming
+// the pots as PokerStars does once there is a side pot
+func (h *starsHand) writeCollected(g *PokerGame) {
+	pots := g.potWinners()
+	// The uncalled bet is all of the last pot or part of it
+	last := pots[len(pots)-1]
+	for i := range last {
+		if last[i].PlayerID == h.uncalledTo {
+			last[i].Amount -= h.uncalled
+		}
+	}
+	total := 0
+	for _, w := range last {
+		total += w.Amount
+	}
+	if total <= 0 && len(pots) > 1 {
+		pots = pots[:len(pots)-1]
+	}
+
+	for i, pot := range pots {
+		name := "pot"
+		switch {
+		case len(pots) == 1:
+		case i == 0:
+			name = "main pot"
+		case len(pots) == 2:
+			name = "side pot"
+		default:
+			name = fmt.Sprintf("side pot-%d", i)
+		}
+		for _, w := range pot {
+			p, _, err := g.findPlayer(w.PlayerID)
+			if err == nil && w.Amount > 0 {
+				h.line("%s collected %d from %s", starsName(p), w.Amount, name)
+			}
+		}
+	}
+}
+
+// returnUncalled notes the part of the last bet nobody called. Bets only
+// go uncalled once nobody else can act, so the hand is over or all-in.
+func (h *starsHand) returnUncalled(g *PokerGame) {
+	var top *PokerPlayer
+	second := 0
+	for _, p := range g.Players {
+		switch {
+		case top == nil || p.TotalBetInHand > top.TotalBetInHand:
+			if top != nil {
+				second = top.TotalBetInHand
+			}
+			top = p
+		case p.TotalBetInHand > second:
+			second = p.TotalBetInHand
+		}
+	}
+	if h.uncalledTo != "" || top == nil || top.IsFolded || top.TotalBetInHand <= second {
+		return
+	}
+
+	h.uncalled, h.uncalledTo = top.TotalBetInHand-second, top.ID
+	h.line("Uncalled bet (%d) returned to %s", h.uncalled, starsName(top))
+}
+
+// collected is what a player won from the pot, less their own uncalled bet
+func (h *starsHand) collected(playerID string, amount int) int {
+	if playerID == h.uncalledTo {
+		return amount - h.uncalled
+	}
+	return amount
+}
+
+func (h *starsHand) writeSummary(g *PokerGame) {
+	won := make(map[string]int)
+	for _, w := range g.Winners {
+		won[w.PlayerID] += h.collected(w.PlayerID, w.Amount)
+		if !h.showdown {
+			if p, _, err := g.findPlayer(w.PlayerID); err == nil {
+				h.line("%s: doesn't show hand", starsName(p))
+			}
+		}
+	}
+
+	h.line("*** SUMMARY ***")
+	total := g.Pot - h.uncalled
+	pots := g.rakedPots()
+	if len(pots) > 0 {
+		pots[len(pots)-1].Amount -= h.uncalled
+		if pots[len(pots)-1].Amount <= 0 {
+			pots = pots[:len(pots)-1]
+		}
+	}
+	if len(pots) > 1 {
+		var parts []string
+		for i, pot := range pots {
+			switch {
+			case i == 0:
+				parts = append(parts, fmt.Sprintf("Main pot %d.", pot.Amount))
+			case len(pots) == 2:
+				parts = append(parts, fmt.Sprintf("Side pot %d.", pot.Amount))
+			default:
+				parts = append(parts, fmt.Sprintf("Side pot-%d %d.", i, pot.Amount))
+			}
+		}
+		h.line("Total pot %d %s | Rake %d", total, strings.Join(parts, " "), g.HandRake)
+	} else {
+		h.line("Total pot %d | Rake %d", total, g.HandRake)
+	}
+	if len(g.CommunityCards) > 0 {
+		h.line("Board [%s]", starsCards(g.CommunityCards))
+	}
+
+	for i, p := range g.Players {
+		if !p.IsActive {
+			continue
+		}
+		// Heads-up the button is also the small blind
+		var tags string
+		if i == g.DealerIndex {
+			tags = " (button)"
+		}
+		switch p.ID {
+		case h.smallBlind:
+			tags += " (small blind)"
+		case h.bigBlind:
+			tags += " (big blind)"
+		}
+
+		var result string
+		switch {
+		case p.IsFolded:
+			result = h.folded[p.ID]
+		case h.showdown && won[p.ID] > 0:
+			result = fmt.Sprintf("showed [%s] and won (%d) with %s",
+				starsCards(p.HoleCards), won[p.ID], showdownHand(g, p).Description)
+		case h.showdown:
+			result = fmt.Sprintf("showed [%s] and lost with %s",
+				starsCards(p.HoleCards), showdownHand(g, p).Description)
+		default:
+			result = fmt.Sprintf("collected (%d)", won[p.ID])
+		}
+		h.line("Seat %d: %s%s %s", p.SeatPosition+1, starsName(p), tags, result)
+	}
+}
+
+// starsAction describes an action the way PokerStars writes it
+func starsAction(g *PokerGame, p *PokerPlayer, action ActionType, amount int) string {
+	var s string
+	chips := amount // Chips the action puts in
+	switch action {
+	case Check:
+		return "checks"
+	case Fold:
+		return "folds"
+	case Call:
+		s = fmt.Sprintf("calls %d", min(amount, p.Chips))
+	case Bet:
+		s = fmt.Sprintf("bets %d", amount)
+	case Raise:
+		chips = amount - p.CurrentBet
+		s = fmt.Sprintf("raises %d to %d", amount-g.CurrentBet, amount)
+	case AllIn:
+		total := p.CurrentBet + amount
+		switch {
+		case g.CurrentBet == 0:
+			s = fmt.Sprintf("bets %d", amount)
+		case total > g.CurrentBet:
+			s = fmt.Sprintf("raises %d to %d", total-g.CurrentBet, total)
+		default:
+			s = fmt.Sprintf("calls %d", amount)
+		}
+	default:
+		return action.String()
+	}
+
+	if chips >= p.Chips {
+		s += " and is all-in"
+	}
+	return s
+}
+
+func foldedWhere(round BettingRound, invested int) string {
+	switch round {
+	case PreFlop:
+		if invested == 0 {
+			return "folded before Flop (didn't bet)"
+		}
+		return "folded before Flop"
+	case Flop:
+		return "folded on the Flop"
+	case Turn:
+		return "folded on the Turn"
+	default:
+		return "folded on the River"
+	}
+}
+
+func showdownHand(g *PokerGame, p *PokerPlayer) HandResult {
+	return EvaluateBestHand(append(append([]Card{}, p.HoleCards...), g.CommunityCards...))
+}
+
+func starsName(p *PokerPlayer) string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.ID
+}
+
+func starsCards(cards []Card) string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = c.ShortString()
+	}
+	return strings.Join(s, " ")
+}
+
+var (
+	starsHeaderRe  = regexp.MustCompile(`^PokerStars (?:Zoom |Home Game )?Hand #(\d+):\s*(.*)$`)
+	starsStakesRe  = regexp.MustCompile(`\(([$€£]?[\d.,]+)/([$€£]?[\d.,]+)(?: ([A-Z]{3}))?\)`)
+	starsTimeRe    = regexp.MustCompile(`\d{4}/\d{2}/\d{2} \d{1,2}:\d{2}:\d{2}`)
+	starsTableRe   = regexp.MustCompile(`^Table '([^']*)'.* Seat #(\d+) is the button`)
+	starsSeatRe    = regexp.MustCompile(`^Seat (\d+): (.+) \(([$€£]?[\d.,]+) in chips[^)]*\)(.*)$`)
+	starsCardsRe   = regexp.MustCompile(`\[([^\]]*)\]`)
+	starsUncallRe  = regexp.MustCompile(`^Uncalled bet \(([^)]+)\) returned to (.+)$`)
+	starsCollectRe = regexp.MustCompile(`^(.+) collected ([$€£]?[\d.,]+) from `)
+	starsTotalRe   = regexp.MustCompile(`^Total pot ([$€£]?[\d.,]+)`)
+	starsRakeRe    = regexp.MustCompile(`\| Rake ([$€£]?[\d.,]+)`)
+	starsShowedRe  = regexp.MustCompile(`^Seat \d+: (.+?) (?:\([^)]*\) )*(?:showed|mucked) \[([^\]]+)\]`)
+	starsRaiseRe   = regexp.MustCompile(`^raises ([$€£]?[\d.,]+) to ([$€£]?[\d.,]+)`)
+)
+
+// ParsePokerStars parses PokerStars no-limit hold'em hand histories, any
+// number to a file. Any other game is an ErrUnsupportedHand.
+func ParsePokerStars(r io.Reader) ([]ImportedHand, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var hands []ImportedHand
+	var lines []string
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		hand, err := parseStarsHand(lines)
+		if err != nil {
+			return err
+		}
+		hands = append(hands, *hand)
+		lines = nil
+		return nil
+	}
+
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\uFEFF"))
+		switch {
+		case starsHeaderRe.MatchString(line):
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(line, "PokerStars "):
+			// Old "Game #" headers and other sites' formats
+			return nil, fmt.Errorf("%w: unrecognised header %q", ErrUnsupportedHand, line)
+		case len(lines) == 0:
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(hands) == 0 {
+		return nil, errors.New("no PokerStars hands found")
+	}
+	return hands, nil
+}
+
+// parseStarsHand parses the lines of one hand, header first
+func parseStarsHand(lines []string) (*ImportedHand, error) {
+	header := starsHeaderRe.FindStringSubmatch(lines[0])
+	h := &ImportedHand{
+		Format:    "pokerstars",
+		ID:        header[1],
+		Collected: make(map[string]int),
+		Uncalled:  make(map[string]int),
+	}
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("hand %s: %s", h.ID, fmt.Sprintf(format, args...))
+	}
+
+	game := header[2]
+	if !strings.Contains(game, "Hold'em No Limit") {
+		name, _, _ := strings.Cut(game, " (")
+		return nil, fmt.Errorf("%w: hand %s is %s, only no-limit hold'em is supported", ErrUnsupportedHand, h.ID, name)
+	}
+	stakes := starsStakesRe.FindStringSubmatch(game)
+	if stakes == nil {
+		return nil, fail("no stakes in header")
+	}
+	cents := strings.ContainsAny(stakes[1], "$€£")
+	if cents {
+		h.Currency = stakes[3]
+		if h.Currency == "" {
+			switch {
+			case strings.HasPrefix(stakes[1], "$"):
+				h.Currency = "USD"
+			case strings.HasPrefix(stakes[1], "€"):
+				h.Currency = "EUR"
+			case strings.HasPrefix(stakes[1], "£"):
+				h.Currency = "GBP"
+			}
+		}
+	}
+	var err error
+	if h.SmallBlind, err = parseAmount(stakes[1], cents); err != nil {
+		return nil, fail("%v", err)
+	}
+	if h.BigBlind, err = parseAmount(stakes[2], cents); err != nil {
+		return nil, fail("%v", err)
+	}
+	if t := starsTimeRe.FindString(game); t != "" {
+		h.Time, _ = time.Parse("2006/01/02 15:04:05", t)
+	}
+
+	street := PreFlop
+	inSummary := false
+	for _, line := range lines[1:] {
+		if inSummary {
+			if m := starsTotalRe.FindStringSubmatch(line); m != nil {
+				if h.TotalPot, err = parseAmount(m[1], cents); err != nil {
+					return nil, fail("%v", err)
+				}
+				if m := starsRakeRe.FindStringSubmatch(line); m != nil {
+					if h.Rake, err = parseAmount(m[1], cents); err != nil {
+						return nil, fail("%v", err)
+					}
+				}
+			} else if m := starsShowedRe.FindStringSubmatch(line); m != nil {
+				if err := h.showCards(m[1], m[2]); err != nil {
+					return nil, fail("%v", err)
+				}
+			}
+			continue
+		}
+
+		if m := starsTableRe.FindStringSubmatch(line); m != nil {
+			h.Table = m[1]
+			h.Button, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if m := starsSeatRe.FindStringSubmatch(line); m != nil && street == PreFlop && h.SmallBlindPlayer == "" {
+			if strings.Contains(m[4], "sitting out") {
+				continue
+			}
+			seat, _ := strconv.Atoi(m[1])
+			chips, err := parseAmount(m[3], cents)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			h.Seats = append(h.Seats, ImportedSeat{Seat: seat, Name: m[2], Chips: chips})
+			continue
+		}
+
+		if strings.HasPrefix(line, "*** ") {
+			switch {
+			case strings.HasPrefix(line, "*** HOLE CARDS"):
+				street = PreFlop
+			case strings.HasPrefix(line, "*** FLOP"), strings.HasPrefix(line, "*** TURN"), strings.HasPrefix(line, "*** RIVER"):
+				street++
+				groups := starsCardsRe.FindAllStringSubmatch(line, -1)
+				if len(groups) == 0 {
+					return nil, fail("no cards in %q", line)
+				}
+				cards, err := ParseCards(groups[len(groups)-1][1])
+				if err != nil {
+					return nil, fail("%v", err)
+				}
+				h.Board = append(h.Board, cards...)
+			case strings.HasPrefix(line, "*** SHOW DOWN"):
+				street = Showdown
+			case strings.HasPrefix(line, "*** SUMMARY"):
+				inSummary = true
+			default:
+				return nil, fmt.Errorf("%w: hand %s has %q", ErrUnsupportedHand, h.ID, line)
+			}
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "Dealt to "); ok {
+			if name, cards, ok := strings.Cut(rest, " ["); ok {
+				if err := h.showCards(name, strings.TrimSuffix(cards, "]")); err != nil {
+					return nil, fail("%v", err)
+				}
+			}
+			continue
+		}
+		if m := starsUncallRe.FindStringSubmatch(line); m != nil {
+			v, err := parseAmount(m[1], cents)
+			if err != nil {
+				return nil, fail("%v", err)
+			}
+			h.Uncalled[m[2]] += v
+			continue
+		}
+
+		name, rest := h.starsPlayerLine(line)
+		if name == "" {
+			if m := starsCollectRe.FindStringSubmatch(line); m != nil {
+				v, err := parseAmount(m[2], cents)
+				if err != nil {
+					return nil, fail("%v", err)
+				}
+				h.Collected[m[1]] += v
+			}
+			// Chat, table and connection messages
+			continue
+		}
+		if err := h.parseStarsAction(name, rest, street, cents); err != nil {
+			return nil, fmt.Errorf("hand %s: %w", h.ID, err)
+		}
+	}
+
+	if len(h.Seats) == 0 || h.SmallBlindPlayer == "" || h.BigBlindPlayer == "" {
+		return nil, fail("missing seats or blinds")
+	}
+	return h, nil
+}
+
+// starsPlayerLine splits "Name: action" lines, matching the longest seated
+// name since names may contain spaces and colons
+func (h *ImportedHand) starsPlayerLine(line string) (name, rest string) {
+	for _, s := range h.Seats {
+		if len(s.Name) > len(name) && strings.HasPrefix(line, s.Name+": ") {
+			name = s.Name
+		}
+	}
+	if name == "" {
+		return "", ""
+	}
+	return name, line[len(name)+2:]
+}
+
+func (h *ImportedHand) parseStarsAction(name, rest string, street BettingRound, cents bool) error {
+	allIn := strings.HasSuffix(rest, " and is all-in")
+	rest = strings.TrimSuffix(rest, " and is all-in")
+	fields := strings.Fields(rest)
+	last := ""
+	if len(fields) > 0 {
+		last = fields[len(fields)-1]
+	}
+
+	add := func(action ActionType, amount string) error {
+		a := ImportedAction{Player: name, Street: street, Action: action, AllIn: allIn}
+		if amount != "" {
+			v, err := parseAmount(amount, cents)
+			if err != nil {
+				return err
+			}
+			a.Amount = v
+		}
+		h.Actions = append(h.Actions, a)
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "posts small blind "):
+		h.SmallBlindPlayer = name
+	case strings.HasPrefix(rest, "posts big blind "):
+		h.BigBlindPlayer = name
+	case strings.HasPrefix(rest, "posts "):
+		return fmt.Errorf("%w: %s %s", ErrUnsupportedHand, name, rest)
+	case rest == "folds" || strings.HasPrefix(rest, "folds ["):
+		if _, cards, ok := strings.Cut(rest, "["); ok {
+			if err := h.showCards(name, strings.TrimSuffix(cards, "]")); err != nil {
+				return err
+			}
+		}
+		return add(Fold, "")
+	case rest == "checks":
+		return add(Check, "")
+	case strings.HasPrefix(rest, "calls "):
+		return add(Call, last)
+	case strings.HasPrefix(rest, "bets "):
+		return add(Bet, last)
+	case strings.HasPrefix(rest, "raises "):
+		m := starsRaiseRe.FindStringSubmatch(rest)
+		if m == nil {
+			return fmt.Errorf("invalid raise %q", rest)
+		}
+		return add(Raise, m[2])
+	case strings.HasPrefix(rest, "shows ["):
+		cards, _, _ := strings.Cut(strings.TrimPrefix(rest, "shows ["), "]")
+		return h.showCards(name, cards)
+	}
+	return nil
+}
+
+// showCards records a player's hole cards once they are seen
+func (h *ImportedHand) showCards(name, cards string) error {
+	seat := h.seat(name)
+	if seat == nil {
+		return fmt.Errorf("unknown player %q", name)
+	}
+	parsed, err := ParseCards(cards)
+	if err != nil {
+		return err
+	}
+	if len(parsed) == 2 {
+		seat.HoleCards = parsed
+	}
+	return nil
+}
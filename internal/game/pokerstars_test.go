@@ -0,0 +1,246 @@
+package game
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// starsGame plays the hands the PokerStars tests export: a three-way all-in
+// with side pots and an uncalled bet, then a raise nobody calls. The event
+// times are fixed so the output is too.
+func starsGame(t *testing.T) []Event {
+	t.Helper()
+	g := NewPokerGameWithRNG(5, 10, NewSeededRNG(1))
+	for _, p := range []struct {
+		id    string
+		chips int
+	}{{"a", 1000}, {"b", 100}, {"c", 300}} {
+		if err := g.AddPlayer(p.id, p.id, p.chips); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// b is the button, c the small blind and a the big blind
+	deal, err := ParseScriptedDeal("7c2d AsAh KsKh | Qd9c5s 3h 8d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g, BotAction{Action: AllIn}, BotAction{Action: AllIn}, BotAction{Action: AllIn})
+
+	// c is the button and raises, the blinds fold
+	deal, err = ParseScriptedDeal("Tc4h 6s6d JhJd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g, BotAction{Action: Raise, Amount: 30}, BotAction{Action: Fold}, BotAction{Action: Fold})
+
+	events := append([]Event{}, g.Events...)
+	for i := range events {
+		events[i].Time = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	}
+	return events
+}
+
+func TestWritePokerStarsGolden(t *testing.T) {
+	var buf bytes.Buffer
+	err := WritePokerStars(&buf, starsGame(t), PokerStarsOptions{
+		Table:  "Golden",
+		HandID: func(n int) int64 { return 250000000000 + int64(n) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "pokerstars.golden")
+	if *update {
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != string(want) {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWritePokerStarsHero(t *testing.T) {
+	events := starsGame(t)
+	tests := []struct {
+		name   string
+		opts   PokerStarsOptions
+		hands  int
+		header string
+	}{
+		{"every hand", PokerStarsOptions{}, 2, "PokerStars Hand #1:"},
+		{"one hand", PokerStarsOptions{HandNumber: 2}, 1, "PokerStars Hand #2:"},
+		{"hand IDs", PokerStarsOptions{HandID: func(n int) int64 { return int64(n) * 1000 }}, 2, "PokerStars Hand #1000:"},
+		{"hero's hole cards only", PokerStarsOptions{HeroID: "a", HandNumber: 2}, 1, "Dealt to a [Tc 4h]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WritePokerStars(&buf, events, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			out := buf.String()
+			if got := strings.Count(out, "PokerStars Hand #"); got != tt.hands {
+				t.Errorf("wrote %d hands, want %d", got, tt.hands)
+			}
+			if !strings.Contains(out, tt.header) {
+				t.Errorf("missing %q in\n%s", tt.header, out)
+			}
+			if tt.opts.HeroID != "" && strings.Contains(out, "Dealt to b") {
+				t.Errorf("another player's hole cards shown\n%s", out)
+			}
+		})
+	}
+}
+
+// handEvents picks out the events of the nth hand in a log, as an import
+// replays them: who did what for how much and the board, without times,
+// sequence numbers or cards nobody showed
+func handEvents(events []Event, n int) []string {
+	var out []string
+	hand := 0
+	for _, e := range events {
+		if e.Type == EventHandStarted {
+			hand++
+		}
+		if hand != n {
+			continue
+		}
+		switch e.Type {
+		case EventHandStarted:
+			out = append(out, fmt.Sprintf("%s dealer %d, %d/%d", e.Type, e.Seat, e.SmallBlind, e.BigBlind))
+		case EventStreetDealt:
+			out = append(out, fmt.Sprintf("%s %s %s", e.Type, e.Street, starsCards(e.Cards)))
+		case EventDeckShuffled, EventHoleCardsDealt, EventEquityCalculated:
+		default:
+			out = append(out, fmt.Sprintf("%s %s %s %d", e.Type, e.PlayerID, e.Action, e.Amount))
+		}
+	}
+	return out
+}
+
+func TestPokerStarsRoundTrip(t *testing.T) {
+	events := starsGame(t)
+	var buf bytes.Buffer
+	if err := WritePokerStars(&buf, events, PokerStarsOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	hands, err := ParsePokerStars(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("parsed %d hands, want 2", len(hands))
+	}
+
+	for i, h := range hands {
+		g, err := h.Replay()
+		if err != nil {
+			t.Fatalf("hand %s: %v", h.ID, err)
+		}
+		got, want := handEvents(g.Events, 1), handEvents(events, i+1)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("hand %s replays as\n%s\nwant\n%s", h.ID, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestParsePokerStarsRejects(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePokerStars(&buf, starsGame(t), PokerStarsOptions{HandNumber: 1}); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.String()
+
+	tests := []struct {
+		name        string
+		text        string
+		unsupported bool
+	}{
+		{"empty", "", false},
+		{"no hands", "Hello\nWorld\n", false},
+		{"omaha", strings.Replace(valid, "Hold'em No Limit", "Omaha Pot Limit", 1), true},
+		{"old header", strings.Replace(valid, "PokerStars Hand #1:", "PokerStars Game #1:", 1), true},
+		{"no stakes", strings.Replace(valid, "(5/10)", "", 1), false},
+		{"bad stack", strings.Replace(valid, "(100 in chips)", "(1e9x in chips)", 1), false},
+		{"no blinds", strings.Replace(strings.Replace(valid, "posts small blind", "sits", 1), "posts big blind", "sits", 1), false},
+		{"bad board", strings.Replace(valid, "[Qd 9c 5s]", "[Qd 9c 5x]", 1), false},
+		{"unknown street", strings.Replace(valid, "*** TURN ***", "*** 4TH STREET ***", 1), true},
+		{"straddle", strings.Replace(valid, "a: posts big blind 10", "a: posts big blind 10\nb: posts straddle 20", 1), true},
+		{"bad raise", strings.Replace(valid, "raises 90 to 100", "raises lots", 1), false},
+		{"shown by a stranger", strings.Replace(valid, "a: shows", "zed: shows", 1) + "\nSeat 9: zed showed [2c 2d] and lost", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePokerStars(strings.NewReader(tt.text))
+			if err == nil {
+				t.Fatal("parsed")
+			}
+			if got := errors.Is(err, ErrUnsupportedHand); got != tt.unsupported {
+				t.Errorf("unsupported = %v, want %v: %v", got, tt.unsupported, err)
+			}
+		})
+	}
+}
+
+// Wherever a history is cut short, parsing and replaying it fail or
+// succeed without panicking
+func TestParseTruncatedHistories(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePokerStars(&buf, starsGame(t), PokerStarsOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	texts := map[string]string{"pokerstars": buf.String(), "phh": phhHand}
+	for format, text := range texts {
+		// At the end and the middle of every line
+		var cuts []int
+		start := 0
+		for i, c := range text {
+			if c == '\n' {
+				cuts = append(cuts, (start+i)/2, i)
+				start = i + 1
+			}
+		}
+		for _, cut := range cuts {
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("%s cut to %d bytes: panic %v", format, cut, r)
+					}
+				}()
+				hands, _ := ParseHandHistory(strings.NewReader(text[:cut]))
+				for _, h := range hands {
+					h.Replay()
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package game
+
+//go:generate go run preflop_gen.go
+
+import (
+	"math"
+)
+
+// MaxPreflopOpponents is the largest opponent count with precomputed equity
+const MaxPreflopOpponents = 9
+
+// PreflopHand holds precomputed data for one of the 169 starting hands
+type PreflopHand struct {
+	Notation string // Canonical notation, e.g. "AA", "AKs", "T9o"
+
+	// Equity percentage against 1 to 9 random hands, all the way to showdown.
+	// Exact against one hand, and within a tenth of a percent against more.
+	Equity [MaxPreflopOpponents]float64
+
+	// Sklansky-Chubukov number: the largest stack at which shoving from the
+	// small blind heads-up beats folding, even against an opponent who sees
+	// our cards. Counted in small blinds with blinds of 1 and 2, as in the
+	// published tables. math.Inf(1) for hands that always shove.
+	SklanskyChubukov float64
+
+	ChenScore     int
+	SklanskyGroup int // Sklansky-Malmuth group 1-8, or 9 for hands outside the groups
+}
+
+// EquityVs returns the hand's equity against n random opponents
+func (h PreflopHand) EquityVs(n int) float64 {
+	if n < 1 || n > MaxPreflopOpponents {
+		return 0
+	}
+	return h.Equity[n-1]
+}
+
+var preflopIndex = func() map[string]int {
+	index := make(map[string]int, len(preflopTable))
+	for i, h := range preflopTable {
+		index[h.Notation] = i
+	}
+	return index
+}()
+
+// LookupPreflop returns the precomputed data for a hand in canonical notation
+func LookupPreflop(notation string) (PreflopHand, bool) {
+	i, ok := preflopIndex[notation]
+	if !ok {
+		return PreflopHand{}, false
+	}
+	return preflopTable[i], true
+}
+
+// PreflopStats returns the precomputed data for two hole cards
+func PreflopStats(holeCards []Card) (PreflopHand, bool) {
+	return LookupPreflop(CanonicalHand(holeCards))
+}
+
+// PreflopHands returns every starting hand, in StartingHandNotations order
+func PreflopHands() []PreflopHand {
+	return append([]PreflopHand{}, preflopTable...)
+}
+
+// CanonicalHand returns the notation for two hole cards, high rank first
+// with "s" for suited and "o" for offsuit (e.g. "AKs", "QJo", "77")
+func CanonicalHand(holeCards []Card) string {
+	if len(holeCards) != 2 {
+		return ""
+	}
+
+	hi, lo := holeCards[0], holeCards[1]
+	if lo.Rank > hi.Rank {
+		hi, lo = lo, hi
+	}
+
+	notation := hi.Rank.Symbol() + lo.Rank.Symbol()
+	switch {
+	case hi.Rank == lo.Rank:
+		return notation
+	case hi.Suit == lo.Suit:
+		return notation + "s"
+	default:
+		return notation + "o"
+	}
+}
+
+// StartingHandNotations lists the 169 canonical starting hands: for each
+// high rank from aces down, the pair and then suited and offsuit hands
+func StartingHandNotations() []string {
+	notations := make([]string, 0, 169)
+	for hi := Ace; hi >= Two; hi-- {
+		notations = append(notations, hi.Symbol()+hi.Symbol())
+		for lo := hi - 1; lo >= Two; lo-- {
+			notations = append(notations,
+				hi.Symbol()+lo.Symbol()+"s",
+				hi.Symbol()+lo.Symbol()+"o")
+		}
+	}
+	return notations
+}
+
+// HandCombos returns every pair of hole cards matching a canonical notation
+func HandCombos(notation string) [][]Card {
+	if len(notation) < 2 || len(notation) > 3 {
+		return nil
+	}
+	hi, ok1 := parseRankSymbol(notation[0])
+	lo, ok2 := parseRankSymbol(notation[1])
+	if !ok1 || !ok2 {
+		return nil
+	}
+
+	var combos [][]Card
+	for s1 := Clubs; s1 <= Spades; s1++ {
+		for s2 := Clubs; s2 <= Spades; s2++ {
+			a, b := Card{Suit: s1, Rank: hi}, Card{Suit: s2, Rank: lo}
+			if hi == lo && s2 <= s1 {
+				continue
+			}
+			if hi != lo && CanonicalHand([]Card{a, b}) != notation {
+				continue
+			}
+			combos = append(combos, []Card{a, b})
+		}
+	}
+	return combos
+}
+
+func parseRankSymbol(symbol byte) (Rank, bool) {
+	for r := Two; r <= Ace; r++ {
+		if r.Symbol()[0] == symbol {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// ChenScore scores a starting hand with Bill Chen's formula
+func ChenScore(holeCards []Card) int {
+	if len(holeCards) != 2 {
+		return 0
+	}
+
+	hi, lo := holeCards[0], holeCards[1]
+	if lo.Rank > hi.Rank {
+		hi, lo = lo, hi
+	}
+
+	score := chenCardPoints(hi.Rank)
+	if hi.Rank == lo.Rank {
+		score = math.Max(score*2, 5)
+		return int(math.Ceil(score))
+	}
+
+	if hi.Suit == lo.Suit {
+		score += 2
+	}
+
+	gap := int(hi.Rank-lo.Rank) - 1
+	switch {
+	case gap == 1:
+		score--
+	case gap == 2:
+		score -= 2
+	case gap == 3:
+		score -= 4
+	case gap >= 4:
+		score -= 5
+	}
+
+	// Connected and one-gap hands below a queen straighten more easily
+	if gap <= 1 && hi.Rank < Queen {
+		score++
+	}
+
+	return int(math.Ceil(score))
+}
+
+func chenCardPoints(r Rank) float64 {
+	switch r {
+	case Ace:
+		return 10
+	case King:
+		return 8
+	case Queen:
+		return 7
+	case Jack:
+		return 6
+	default:
+		return float64(r) / 2
+	}
+}
+
+// sklanskyGroups are the Sklansky-Malmuth starting hand groups
+var sklanskyGroups = [][]string{
+	{"AA", "KK", "QQ", "JJ", "AKs"},
+	{"TT", "AQs", "AJs", "KQs", "AKo"},
+	{"99", "JTs", "QJs", "KJs", "ATs", "AQo"},
+	{"T9s", "KQo", "88", "QTs", "98s", "J9s", "AJo", "KTs"},
+	{"77", "87s", "Q9s", "T8s", "KJo", "QJo", "JTo", "76s", "97s",
+		"A9s", "A8s", "A7s", "A6s", "A5s", "A4s", "A3s", "A2s", "65s"},
+	{"66", "ATo", "55", "86s", "KTo", "QTo", "54s", "K9s", "J8s", "75s"},
+	{"44", "J9o", "64s", "T9o", "53s", "33", "98o", "43s", "22",
+		"K8s", "K7s", "K6s", "K5s", "K4s", "K3s", "K2s", "T7s", "Q8s"},
+	{"87o", "A9o", "Q9o", "76o", "42s", "32s", "96s", "85s", "J8o",
+		"J7s", "65o", "54o", "74s", "K9o", "T8o"},
+}
+
+// SklanskyGroup returns the Sklansky-Malmuth group (1-8) for a hand in
+// canonical notation, or 9 if it is outside the groups
+func SklanskyGroup(notation string) int {
+	for i, group := range sklanskyGroups {
+		for _, h := range group {
+			if h == notation {
+				return i + 1
+			}
+		}
+	}
+	return len(sklanskyGroups) + 1
+}
@@ -0,0 +1,177 @@
+// Code generated by preflop_gen.go; DO NOT EDIT.
+
+package game
+
+import "math"
+
+var preflopTable = []PreflopHand{
+	{Notation: "AA", Equity: [MaxPreflopOpponents]float64{85.20, 73.4, 63.8, 55.9, 49.1, 43.5, 38.8, 34.6, 31.0}, SklanskyChubukov: math.Inf(1), ChenScore: 20, SklanskyGroup: 1},
+	{Notation: "AKs", Equity: [MaxPreflopOpponents]float64{67.04, 50.7, 41.4, 35.4, 31.1, 27.7, 24.9, 22.7, 20.6}, SklanskyChubukov: 555.5, ChenScore: 12, SklanskyGroup: 1},
+	{Notation: "AKo", Equity: [MaxPreflopOpponents]float64{65.32, 48.2, 38.5, 32.3, 27.8, 24.4, 21.6, 19.2, 17.2}, SklanskyChubukov: 332.9, ChenScore: 10, SklanskyGroup: 2},
+	{Notation: "AQs", Equity: [MaxPreflopOpponents]float64{66.21, 49.4, 39.8, 33.8, 29.3, 26.0, 23.2, 21.1, 19.2}, SklanskyChubukov: 275.2, ChenScore: 11, SklanskyGroup: 2},
+	{Notation: "AQo", Equity: [MaxPreflopOpponents]float64{64.43, 46.8, 36.7, 30.5, 25.9, 22.4, 19.7, 17.4, 15.5}, SklanskyChubukov: 193.7, ChenScore: 9, SklanskyGroup: 3},
+	{Notation: "AJs", Equity: [MaxPreflopOpponents]float64{65.39, 48.2, 38.4, 32.3, 27.8, 24.6, 21.9, 19.9, 18.1}, SklanskyChubukov: 184.2, ChenScore: 10, SklanskyGroup: 2},
+	{Notation: "AJo", Equity: [MaxPreflopOpponents]float64{63.56, 45.5, 35.3, 28.9, 24.3, 20.9, 18.2, 16.1, 14.3}, SklanskyChubukov: 137.3, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "ATs", Equity: [MaxPreflopOpponents]float64{64.60, 47.0, 37.2, 31.1, 26.7, 23.5, 21.0, 19.0, 17.3}, SklanskyChubukov: 139.9, ChenScore: 8, SklanskyGroup: 3},
+	{Notation: "ATo", Equity: [MaxPreflopOpponents]float64{62.72, 44.3, 33.9, 27.5, 23.0, 19.7, 17.1, 15.1, 13.3}, SklanskyChubukov: 107.3, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "A9s", Equity: [MaxPreflopOpponents]float64{62.78, 44.5, 34.5, 28.4, 24.1, 21.1, 18.7, 16.9, 15.3}, SklanskyChubukov: 105.1, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A9o", Equity: [MaxPreflopOpponents]float64{60.77, 41.6, 31.0, 24.6, 20.2, 17.0, 14.6, 12.7, 11.2}, SklanskyChubukov: 82.7, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "A8s", Equity: [MaxPreflopOpponents]float64{61.94, 43.5, 33.4, 27.4, 23.2, 20.3, 18.0, 16.2, 14.7}, SklanskyChubukov: 90.9, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A8o", Equity: [MaxPreflopOpponents]float64{59.87, 40.4, 29.8, 23.5, 19.2, 16.2, 13.8, 12.0, 10.5}, SklanskyChubukov: 72.0, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A7s", Equity: [MaxPreflopOpponents]float64{60.98, 42.3, 32.4, 26.4, 22.4, 19.5, 17.3, 15.7, 14.3}, SklanskyChubukov: 80.2, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A7o", Equity: [MaxPreflopOpponents]float64{58.84, 39.2, 28.7, 22.5, 18.3, 15.3, 13.1, 11.4, 10.0}, SklanskyChubukov: 63.7, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A6s", Equity: [MaxPreflopOpponents]float64{59.91, 41.1, 31.3, 25.5, 21.6, 18.9, 16.8, 15.2, 13.9}, SklanskyChubukov: 71.7, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A6o", Equity: [MaxPreflopOpponents]float64{57.68, 37.9, 27.5, 21.4, 17.4, 14.6, 12.5, 10.9, 9.6}, SklanskyChubukov: 57.1, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A5s", Equity: [MaxPreflopOpponents]float64{59.92, 41.4, 31.7, 26.0, 22.2, 19.5, 17.4, 15.8, 14.4}, SklanskyChubukov: 73.3, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A5o", Equity: [MaxPreflopOpponents]float64{57.70, 38.2, 28.0, 22.0, 18.0, 15.2, 13.1, 11.5, 10.1}, SklanskyChubukov: 57.5, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A4s", Equity: [MaxPreflopOpponents]float64{59.03, 40.5, 31.0, 25.4, 21.7, 19.1, 17.0, 15.5, 14.2}, SklanskyChubukov: 67.6, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A4o", Equity: [MaxPreflopOpponents]float64{56.73, 37.2, 27.1, 21.3, 17.5, 14.8, 12.7, 11.1, 9.8}, SklanskyChubukov: 52.9, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A3s", Equity: [MaxPreflopOpponents]float64{58.22, 39.6, 30.2, 24.8, 21.1, 18.6, 16.7, 15.2, 13.9}, SklanskyChubukov: 63.3, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A3o", Equity: [MaxPreflopOpponents]float64{55.84, 36.2, 26.3, 20.7, 16.9, 14.3, 12.3, 10.8, 9.6}, SklanskyChubukov: 49.4, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "A2s", Equity: [MaxPreflopOpponents]float64{57.38, 38.8, 29.4, 24.1, 20.6, 18.2, 16.2, 14.8, 13.6}, SklanskyChubukov: 59.1, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "A2o", Equity: [MaxPreflopOpponents]float64{54.93, 35.3, 25.4, 19.9, 16.3, 13.8, 11.8, 10.4, 9.2}, SklanskyChubukov: 46.2, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "KK", Equity: [MaxPreflopOpponents]float64{82.40, 68.9, 58.2, 49.8, 42.9, 37.4, 32.9, 29.2, 26.1}, SklanskyChubukov: 955.0, ChenScore: 16, SklanskyGroup: 1},
+	{Notation: "KQs", Equity: [MaxPreflopOpponents]float64{63.40, 47.1, 38.2, 32.5, 28.4, 25.1, 22.5, 20.4, 18.6}, SklanskyChubukov: 87.6, ChenScore: 10, SklanskyGroup: 2},
+	{Notation: "KQo", Equity: [MaxPreflopOpponents]float64{61.46, 44.4, 35.1, 29.3, 25.0, 21.8, 19.1, 16.9, 15.1}, SklanskyChubukov: 59.8, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "KJs", Equity: [MaxPreflopOpponents]float64{62.57, 45.9, 36.8, 31.1, 26.9, 23.8, 21.3, 19.3, 17.6}, SklanskyChubukov: 73.6, ChenScore: 9, SklanskyGroup: 3},
+	{Notation: "KJo", Equity: [MaxPreflopOpponents]float64{60.57, 43.1, 33.7, 27.8, 23.5, 20.3, 17.7, 15.6, 13.9}, SklanskyChubukov: 51.8, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "KTs", Equity: [MaxPreflopOpponents]float64{61.79, 44.8, 35.7, 29.9, 25.9, 22.8, 20.4, 18.5, 16.9}, SklanskyChubukov: 63.8, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "KTo", Equity: [MaxPreflopOpponents]float64{59.74, 42.0, 32.4, 26.4, 22.3, 19.1, 16.7, 14.6, 13.1}, SklanskyChubukov: 45.9, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "K9s", Equity: [MaxPreflopOpponents]float64{59.99, 42.3, 32.9, 27.2, 23.2, 20.3, 18.1, 16.3, 14.8}, SklanskyChubukov: 48.8, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "K9o", Equity: [MaxPreflopOpponents]float64{57.81, 39.3, 29.5, 23.5, 19.4, 16.4, 14.1, 12.3, 10.8}, SklanskyChubukov: 36.7, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "K8s", Equity: [MaxPreflopOpponents]float64{58.31, 40.1, 30.8, 25.1, 21.4, 18.6, 16.5, 14.9, 13.5}, SklanskyChubukov: 40.9, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K8o", Equity: [MaxPreflopOpponents]float64{56.02, 37.0, 27.1, 21.3, 17.4, 14.6, 12.4, 10.7, 9.4}, SklanskyChubukov: 31.5, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K7s", Equity: [MaxPreflopOpponents]float64{57.54, 39.3, 29.9, 24.4, 20.7, 18.0, 16.0, 14.4, 13.1}, SklanskyChubukov: 38.3, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K7o", Equity: [MaxPreflopOpponents]float64{55.19, 36.0, 26.2, 20.5, 16.6, 13.9, 11.8, 10.2, 8.9}, SklanskyChubukov: 29.5, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K6s", Equity: [MaxPreflopOpponents]float64{56.64, 38.3, 29.0, 23.6, 20.0, 17.5, 15.5, 14.0, 12.8}, SklanskyChubukov: 35.9, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K6o", Equity: [MaxPreflopOpponents]float64{54.22, 35.0, 25.2, 19.6, 15.9, 13.2, 11.3, 9.8, 8.5}, SklanskyChubukov: 27.7, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K5s", Equity: [MaxPreflopOpponents]float64{55.79, 37.4, 28.3, 23.0, 19.5, 17.0, 15.1, 13.7, 12.5}, SklanskyChubukov: 33.3, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K5o", Equity: [MaxPreflopOpponents]float64{53.31, 34.0, 24.4, 18.9, 15.3, 12.8, 10.9, 9.4, 8.2}, SklanskyChubukov: 25.7, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K4s", Equity: [MaxPreflopOpponents]float64{54.88, 36.5, 27.5, 22.4, 19.0, 16.6, 14.8, 13.4, 12.3}, SklanskyChubukov: 31.2, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K4o", Equity: [MaxPreflopOpponents]float64{52.33, 33.0, 23.6, 18.2, 14.8, 12.3, 10.5, 9.1, 8.0}, SklanskyChubukov: 23.8, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K3s", Equity: [MaxPreflopOpponents]float64{54.05, 35.7, 26.9, 21.9, 18.6, 16.3, 14.5, 13.2, 12.1}, SklanskyChubukov: 29.4, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K3o", Equity: [MaxPreflopOpponents]float64{51.43, 32.1, 22.8, 17.6, 14.3, 11.9, 10.2, 8.9, 7.8}, SklanskyChubukov: 22.4, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "K2s", Equity: [MaxPreflopOpponents]float64{53.21, 34.9, 26.2, 21.3, 18.2, 16.0, 14.3, 13.0, 12.0}, SklanskyChubukov: 27.7, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "K2o", Equity: [MaxPreflopOpponents]float64{50.51, 31.2, 22.1, 17.0, 13.9, 11.6, 10.0, 8.7, 7.7}, SklanskyChubukov: 21.0, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "QQ", Equity: [MaxPreflopOpponents]float64{79.93, 64.9, 53.5, 44.7, 37.9, 32.5, 28.2, 24.9, 22.2}, SklanskyChubukov: 479.0, ChenScore: 14, SklanskyGroup: 1},
+	{Notation: "QJs", Equity: [MaxPreflopOpponents]float64{60.26, 44.2, 35.6, 30.2, 26.2, 23.2, 20.7, 18.8, 17.2}, SklanskyChubukov: 50.5, ChenScore: 9, SklanskyGroup: 3},
+	{Notation: "QJo", Equity: [MaxPreflopOpponents]float64{58.13, 41.4, 32.5, 27.0, 22.9, 19.8, 17.3, 15.3, 13.7}, SklanskyChubukov: 33.8, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "QTs", Equity: [MaxPreflopOpponents]float64{59.47, 43.1, 34.5, 29.1, 25.2, 22.2, 19.9, 18.0, 16.6}, SklanskyChubukov: 44.8, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "QTo", Equity: [MaxPreflopOpponents]float64{57.29, 40.2, 31.3, 25.7, 21.7, 18.6, 16.3, 14.4, 12.9}, SklanskyChubukov: 30.7, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "Q9s", Equity: [MaxPreflopOpponents]float64{57.66, 40.6, 31.8, 26.4, 22.6, 19.8, 17.6, 15.9, 14.5}, SklanskyChubukov: 33.5, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "Q9o", Equity: [MaxPreflopOpponents]float64{55.36, 37.6, 28.4, 22.8, 18.9, 15.9, 13.8, 12.1, 10.7}, SklanskyChubukov: 24.4, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "Q8s", Equity: [MaxPreflopOpponents]float64{56.02, 38.5, 29.7, 24.4, 20.7, 18.1, 16.1, 14.5, 13.2}, SklanskyChubukov: 27.7, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "Q8o", Equity: [MaxPreflopOpponents]float64{53.60, 35.3, 26.1, 20.6, 16.9, 14.1, 12.1, 10.5, 9.2}, SklanskyChubukov: 20.8, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "Q7s", Equity: [MaxPreflopOpponents]float64{54.30, 36.4, 27.7, 22.6, 19.1, 16.6, 14.7, 13.2, 12.1}, SklanskyChubukov: 23.7, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q7o", Equity: [MaxPreflopOpponents]float64{51.77, 33.0, 23.9, 18.6, 15.0, 12.5, 10.6, 9.2, 8.0}, SklanskyChubukov: 18.1, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "Q6s", Equity: [MaxPreflopOpponents]float64{53.61, 35.6, 27.0, 21.9, 18.6, 16.1, 14.3, 12.9, 11.8}, SklanskyChubukov: 22.8, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q6o", Equity: [MaxPreflopOpponents]float64{51.02, 32.2, 23.1, 17.9, 14.4, 12.0, 10.1, 8.7, 7.7}, SklanskyChubukov: 17.3, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "Q5s", Equity: [MaxPreflopOpponents]float64{52.77, 34.8, 26.3, 21.3, 18.1, 15.7, 13.9, 12.6, 11.5}, SklanskyChubukov: 21.3, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q5o", Equity: [MaxPreflopOpponents]float64{50.12, 31.3, 22.3, 17.2, 13.9, 11.5, 9.7, 8.4, 7.4}, SklanskyChubukov: 16.0, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "Q4s", Equity: [MaxPreflopOpponents]float64{51.86, 34.0, 25.5, 20.7, 17.6, 15.3, 13.6, 12.3, 11.3}, SklanskyChubukov: 19.9, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q4o", Equity: [MaxPreflopOpponents]float64{49.13, 30.4, 21.5, 16.6, 13.4, 11.1, 9.4, 8.1, 7.1}, SklanskyChubukov: 14.7, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "Q3s", Equity: [MaxPreflopOpponents]float64{51.02, 33.2, 24.9, 20.2, 17.2, 15.0, 13.4, 12.1, 11.2}, SklanskyChubukov: 18.7, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q3o", Equity: [MaxPreflopOpponents]float64{48.22, 29.5, 20.8, 16.0, 12.9, 10.7, 9.2, 7.9, 7.0}, SklanskyChubukov: 13.5, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "Q2s", Equity: [MaxPreflopOpponents]float64{50.17, 32.4, 24.2, 19.7, 16.8, 14.7, 13.1, 11.9, 11.0}, SklanskyChubukov: 17.6, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "Q2o", Equity: [MaxPreflopOpponents]float64{47.30, 28.6, 20.1, 15.4, 12.5, 10.4, 8.9, 7.7, 6.8}, SklanskyChubukov: 12.3, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "JJ", Equity: [MaxPreflopOpponents]float64{77.47, 61.2, 49.1, 40.4, 33.5, 28.5, 24.6, 21.6, 19.3}, SklanskyChubukov: 320.2, ChenScore: 12, SklanskyGroup: 1},
+	{Notation: "JTs", Equity: [MaxPreflopOpponents]float64{57.53, 42.0, 33.8, 28.7, 24.8, 22.0, 19.7, 18.0, 16.5}, SklanskyChubukov: 37.1, ChenScore: 9, SklanskyGroup: 3},
+	{Notation: "JTo", Equity: [MaxPreflopOpponents]float64{55.25, 39.1, 30.7, 25.4, 21.4, 18.5, 16.2, 14.5, 13.0}, SklanskyChubukov: 24.1, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "J9s", Equity: [MaxPreflopOpponents]float64{55.66, 39.5, 31.2, 26.0, 22.3, 19.6, 17.5, 15.9, 14.5}, SklanskyChubukov: 26.7, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "J9o", Equity: [MaxPreflopOpponents]float64{53.25, 36.4, 27.8, 22.5, 18.7, 15.9, 13.8, 12.2, 10.9}, SklanskyChubukov: 18.8, ChenScore: 6, SklanskyGroup: 7},
+	{Notation: "J8s", Equity: [MaxPreflopOpponents]float64{54.02, 37.4, 29.1, 24.0, 20.5, 17.9, 16.0, 14.4, 13.2}, SklanskyChubukov: 21.6, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "J8o", Equity: [MaxPreflopOpponents]float64{51.49, 34.1, 25.5, 20.3, 16.7, 14.1, 12.2, 10.6, 9.4}, SklanskyChubukov: 15.9, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "J7s", Equity: [MaxPreflopOpponents]float64{52.32, 35.3, 27.1, 22.2, 18.8, 16.4, 14.5, 13.1, 12.0}, SklanskyChubukov: 18.2, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "J7o", Equity: [MaxPreflopOpponents]float64{49.68, 31.9, 23.4, 18.3, 14.8, 12.4, 10.6, 9.2, 8.1}, SklanskyChubukov: 13.7, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "J6s", Equity: [MaxPreflopOpponents]float64{50.61, 33.4, 25.2, 20.5, 17.3, 15.1, 13.3, 12.1, 11.0}, SklanskyChubukov: 15.7, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "J6o", Equity: [MaxPreflopOpponents]float64{47.84, 29.8, 21.3, 16.5, 13.2, 11.0, 9.3, 8.0, 7.0}, SklanskyChubukov: 11.8, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "J5s", Equity: [MaxPreflopOpponents]float64{49.99, 32.7, 24.6, 20.0, 16.9, 14.7, 13.0, 11.8, 10.7}, SklanskyChubukov: 15.0, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "J5o", Equity: [MaxPreflopOpponents]float64{47.18, 29.2, 20.7, 15.9, 12.8, 10.6, 8.9, 7.7, 6.7}, SklanskyChubukov: 11.0, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "J4s", Equity: [MaxPreflopOpponents]float64{49.07, 31.9, 23.9, 19.5, 16.5, 14.3, 12.7, 11.5, 10.6}, SklanskyChubukov: 13.9, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "J4o", Equity: [MaxPreflopOpponents]float64{46.19, 28.2, 19.9, 15.3, 12.3, 10.2, 8.6, 7.4, 6.5}, SklanskyChubukov: 9.9, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "J3s", Equity: [MaxPreflopOpponents]float64{48.23, 31.1, 23.3, 19.0, 16.0, 14.0, 12.5, 11.3, 10.4}, SklanskyChubukov: 13.0, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "J3o", Equity: [MaxPreflopOpponents]float64{45.28, 27.4, 19.2, 14.8, 11.8, 9.8, 8.4, 7.2, 6.4}, SklanskyChubukov: 8.9, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "J2s", Equity: [MaxPreflopOpponents]float64{47.38, 30.4, 22.7, 18.5, 15.7, 13.8, 12.3, 11.2, 10.3}, SklanskyChubukov: 12.1, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "J2o", Equity: [MaxPreflopOpponents]float64{44.35, 26.5, 18.5, 14.2, 11.4, 9.5, 8.1, 7.0, 6.2}, SklanskyChubukov: 7.9, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "TT", Equity: [MaxPreflopOpponents]float64{75.01, 57.6, 45.2, 36.4, 29.9, 25.2, 21.7, 19.1, 17.1}, SklanskyChubukov: 240.8, ChenScore: 10, SklanskyGroup: 2},
+	{Notation: "T9s", Equity: [MaxPreflopOpponents]float64{54.03, 38.7, 31.0, 25.9, 22.4, 19.8, 17.7, 16.1, 14.8}, SklanskyChubukov: 23.5, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "T9o", Equity: [MaxPreflopOpponents]float64{51.53, 35.7, 27.6, 22.5, 18.9, 16.2, 14.1, 12.6, 11.3}, SklanskyChubukov: 15.8, ChenScore: 6, SklanskyGroup: 7},
+	{Notation: "T8s", Equity: [MaxPreflopOpponents]float64{52.33, 36.6, 28.9, 24.0, 20.5, 18.1, 16.2, 14.7, 13.5}, SklanskyChubukov: 18.5, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "T8o", Equity: [MaxPreflopOpponents]float64{49.72, 33.4, 25.4, 20.4, 16.9, 14.4, 12.5, 11.0, 9.9}, SklanskyChubukov: 13.2, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "T7s", Equity: [MaxPreflopOpponents]float64{50.64, 34.6, 26.9, 22.2, 18.9, 16.5, 14.7, 13.4, 12.3}, SklanskyChubukov: 15.2, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "T7o", Equity: [MaxPreflopOpponents]float64{47.91, 31.3, 23.2, 18.4, 15.1, 12.7, 10.9, 9.6, 8.6}, SklanskyChubukov: 11.2, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "T6s", Equity: [MaxPreflopOpponents]float64{48.94, 32.6, 25.1, 20.4, 17.3, 15.2, 13.5, 12.2, 11.2}, SklanskyChubukov: 12.9, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "T6o", Equity: [MaxPreflopOpponents]float64{46.09, 29.1, 21.2, 16.5, 13.4, 11.2, 9.5, 8.3, 7.4}, SklanskyChubukov: 9.6, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "T5s", Equity: [MaxPreflopOpponents]float64{47.22, 30.8, 23.3, 18.9, 16.0, 13.9, 12.4, 11.2, 10.2}, SklanskyChubukov: 10.9, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "T5o", Equity: [MaxPreflopOpponents]float64{44.25, 27.1, 19.4, 14.8, 11.9, 9.9, 8.4, 7.2, 6.4}, SklanskyChubukov: 7.9, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "T4s", Equity: [MaxPreflopOpponents]float64{46.53, 30.1, 22.8, 18.4, 15.6, 13.6, 12.1, 11.0, 10.0}, SklanskyChubukov: 10.3, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "T4o", Equity: [MaxPreflopOpponents]float64{43.50, 26.4, 18.7, 14.3, 11.5, 9.5, 8.0, 7.0, 6.1}, SklanskyChubukov: 7.2, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "T3s", Equity: [MaxPreflopOpponents]float64{45.69, 29.4, 22.2, 18.0, 15.2, 13.3, 11.9, 10.8, 9.9}, SklanskyChubukov: 9.4, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "T3o", Equity: [MaxPreflopOpponents]float64{42.59, 25.6, 18.0, 13.8, 11.0, 9.2, 7.8, 6.7, 6.0}, SklanskyChubukov: 6.5, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "T2s", Equity: [MaxPreflopOpponents]float64{44.84, 28.6, 21.5, 17.5, 14.9, 13.0, 11.7, 10.6, 9.7}, SklanskyChubukov: 8.5, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "T2o", Equity: [MaxPreflopOpponents]float64{41.67, 24.7, 17.3, 13.2, 10.6, 8.8, 7.5, 6.6, 5.8}, SklanskyChubukov: 5.8, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "99", Equity: [MaxPreflopOpponents]float64{72.06, 53.6, 41.1, 32.6, 26.6, 22.4, 19.4, 17.2, 15.6}, SklanskyChubukov: 192.4, ChenScore: 9, SklanskyGroup: 3},
+	{Notation: "98s", Equity: [MaxPreflopOpponents]float64{50.80, 35.9, 28.5, 23.6, 20.3, 17.8, 16.0, 14.5, 13.3}, SklanskyChubukov: 16.3, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "98o", Equity: [MaxPreflopOpponents]float64{48.10, 32.7, 25.0, 20.0, 16.6, 14.1, 12.4, 10.9, 9.8}, SklanskyChubukov: 11.3, ChenScore: 6, SklanskyGroup: 7},
+	{Notation: "97s", Equity: [MaxPreflopOpponents]float64{49.12, 34.0, 26.6, 22.0, 18.8, 16.5, 14.8, 13.5, 12.4}, SklanskyChubukov: 13.2, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "97o", Equity: [MaxPreflopOpponents]float64{46.30, 30.7, 23.0, 18.3, 15.0, 12.8, 11.1, 9.8, 8.8}, SklanskyChubukov: 9.6, ChenScore: 5, SklanskyGroup: 9},
+	{Notation: "96s", Equity: [MaxPreflopOpponents]float64{47.43, 32.1, 24.9, 20.3, 17.3, 15.2, 13.6, 12.4, 11.4}, SklanskyChubukov: 11.1, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "96o", Equity: [MaxPreflopOpponents]float64{44.49, 28.6, 21.0, 16.5, 13.4, 11.3, 9.7, 8.6, 7.7}, SklanskyChubukov: 8.1, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "95s", Equity: [MaxPreflopOpponents]float64{45.72, 30.2, 23.0, 18.7, 15.9, 13.9, 12.4, 11.3, 10.3}, SklanskyChubukov: 9.3, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "95o", Equity: [MaxPreflopOpponents]float64{42.67, 26.6, 19.1, 14.8, 11.9, 9.9, 8.5, 7.4, 6.5}, SklanskyChubukov: 6.6, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "94s", Equity: [MaxPreflopOpponents]float64{43.86, 28.4, 21.3, 17.3, 14.6, 12.7, 11.4, 10.3, 9.4}, SklanskyChubukov: 7.6, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "94o", Equity: [MaxPreflopOpponents]float64{40.67, 24.5, 17.3, 13.2, 10.5, 8.6, 7.3, 6.4, 5.6}, SklanskyChubukov: 5.3, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "93s", Equity: [MaxPreflopOpponents]float64{43.26, 27.8, 20.9, 16.9, 14.3, 12.5, 11.1, 10.1, 9.2}, SklanskyChubukov: 7.1, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "93o", Equity: [MaxPreflopOpponents]float64{40.02, 23.9, 16.7, 12.7, 10.1, 8.3, 7.1, 6.1, 5.4}, SklanskyChubukov: 5.0, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "92s", Equity: [MaxPreflopOpponents]float64{42.42, 27.1, 20.3, 16.4, 13.9, 12.2, 10.9, 9.9, 9.1}, SklanskyChubukov: 6.4, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "92o", Equity: [MaxPreflopOpponents]float64{39.10, 23.1, 16.1, 12.2, 9.7, 8.1, 6.8, 5.9, 5.2}, SklanskyChubukov: 4.6, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "88", Equity: [MaxPreflopOpponents]float64{69.16, 50.0, 37.5, 29.5, 24.0, 20.3, 17.8, 15.8, 14.5}, SklanskyChubukov: 160.3, ChenScore: 8, SklanskyGroup: 4},
+	{Notation: "87s", Equity: [MaxPreflopOpponents]float64{47.94, 33.7, 26.6, 22.1, 19.0, 16.7, 15.0, 13.8, 12.7}, SklanskyChubukov: 12.1, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "87o", Equity: [MaxPreflopOpponents]float64{45.05, 30.3, 23.0, 18.4, 15.2, 13.1, 11.4, 10.2, 9.2}, SklanskyChubukov: 8.5, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "86s", Equity: [MaxPreflopOpponents]float64{46.24, 31.9, 25.0, 20.5, 17.6, 15.5, 14.1, 12.8, 11.9}, SklanskyChubukov: 10.0, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "86o", Equity: [MaxPreflopOpponents]float64{43.24, 28.4, 21.2, 16.8, 13.8, 11.8, 10.3, 9.2, 8.3}, SklanskyChubukov: 7.1, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "85s", Equity: [MaxPreflopOpponents]float64{44.54, 30.0, 23.2, 19.0, 16.3, 14.3, 12.9, 11.8, 10.8}, SklanskyChubukov: 8.2, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "85o", Equity: [MaxPreflopOpponents]float64{41.43, 26.4, 19.3, 15.1, 12.4, 10.5, 9.1, 8.1, 7.2}, SklanskyChubukov: 5.8, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "84s", Equity: [MaxPreflopOpponents]float64{42.70, 28.1, 21.4, 17.5, 14.9, 13.0, 11.7, 10.7, 9.8}, SklanskyChubukov: 6.7, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "84o", Equity: [MaxPreflopOpponents]float64{39.45, 24.3, 17.4, 13.4, 10.8, 9.0, 7.8, 6.8, 6.1}, SklanskyChubukov: 4.7, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "83s", Equity: [MaxPreflopOpponents]float64{40.87, 26.3, 19.8, 16.1, 13.6, 11.9, 10.7, 9.7, 8.9}, SklanskyChubukov: 5.5, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "83o", Equity: [MaxPreflopOpponents]float64{37.48, 22.3, 15.7, 11.9, 9.5, 7.9, 6.7, 5.8, 5.1}, SklanskyChubukov: 4.0, ChenScore: -1, SklanskyGroup: 9},
+	{Notation: "82s", Equity: [MaxPreflopOpponents]float64{40.27, 25.8, 19.4, 15.7, 13.3, 11.7, 10.5, 9.5, 8.7}, SklanskyChubukov: 5.1, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "82o", Equity: [MaxPreflopOpponents]float64{36.83, 21.8, 15.2, 11.4, 9.1, 7.6, 6.5, 5.6, 4.9}, SklanskyChubukov: 3.8, ChenScore: -1, SklanskyGroup: 9},
+	{Notation: "77", Equity: [MaxPreflopOpponents]float64{66.24, 46.5, 34.3, 26.8, 21.8, 18.6, 16.3, 14.8, 13.6}, SklanskyChubukov: 135.8, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "76s", Equity: [MaxPreflopOpponents]float64{45.37, 31.9, 25.1, 20.8, 17.9, 15.9, 14.4, 13.2, 12.2}, SklanskyChubukov: 9.3, ChenScore: 7, SklanskyGroup: 5},
+	{Notation: "76o", Equity: [MaxPreflopOpponents]float64{42.32, 28.4, 21.4, 17.1, 14.1, 12.2, 10.7, 9.6, 8.7}, SklanskyChubukov: 6.4, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "75s", Equity: [MaxPreflopOpponents]float64{43.68, 30.1, 23.4, 19.4, 16.7, 14.8, 13.4, 12.3, 11.4}, SklanskyChubukov: 7.6, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "75o", Equity: [MaxPreflopOpponents]float64{40.51, 26.5, 19.6, 15.6, 12.8, 11.0, 9.7, 8.7, 7.9}, SklanskyChubukov: 5.3, ChenScore: 4, SklanskyGroup: 9},
+	{Notation: "74s", Equity: [MaxPreflopOpponents]float64{41.85, 28.2, 21.8, 17.9, 15.4, 13.5, 12.3, 11.2, 10.4}, SklanskyChubukov: 6.1, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "74o", Equity: [MaxPreflopOpponents]float64{38.55, 24.5, 17.8, 13.9, 11.4, 9.6, 8.4, 7.5, 6.8}, SklanskyChubukov: 4.4, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "73s", Equity: [MaxPreflopOpponents]float64{40.04, 26.4, 20.1, 16.4, 14.0, 12.4, 11.1, 10.2, 9.4}, SklanskyChubukov: 5.0, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "73o", Equity: [MaxPreflopOpponents]float64{36.60, 22.4, 15.9, 12.3, 9.9, 8.4, 7.2, 6.4, 5.7}, SklanskyChubukov: 3.7, ChenScore: 0, SklanskyGroup: 9},
+	{Notation: "72s", Equity: [MaxPreflopOpponents]float64{38.16, 24.6, 18.5, 15.0, 12.8, 11.3, 10.1, 9.2, 8.5}, SklanskyChubukov: 4.2, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "72o", Equity: [MaxPreflopOpponents]float64{34.58, 20.5, 14.2, 10.8, 8.6, 7.2, 6.1, 5.4, 4.8}, SklanskyChubukov: 3.2, ChenScore: -1, SklanskyGroup: 9},
+	{Notation: "66", Equity: [MaxPreflopOpponents]float64{63.28, 43.1, 31.5, 24.5, 20.1, 17.3, 15.3, 14.0, 13.0}, SklanskyChubukov: 116.3, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "65s", Equity: [MaxPreflopOpponents]float64{43.13, 30.3, 23.8, 19.7, 17.1, 15.2, 13.8, 12.8, 11.8}, SklanskyChubukov: 7.2, ChenScore: 6, SklanskyGroup: 5},
+	{Notation: "65o", Equity: [MaxPreflopOpponents]float64{39.94, 26.6, 20.0, 15.9, 13.2, 11.4, 10.2, 9.2, 8.4}, SklanskyChubukov: 5.0, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "64s", Equity: [MaxPreflopOpponents]float64{41.33, 28.5, 22.2, 18.3, 15.9, 14.2, 12.9, 11.9, 11.0}, SklanskyChubukov: 5.8, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "64o", Equity: [MaxPreflopOpponents]float64{38.01, 24.8, 18.3, 14.4, 12.0, 10.3, 9.2, 8.2, 7.5}, SklanskyChubukov: 4.2, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "63s", Equity: [MaxPreflopOpponents]float64{39.53, 26.6, 20.5, 16.8, 14.6, 13.0, 11.8, 10.8, 10.0}, SklanskyChubukov: 4.8, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "63o", Equity: [MaxPreflopOpponents]float64{36.08, 22.7, 16.5, 12.8, 10.5, 9.0, 8.0, 7.1, 6.5}, SklanskyChubukov: 3.6, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "62s", Equity: [MaxPreflopOpponents]float64{37.67, 24.8, 18.9, 15.4, 13.2, 11.8, 10.7, 9.8, 9.0}, SklanskyChubukov: 4.1, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "62o", Equity: [MaxPreflopOpponents]float64{34.08, 20.8, 14.7, 11.2, 9.1, 7.8, 6.8, 6.0, 5.4}, SklanskyChubukov: 3.1, ChenScore: -1, SklanskyGroup: 9},
+	{Notation: "55", Equity: [MaxPreflopOpponents]float64{60.32, 40.0, 28.9, 22.5, 18.5, 16.1, 14.4, 13.2, 12.4}, SklanskyChubukov: 99.6, ChenScore: 5, SklanskyGroup: 6},
+	{Notation: "54s", Equity: [MaxPreflopOpponents]float64{41.45, 29.0, 22.7, 18.8, 16.5, 14.7, 13.5, 12.5, 11.6}, SklanskyChubukov: 5.8, ChenScore: 6, SklanskyGroup: 6},
+	{Notation: "54o", Equity: [MaxPreflopOpponents]float64{38.16, 25.3, 18.9, 15.0, 12.6, 11.0, 9.8, 8.9, 8.2}, SklanskyChubukov: 4.2, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "53s", Equity: [MaxPreflopOpponents]float64{39.69, 27.2, 21.2, 17.6, 15.4, 13.8, 12.6, 11.6, 10.8}, SklanskyChubukov: 4.8, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "53o", Equity: [MaxPreflopOpponents]float64{36.26, 23.4, 17.2, 13.7, 11.4, 9.9, 8.8, 8.0, 7.3}, SklanskyChubukov: 3.6, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "52s", Equity: [MaxPreflopOpponents]float64{37.85, 25.5, 19.6, 16.1, 14.1, 12.6, 11.4, 10.6, 9.8}, SklanskyChubukov: 4.1, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "52o", Equity: [MaxPreflopOpponents]float64{34.28, 21.5, 15.5, 12.1, 10.0, 8.6, 7.6, 6.9, 6.3}, SklanskyChubukov: 3.2, ChenScore: 1, SklanskyGroup: 9},
+	{Notation: "44", Equity: [MaxPreflopOpponents]float64{57.02, 36.7, 26.3, 20.6, 17.3, 15.2, 13.9, 12.9, 12.1}, SklanskyChubukov: 83.0, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "43s", Equity: [MaxPreflopOpponents]float64{38.64, 26.4, 20.4, 16.9, 14.7, 13.2, 12.1, 11.1, 10.4}, SklanskyChubukov: 4.4, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "43o", Equity: [MaxPreflopOpponents]float64{35.15, 22.5, 16.4, 12.9, 10.8, 9.3, 8.3, 7.5, 6.9}, SklanskyChubukov: 3.4, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "42s", Equity: [MaxPreflopOpponents]float64{36.83, 24.7, 18.9, 15.7, 13.6, 12.2, 11.2, 10.3, 9.6}, SklanskyChubukov: 3.8, ChenScore: 4, SklanskyGroup: 8},
+	{Notation: "42o", Equity: [MaxPreflopOpponents]float64{33.20, 20.6, 14.8, 11.5, 9.6, 8.3, 7.3, 6.6, 6.0}, SklanskyChubukov: 3.0, ChenScore: 2, SklanskyGroup: 9},
+	{Notation: "33", Equity: [MaxPreflopOpponents]float64{53.69, 33.6, 24.0, 19.0, 16.2, 14.6, 13.5, 12.7, 12.0}, SklanskyChubukov: 66.4, ChenScore: 5, SklanskyGroup: 7},
+	{Notation: "32s", Equity: [MaxPreflopOpponents]float64{35.98, 23.8, 18.2, 15.0, 13.1, 11.7, 10.7, 9.9, 9.2}, SklanskyChubukov: 3.6, ChenScore: 5, SklanskyGroup: 8},
+	{Notation: "32o", Equity: [MaxPreflopOpponents]float64{32.30, 19.8, 13.9, 10.8, 8.9, 7.7, 6.8, 6.1, 5.6}, SklanskyChubukov: 2.8, ChenScore: 3, SklanskyGroup: 9},
+	{Notation: "22", Equity: [MaxPreflopOpponents]float64{50.33, 30.7, 21.9, 17.7, 15.4, 14.2, 13.2, 12.5, 11.9}, SklanskyChubukov: 49.0, ChenScore: 5, SklanskyGroup: 7},
+}
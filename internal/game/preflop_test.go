@@ -0,0 +1,90 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPreflopHandsOrder(t *testing.T) {
+	hands, notations := PreflopHands(), StartingHandNotations()
+	if len(hands) != len(notations) {
+		t.Fatalf("got %d hands, want %d", len(hands), len(notations))
+	}
+	for i, h := range hands {
+		if h.Notation != notations[i] {
+			t.Errorf("hand %d is %s, want %s", i, h.Notation, notations[i])
+		}
+	}
+}
+
+// Checked against the published heads-up equities and Sklansky-Chubukov
+// numbers
+func TestPreflopKnownValues(t *testing.T) {
+	tests := []struct {
+		notation string
+		equity   float64
+		sc       float64
+	}{
+		{"AA", 85.20, math.Inf(1)},
+		{"KK", 82.40, 954},
+		{"QQ", 79.93, 478},
+		{"AKs", 67.04, 555.5},
+		{"AQs", 66.21, 0},
+		{"AKo", 65.32, 0},
+		{"72o", 34.58, 3.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			h, ok := LookupPreflop(tt.notation)
+			if !ok {
+				t.Fatal("not found")
+			}
+			if got := h.EquityVs(1); math.Abs(got-tt.equity) > 0.005 {
+				t.Errorf("equity = %.2f, want %.2f", got, tt.equity)
+			}
+			if tt.sc == 0 {
+				return
+			}
+			if math.IsInf(tt.sc, 1) != math.IsInf(h.SklanskyChubukov, 1) || math.Abs(h.SklanskyChubukov-tt.sc) > tt.sc/200 {
+				t.Errorf("Sklansky-Chubukov = %.1f, want about %g", h.SklanskyChubukov, tt.sc)
+			}
+		})
+	}
+}
+
+func TestChenScore(t *testing.T) {
+	tests := []struct {
+		hole string
+		want int
+	}{
+		{"AsAh", 20},
+		{"KdKc", 16},
+		{"7s7h", 7},
+		// Pairs score at least 5
+		{"2s2h", 5},
+		{"AsKs", 12},
+		{"AsKd", 10},
+		{"QsJs", 9},
+		// Connected below a queen
+		{"Ts9s", 8},
+		// One gap below a queen
+		{"Js9s", 8},
+		{"5s3d", 3},
+		// Rounded up from -1.5
+		{"7s2d", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.hole, func(t *testing.T) {
+			cards := mustCards(t, tt.hole)
+			if got := ChenScore(cards); got != tt.want {
+				t.Errorf("ChenScore = %d, want %d", got, tt.want)
+			}
+			if got := ChenScore([]Card{cards[1], cards[0]}); got != tt.want {
+				t.Errorf("ChenScore with the cards swapped = %d, want %d", got, tt.want)
+			}
+			if h, _ := PreflopStats(cards); h.ChenScore != tt.want {
+				t.Errorf("table Chen score = %d, want %d", h.ChenScore, tt.want)
+			}
+		})
+	}
+}
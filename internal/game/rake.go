@@ -0,0 +1,131 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RakeRules sets the house's cut of each pot. The zero value takes no rake.
+type RakeRules struct {
+	Percent      float64     `json:"percent"`                // Of the pot, e.g. 5 for 5%
+	Cap          int         `json:"cap,omitempty"`          // Most taken from a hand, 0 for no cap
+	CapByPlayers map[int]int `json:"capByPlayers,omitempty"` // Cap for hands dealt to at least this many players, overriding Cap
+	NoFlopNoDrop bool        `json:"noFlopNoDrop,omitempty"` // Take nothing from hands over before the flop
+}
+
+// amount returns the rake for a pot, rounded down to a whole chip
+func (r RakeRules) amount(pot, dealtIn int, sawFlop bool) int {
+	if r.Percent <= 0 || pot <= 0 || (r.NoFlopNoDrop && !sawFlop) {
+		return 0
+	}
+	// The small epsilon stops 5% of 100 flooring to 4
+	rake := int(math.Floor(float64(pot)*r.Percent/100 + 1e-9))
+	if c := r.cap(dealtIn); c > 0 {
+		rake = min(rake, c)
+	}
+	return min(rake, pot)
+}
+
+// cap returns the cap for a hand dealt to n players: the CapByPlayers entry
+// with the largest count not above n, or Cap
+func (r RakeRules) cap(n int) int {
+	c, best := r.Cap, 0
+	for players, playerCap := range r.CapByPlayers {
+		if players <= n && players > best {
+			c, best = playerCap, players
+		}
+	}
+	return c
+}
+
+// takeRake works out the rake for the hand that is ending
+func (g *PokerGame) takeRake() error {
+	rake := g.rakeDue(len(g.CommunityCards) >= 3)
+	if rake == 0 {
+		return nil
+	}
+	return g.emit(Event{Type: EventRakeTaken, Amount: rake})
+}
+
+// rakeDue returns the rake on the pot as it stands. Uncalled bets go back
+// to the bettor, so they are never raked.
+func (g *PokerGame) rakeDue(sawFlop bool) int {
+	dealtIn := 0
+	for _, p := range g.Players {
+		if len(p.HoleCards) > 0 {
+			dealtIn++
+		}
+	}
+	return g.Rake.amount(g.Pot-g.uncalledBet(), dealtIn, sawFlop)
+}
+
+// uncalledBet returns the part of the largest investment in the hand that
+// nobody matched
+func (g *PokerGame) uncalledBet() int {
+	top, second := 0, 0
+	for _, p := range g.Players {
+		switch {
+		case p.TotalBetInHand > top:
+			top, second = p.TotalBetInHand, top
+		case p.TotalBetInHand > second:
+			second = p.TotalBetInHand
+		}
+	}
+	return top - second
+}
+
+// rakedPots returns the pots less the hand's rake
+func (g *PokerGame) rakedPots() []SidePot {
+	return deductRake(g.pots(), g.HandRake)
+}
+
+// deductRake takes the rake from the pots, main pot first
+func deductRake(pots []SidePot, rake int) []SidePot {
+	for i := range pots {
+		take := min(rake, pots[i].Amount)
+		pots[i].Amount -= take
+		rake -= take
+	}
+	return pots
+}
+
+// EntryFee is the cost of a tournament entry: the buy-in goes to the prize
+// pool and the fee to the house, as in "$10+$1"
+type EntryFee struct {
+	BuyIn int `json:"buyIn"`
+	Fee   int `json:"fee"`
+}
+
+// PrizePoolAccount holds a tournament's entries until they are paid out
+const PrizePoolAccount = "prizePool"
+
+// RecordTournamentEntry posts a player's tournament entry: what they pay
+// comes from their cashier account, split between the prize pool and the
+// house
+func (l *Ledger) RecordTournamentEntry(playerID string, fee EntryFee) error {
+	if fee.BuyIn < 0 || fee.Fee < 0 || fee.BuyIn+fee.Fee == 0 {
+		return fmt.Errorf("invalid entry fee %d+%d", fee.BuyIn, fee.Fee)
+	}
+	postings := []Posting{
+		{CashierAccount(playerID), -(fee.BuyIn + fee.Fee)},
+		{PrizePoolAccount, fee.BuyIn},
+	}
+	if fee.Fee > 0 {
+		postings = append(postings, Posting{HouseAccount, fee.Fee})
+	}
+	l.post(Event{Time: time.Now().UTC()}, playerID, LedgerTournamentEntry, postings...)
+	return nil
+}
+
+// RecordTournamentPayout pays a prize from the prize pool to a player
+func (l *Ledger) RecordTournamentPayout(playerID string, amount int) error {
+	if amount <= 0 || amount > l.balances[PrizePoolAccount] {
+		return fmt.Errorf("cannot pay %d from a prize pool of %d", amount, l.balances[PrizePoolAccount])
+	}
+	l.post(Event{Time: time.Now().UTC()}, playerID, LedgerTournamentPayout,
+		Posting{PrizePoolAccount, -amount},
+		Posting{CashierAccount(playerID), amount},
+	)
+	return nil
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRakeAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   RakeRules
+		pot     int
+		dealtIn int
+		sawFlop bool
+		want    int
+	}{
+		{"no rake", RakeRules{}, 1000, 6, true, 0},
+		{"percent", RakeRules{Percent: 5}, 1000, 6, true, 50},
+		{"rounds down", RakeRules{Percent: 5}, 99, 6, true, 4},
+		{"exact percent", RakeRules{Percent: 5}, 100, 6, true, 5},
+		{"fractional percent", RakeRules{Percent: 2.5}, 1000, 6, true, 25},
+		{"capped", RakeRules{Percent: 5, Cap: 30}, 1000, 6, true, 30},
+		{"under the cap", RakeRules{Percent: 5, Cap: 30}, 200, 6, true, 10},
+		{"cap by players", RakeRules{Percent: 5, Cap: 30, CapByPlayers: map[int]int{2: 10, 4: 20}}, 1000, 3, true, 10},
+		{"largest count not above", RakeRules{Percent: 5, Cap: 30, CapByPlayers: map[int]int{2: 10, 4: 20}}, 1000, 9, true, 20},
+		{"below every count", RakeRules{Percent: 5, Cap: 30, CapByPlayers: map[int]int{3: 10}}, 1000, 2, true, 30},
+		{"no flop no drop", RakeRules{Percent: 5, NoFlopNoDrop: true}, 1000, 6, false, 0},
+		{"no flop, dropped anyway", RakeRules{Percent: 5}, 1000, 6, false, 50},
+		{"no flop no drop after the flop", RakeRules{Percent: 5, NoFlopNoDrop: true}, 1000, 6, true, 50},
+		{"never above the pot", RakeRules{Percent: 150}, 10, 2, true, 10},
+		{"empty pot", RakeRules{Percent: 5}, 0, 2, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.amount(tt.pot, tt.dealtIn, tt.sawFlop); got != tt.want {
+				t.Errorf("amount(%d, %d, %v) = %d, want %d", tt.pot, tt.dealtIn, tt.sawFlop, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeductRakeMainPotFirst(t *testing.T) {
+	pots := func() []SidePot {
+		return []SidePot{{Amount: 30}, {Amount: 50}, {Amount: 20}}
+	}
+	tests := []struct {
+		rake int
+		want []int
+	}{
+		{0, []int{30, 50, 20}},
+		{10, []int{20, 50, 20}},
+		{30, []int{0, 50, 20}},
+		{45, []int{0, 35, 20}},
+		{100, []int{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, pot := range deductRake(pots(), tt.rake) {
+			got = append(got, pot.Amount)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("rake %d: pots = %v, want %v", tt.rake, got, tt.want)
+		}
+	}
+}
+
+func TestRakeSkipsUncalledBet(t *testing.T) {
+	g := newTestGame(t, 4, "a", "b")
+	g.Rake = RakeRules{Percent: 10}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	// The button raises to 100 and the big blind folds: only the 10 the
+	// big blind called is matched, so 20 is raked at 10%
+	act(t, g, BotAction{Action: Raise, Amount: 100}, BotAction{Action: Fold})
+	if g.HandRake != 2 {
+		t.Errorf("rake = %d, want 2", g.HandRake)
+	}
+	if chips := g.Players[0].Chips + g.Players[1].Chips; chips != 2000-2 {
+		t.Errorf("players hold %d chips, want %d", chips, 2000-2)
+	}
+}
+
+// The all-in EV shares out what the pots will hold after the rake, so it
+// sums to the same as the actual results
+func TestAllInEVAfterRake(t *testing.T) {
+	for _, players := range [][]string{{"a", "b"}, {"a", "b", "c"}} {
+		g := newTestGame(t, 5, players...)
+		g.Rake = RakeRules{Percent: 5, Cap: 30}
+		if err := g.StartNewHand(); err != nil {
+			t.Fatal(err)
+		}
+		for !g.HandComplete {
+			act(t, g, BotAction{Action: AllIn})
+		}
+
+		history := g.History[len(g.History)-1]
+		if history.Rake != 30 {
+			t.Errorf("%d players: rake = %d, want 30", len(players), history.Rake)
+		}
+		net, evNet := 0, 0.0
+		for _, r := range history.Results {
+			net += r.Net
+			evNet += r.EVNet
+		}
+		if net != -history.Rake || math.Abs(evNet+float64(history.Rake)) > 1e-6 {
+			t.Errorf("%d players: results sum to %d and EV to %.2f, want both -%d", len(players), net, evNet, history.Rake)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Replayer errors
+var (
+	ErrHandNotFound   = errors.New("hand not found")
+	ErrHandNotOver    = errors.New("hand is not over")
+	ErrStepOutOfRange = errors.New("replay step out of range")
+)
+
+// ReplayView chooses whose hole cards a replay shows. Cards shown down at
+// showdown are always visible.
+type ReplayView struct {
+	HeroID string // This player's cards are shown
+	Reveal bool   // Show every player's cards
+}
+
+// ReplayStep is the table as it was after one event of a hand
+type ReplayStep struct {
+	Index int        `json:"index"`
+	Total int        `json:"total"`
+	Event Event      `json:"event"`
+	State *GameState `json:"state"`
+}
+
+// HandReplayer steps forwards and backwards through a completed hand
+type HandReplayer struct {
+	HandNumber int
+	frames     []replayFrame
+	position   int
+}
+
+// replayFrame is a snapshot of the game after an event
+type replayFrame struct {
+	event     Event
+	state     *GameState
+	holeCards map[string][]Card
+	showdown  bool
+}
+
+// NewHandReplayer folds a game's events up to the end of the given hand and
+// records the table after each of that hand's events. The shuffle is not a
+// step of its own since nothing visible changes.
+func NewHandReplayer(events []Event, handNumber int) (*HandReplayer, error) {
+	g := &PokerGame{
+		RNG:     NewCryptoRNG(),
+		Players: make([]*PokerPlayer, 0),
+	}
+	r := &HandReplayer{HandNumber: handNumber}
+
+	showdown, ended := false, false
+	for _, e := range events {
+		if e.HandNumber > handNumber && e.Type != EventPlayerJoined {
+			break
+		}
+		if err := g.apply(e); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", e.Sequence, e.Type, err)
+		}
+		if e.HandNumber != handNumber || e.Type == EventPlayerJoined || e.Type == EventDeckShuffled {
+			continue
+		}
+
+		if e.Type == EventStreetDealt && e.Street == Showdown.String() {
+			showdown = true
+		}
+		state := g.GetState()
+		state.LastEventSeq = e.Sequence
+		holeCards := make(map[string][]Card, len(g.Players))
+		for _, p := range g.Players {
+			if len(p.HoleCards) > 0 {
+				holeCards[p.ID] = p.HoleCards
+			}
+		}
+		r.frames = append(r.frames, replayFrame{event: e, state: state, holeCards: holeCards, showdown: showdown})
+
+		if e.Type == EventHandEnded {
+			ended = true
+			break
+		}
+	}
+
+	if len(r.frames) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrHandNotFound, handNumber)
+	}
+	if !ended {
+		return nil, fmt.Errorf("%w: %d", ErrHandNotOver, handNumber)
+	}
+	return r, nil
+}
+
+// HandLog cuts a completed hand out of a game's event log as a log of its
+// own: a playerJoined for each seated player, with the stack they started
+// the hand with, then the hand's events, numbered from 1. It replays and
+// exports to PokerStars without the rest of the game's log.
+func HandLog(events []Event, handNumber int) ([]Event, error) {
+	g := &PokerGame{Players: make([]*PokerPlayer, 0)}
+	var log []Event
+	for _, e := range events {
+		if e.Type == EventHandStarted && e.HandNumber == handNumber {
+			for _, p := range g.Players {
+				log = append(log, Event{
+					Sequence:   len(log) + 1,
+					Type:       EventPlayerJoined,
+					Time:       e.Time,
+					HandNumber: handNumber - 1,
+					PlayerID:   p.ID,
+					Name:       p.Name,
+					Seat:       p.SeatPosition,
+					Amount:     p.Chips,
+				})
+			}
+		}
+		if len(log) > 0 {
+			e.Sequence = len(log) + 1
+			log = append(log, e)
+			if e.Type == EventHandEnded {
+				return log, nil
+			}
+			continue
+		}
+
+		if err := g.apply(e); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", e.Sequence, e.Type, err)
+		}
+	}
+
+	if len(log) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrHandNotFound, handNumber)
+	}
+	return nil, fmt.Errorf("%w: %d", ErrHandNotOver, handNumber)
+}
+
+// NewHandReplayer replays an imported hand through the engine and returns a
+// replayer for it
+func (h *ImportedHand) NewHandReplayer() (*HandReplayer, error) {
+	g, err := h.Replay()
+	if err != nil {
+		return nil, err
+	}
+	return NewHandReplayer(g.Events, g.HandNumber)
+}
+
+// Len returns the number of steps in the hand
+func (r *HandReplayer) Len() int {
+	return len(r.frames)
+}
+
+// Position returns the index of the current step
+func (r *HandReplayer) Position() int {
+	return r.position
+}
+
+// Step returns the table after step i without moving the replayer
+func (r *HandReplayer) Step(i int, view ReplayView) (ReplayStep, error) {
+	if i < 0 || i >= len(r.frames) {
+		return ReplayStep{}, fmt.Errorf("%w: %d of %d", ErrStepOutOfRange, i, len(r.frames))
+	}
+	f := r.frames[i]
+
+	state := *f.state
+	state.Players = make([]PlayerState, len(f.state.Players))
+	for j, p := range f.state.Players {
+		visible := view.Reveal || p.ID == view.HeroID || (f.showdown && !p.IsFolded)
+		if visible {
+			p.HoleCards = f.holeCards[p.ID]
+		}
+		state.Players[j] = p
+	}
+
+	event := f.event
+	redactEvent(&event, view.HeroID, view.Reveal)
+	return ReplayStep{Index: i, Total: len(r.frames), Event: event, State: &state}, nil
+}
+
+// Current returns the current step
+func (r *HandReplayer) Current(view ReplayView) ReplayStep {
+	step, _ := r.Step(r.position, view)
+	return step
+}
+
+// Next moves forward a step, returning false at the end of the hand
+func (r *HandReplayer) Next(view ReplayView) (ReplayStep, bool) {
+	if r.position+1 >= len(r.frames) {
+		return r.Current(view), false
+	}
+	r.position++
+	return r.Current(view), true
+}
+
+// Prev moves back a step, returning false at the start of the hand
+func (r *HandReplayer) Prev(view ReplayView) (ReplayStep, bool) {
+	if r.position == 0 {
+		return r.Current(view), false
+	}
+	r.position--
+	return r.Current(view), true
+}
+
+// Seek moves to step i
+func (r *HandReplayer) Seek(i int, view ReplayView) (ReplayStep, error) {
+	step, err := r.Step(i, view)
+	if err != nil {
+		return step, err
+	}
+	r.position = i
+	return step, nil
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// Seeking backwards and forwards through a hand shows the same tables as
+// replaying its events one at a time
+func TestHandReplayerSeek(t *testing.T) {
+	g := newTestGame(t, 3, "a", "b", "c")
+	playHands(t, g, 4, 3)
+	const hand = 3
+
+	var want []string
+	for i, e := range g.Events {
+		if e.HandNumber != hand || e.Type == EventPlayerJoined || e.Type == EventDeckShuffled {
+			continue
+		}
+		replayed, err := ReplayEvents(g.Events[:i+1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := replayed.GetState()
+		state.LastEventSeq = e.Sequence
+		for j, p := range replayed.Players {
+			state.Players[j].HoleCards = p.HoleCards
+		}
+		want = append(want, mustJSON(t, ReplayStep{Index: len(want), Event: e, State: state}))
+		if e.Type == EventHandEnded {
+			break
+		}
+	}
+
+	r, err := NewHandReplayer(g.Events, hand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != len(want) {
+		t.Fatalf("%d steps, want %d", r.Len(), len(want))
+	}
+	view := ReplayView{Reveal: true}
+	check := func(how string, step ReplayStep) {
+		t.Helper()
+		if step.Index != r.Position() {
+			t.Fatalf("%s: step %d at position %d", how, step.Index, r.Position())
+		}
+		step.Total = 0
+		if got := mustJSON(t, step); got != want[step.Index] {
+			t.Errorf("%s to step %d:\n got %s\nwant %s", how, step.Index, got, want[step.Index])
+		}
+	}
+
+	// To the end, back to the start, then jumping about
+	check("start", r.Current(view))
+	for {
+		step, ok := r.Next(view)
+		if !ok {
+			break
+		}
+		check("next", step)
+	}
+	if r.Position() != r.Len()-1 {
+		t.Errorf("next stopped at %d of %d", r.Position(), r.Len())
+	}
+	for {
+		step, ok := r.Prev(view)
+		if !ok {
+			break
+		}
+		check("prev", step)
+	}
+	if r.Position() != 0 {
+		t.Errorf("prev stopped at %d", r.Position())
+	}
+	for _, i := range []int{r.Len() - 1, 2, r.Len() / 2, 0, r.Len() - 2, 1} {
+		step, err := r.Seek(i, view)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("seek", step)
+	}
+
+	for _, i := range []int{-1, r.Len()} {
+		if _, err := r.Seek(i, view); !errors.Is(err, ErrStepOutOfRange) {
+			t.Errorf("seek to %d: got %v, want %v", i, err, ErrStepOutOfRange)
+		}
+	}
+	if r.Position() != 1 {
+		t.Errorf("a failed seek moved to %d", r.Position())
+	}
+
+	if _, err := NewHandReplayer(g.Events, 99); !errors.Is(err, ErrHandNotFound) {
+		t.Errorf("got %v, want %v", err, ErrHandNotFound)
+	}
+}
+
+// Only the hero's cards show until the showdown
+func TestHandReplayerView(t *testing.T) {
+	g := newTestGame(t, 1, "a", "b")
+	deal, err := ParseScriptedDeal("AsAh KsKh | Qd9c5s 3h 8d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	act(t, g, BotAction{Action: Call}, BotAction{Action: Check})
+	for range 3 {
+		act(t, g, BotAction{Action: Check}, BotAction{Action: Check})
+	}
+
+	r, err := NewHandReplayer(g.Events, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shown := func(step ReplayStep) (ids string) {
+		for _, p := range step.State.Players {
+			if len(p.HoleCards) > 0 {
+				ids += p.ID
+			}
+		}
+		return ids
+	}
+	first := r.Current(ReplayView{HeroID: "a"})
+	if got := shown(first); got != "" {
+		t.Errorf("cards shown before the deal: %q", got)
+	}
+	dealt := 0
+	for i := range r.Len() {
+		if step, _ := r.Step(i, ReplayView{}); step.Event.Type == EventHoleCardsDealt {
+			dealt = i
+		}
+	}
+	step, err := r.Seek(dealt, ReplayView{HeroID: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := shown(step); got != "a" {
+		t.Errorf("after the deal, hero a sees %q's cards", got)
+	}
+	step, _ = r.Seek(r.Len()-1, ReplayView{HeroID: "a"})
+	if got := shown(step); got != "ab" {
+		t.Errorf("at showdown, hero a sees %q's cards", got)
+	}
+	if got := shown(r.Current(ReplayView{Reveal: true})); got != "ab" {
+		t.Errorf("revealed %q's cards", got)
+	}
+}
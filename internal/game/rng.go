@@ -0,0 +1,56 @@
+package game
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+)
+
+// RNG is the source of randomness used to shuffle decks
+type RNG interface {
+	// Intn returns a uniform random number in [0, n)
+	Intn(n int) int
+}
+
+// NewSeededRNG returns a deterministic RNG: the same seed always produces
+// the same shuffles, for tests and replays
+func NewSeededRNG(seed int64) RNG {
+	return rand.New(rand.NewSource(seed))
+}
+
+// CryptoRNG draws from the operating system's secure random source, with
+// rejection sampling so every value is equally likely
+type CryptoRNG struct{}
+
+// NewCryptoRNG returns an RNG backed by crypto/rand
+func NewCryptoRNG() RNG {
+	return CryptoRNG{}
+}
+
+// Intn returns a uniform random number in [0, n)
+func (CryptoRNG) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(uniformUint64(crandUint64, uint64(n)))
+}
+
+func crandUint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic("crypto/rand unavailable: " + err.Error())
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+// uniformUint64 maps random 64-bit values onto [0, n) without modulo
+// bias, redrawing values from the incomplete final block
+func uniformUint64(next func() uint64, n uint64) uint64 {
+	limit := ^uint64(0) - (^uint64(0)%n+1)%n
+	for {
+		v := next()
+		if v <= limit {
+			return v % n
+		}
+	}
+}
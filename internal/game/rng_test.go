@@ -0,0 +1,96 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSeededRNG(t *testing.T) {
+	draws := func(seed int64) []int {
+		rng := NewSeededRNG(seed)
+		var out []int
+		for n := 1; n <= 52; n++ {
+			out = append(out, rng.Intn(n))
+		}
+		return out
+	}
+	if !reflect.DeepEqual(draws(5), draws(5)) {
+		t.Error("the same seed drew different numbers")
+	}
+	if reflect.DeepEqual(draws(5), draws(6)) {
+		t.Error("different seeds drew the same numbers")
+	}
+
+	deck := func(seed int64) []Card {
+		d := NewDeckWithRNG(NewSeededRNG(seed))
+		d.Shuffle()
+		return d.cards
+	}
+	if !reflect.DeepEqual(deck(5), deck(5)) {
+		t.Error("the same seed shuffled different decks")
+	}
+}
+
+func TestCryptoRNG(t *testing.T) {
+	rng := NewCryptoRNG()
+	seen := make(map[int]bool)
+	for range 1000 {
+		v := rng.Intn(6)
+		if v < 0 || v >= 6 {
+			t.Fatalf("Intn(6) = %d", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 6 {
+		t.Errorf("1000 rolls gave only %v", seen)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Intn(0) did not panic")
+		}
+	}()
+	rng.Intn(0)
+}
+
+// uniformUint64 accepts a whole number of blocks of n values and redraws
+// the fewer than n left over at the top
+func TestUniformUint64(t *testing.T) {
+	tests := []struct {
+		n     uint64
+		limit uint64 // Largest value accepted
+	}{
+		{n: 1, limit: math.MaxUint64},
+		{n: 2, limit: math.MaxUint64},
+		{n: 3, limit: math.MaxUint64 - 1},
+		{n: 6, limit: math.MaxUint64 - 4},
+		{n: 52, limit: math.MaxUint64 - 16},
+		{n: 1 << 40, limit: math.MaxUint64},
+		// Only one block fits, so almost half the values are redrawn
+		{n: 1<<63 + 1, limit: 1 << 63},
+		{n: math.MaxUint64, limit: math.MaxUint64 - 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			if (tt.limit+1)%tt.n != 0 || math.MaxUint64-tt.limit >= tt.n {
+				t.Fatalf("bad test: limit %d for n=%d", tt.limit, tt.n)
+			}
+
+			var draws int
+			values := []uint64{tt.limit, 7}
+			if tt.limit < math.MaxUint64 {
+				values = []uint64{math.MaxUint64, tt.limit + 1, tt.limit, 7}
+			}
+			next := func() uint64 {
+				v := values[draws]
+				draws++
+				return v
+			}
+			if got := uniformUint64(next, tt.n); got != tt.limit%tt.n || draws != len(values)-1 {
+				t.Errorf("got %d after %d draws, want %d after %d", got, draws, tt.limit%tt.n, len(values)-1)
+			}
+		})
+	}
+}
@@ -144,6 +144,8 @@ var (
 	ErrTooManyPlayers    = NewGameError("too many players")
 	ErrPlayerNotFound    = NewGameError("player not found")
 	ErrInvalidAction     = NewGameError("invalid action")
+	ErrDeckEmpty         = NewGameError("no cards left in deck")
+	ErrNoDeck            = NewGameError("no deck, the game was rebuilt from its events")
 )
 
 // GameError represents a game-specific error
@@ -170,6 +172,23 @@ func CalculatePotOdds(callAmount, potSize int) float64 {
 	return float64(callAmount) / float64(totalPot) * 100
 }
 
+// CalculateOutsOdds estimates the chance of hitting one of outs as a
+// percentage, using the rule of 4 with two cards to come and 2 otherwise
+func CalculateOutsOdds(outs, cardsToCome int) float64 {
+	if outs <= 0 || cardsToCome <= 0 {
+		return 0
+	}
+	multiplier := 2
+	if cardsToCome >= 2 {
+		multiplier = 4
+	}
+	odds := float64(outs * multiplier)
+	if odds > 100 {
+		odds = 100
+	}
+	return odds
+}
+
 // IsValidBetSize checks if a bet size is valid
 func IsValidBetSize(betAmount, bigBlind, playerChips int) bool {
 	if betAmount < bigBlind {
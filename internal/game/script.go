@@ -0,0 +1,180 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ScriptedDeal fixes the cards for the next hand. Hole holds each seat's
+// cards by index in Players (nil for random cards) and Board the community
+// cards in the order they come. Anything not scripted is dealt at random.
+// A seat with no chips is not dealt in and must be left nil.
+type ScriptedDeal struct {
+	Hole  [][]Card
+	Board []Card
+}
+
+// ParseScriptedDeal parses hole cards per seat and the board, separated by
+// a "|": "AsKs QdQc | 2h3h4h 5c 6d". Use "-" for a seat with random cards.
+func ParseScriptedDeal(s string) (*ScriptedDeal, error) {
+	holePart, boardPart, _ := strings.Cut(s, "|")
+	deal := &ScriptedDeal{}
+
+	for _, group := range strings.Fields(holePart) {
+		if group == "-" {
+			deal.Hole = append(deal.Hole, nil)
+			continue
+		}
+		cards, err := ParseCards(group)
+		if err != nil {
+			return nil, err
+		}
+		if len(cards) != 2 {
+			return nil, fmt.Errorf("seat %d: need 2 hole cards, got %d", len(deal.Hole), len(cards))
+		}
+		deal.Hole = append(deal.Hole, cards)
+	}
+
+	board, err := ParseCards(boardPart)
+	if err != nil {
+		return nil, err
+	}
+	if len(board) > 5 {
+		return nil, errors.New("board has more than 5 cards")
+	}
+	deal.Board = board
+
+	return deal, deal.validate()
+}
+
+func (d *ScriptedDeal) validate() error {
+	seen := make(map[Card]bool)
+	check := func(cards []Card) error {
+		for _, c := range cards {
+			if seen[c] {
+				return fmt.Errorf("card %s scripted twice", c.ShortString())
+			}
+			seen[c] = true
+		}
+		return nil
+	}
+
+	for _, h := range d.Hole {
+		if err := check(h); err != nil {
+			return err
+		}
+	}
+	return check(d.Board)
+}
+
+// ScriptNextHand deals the next hand from a script instead of shuffling
+func (g *PokerGame) ScriptNextHand(deal *ScriptedDeal) error {
+	if err := deal.validate(); err != nil {
+		return err
+	}
+	if len(deal.Hole) > len(g.Players) {
+		return fmt.Errorf("script has %d seats, table has %d players", len(deal.Hole), len(g.Players))
+	}
+	if err := g.checkScriptSeats(deal); err != nil {
+		return err
+	}
+	g.nextScript = deal
+	g.nextStackedDeck = nil
+	return nil
+}
+
+// checkScriptSeats rejects hole cards scripted for a seat that has no chips
+// and so would not be dealt in
+func (g *PokerGame) checkScriptSeats(deal *ScriptedDeal) error {
+	for i, h := range deal.Hole {
+		if h != nil && i < len(g.Players) && g.Players[i].Chips == 0 {
+			return fmt.Errorf("seat %d (%s) has no chips to be dealt %s%s", i, g.Players[i].ID, h[0].ShortString(), h[1].ShortString())
+		}
+	}
+	return nil
+}
+
+// StackNextHand deals the next hand from a fixed deck order, top card
+// first, including the burn cards before each street
+func (g *PokerGame) StackNextHand(order []Card) error {
+	if _, err := NewStackedDeck(order); err != nil {
+		return err
+	}
+	g.nextStackedDeck = append([]Card{}, order...)
+	g.nextScript = nil
+	return nil
+}
+
+// scriptedDeck builds the deck for a pending script or stacked order,
+// returning nil when the next hand should be shuffled normally
+func (g *PokerGame) scriptedDeck() (*Deck, error) {
+	if g.nextStackedDeck != nil {
+		order := g.nextStackedDeck
+		g.nextStackedDeck = nil
+		return NewStackedDeck(order)
+	}
+	if g.nextScript == nil {
+		return nil, nil
+	}
+	script := g.nextScript
+	g.nextScript = nil
+	// Chips may have changed since the script was set
+	if err := g.checkScriptSeats(script); err != nil {
+		return nil, err
+	}
+
+	// Lay out the deck in the order dealHoleCards and the streets draw it,
+	// leaving unscripted positions empty. The deck is built before the hand
+	// starts, so the players dealt in are those with chips.
+	var slots []*Card
+	for round := 0; round < 2; round++ {
+		for i, p := range g.Players {
+			if p.Chips == 0 {
+				continue
+			}
+			var card *Card
+			if i < len(script.Hole) && script.Hole[i] != nil {
+				card = &script.Hole[i][round]
+			}
+			slots = append(slots, card)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		// A burn card before the flop, turn and river
+		if i == 0 || i == 3 || i == 4 {
+			slots = append(slots, nil)
+		}
+
+		var card *Card
+		if i < len(script.Board) {
+			card = &script.Board[i]
+		}
+		slots = append(slots, card)
+	}
+
+	// Fill the gaps from the shuffled remainder
+	var known []Card
+	for _, c := range slots {
+		if c != nil {
+			known = append(known, *c)
+		}
+	}
+	rest := NewDeckWithRNG(g.RNG)
+	rest.cards = remainingCards(known)
+	rest.Shuffle()
+
+	order := make([]Card, len(slots))
+	for i, c := range slots {
+		if c != nil {
+			order[i] = *c
+			continue
+		}
+		card, err := rest.Draw()
+		if err != nil {
+			return nil, err
+		}
+		order[i] = card
+	}
+	return NewStackedDeck(order)
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseScriptedDeal(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr bool
+	}{
+		{name: "hole cards and board", script: "AsAh - KsKh | Qd9c5s 3h 8d"},
+		{name: "hole cards only", script: "AsAh KsKh"},
+		{name: "three hole cards", script: "AsAhAd KsKh", wantErr: true},
+		{name: "card scripted twice", script: "AsAh KsAs", wantErr: true},
+		{name: "board card in a hand", script: "AsAh KsKh | Ah9c5s", wantErr: true},
+		{name: "six board cards", script: "AsAh KsKh | Qd9c5s3h8d2c", wantErr: true},
+		{name: "bad card", script: "AsAx KsKh", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScriptedDeal(tt.script)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// A scripted deal lands with the seats it names and the board in order,
+// skipping a seat with no chips
+func TestScriptNextHand(t *testing.T) {
+	g := newTestGame(t, 1, "a", "b", "c", "d")
+	g.Players[2].Chips = 0
+	deal, err := ParseScriptedDeal("AsAh - - 7c2d | Qd9c5s 3h 8d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []string{"AsAh", "", "", "7c2d"} {
+		got := g.Players[i].HoleCards
+		switch {
+		case i == 2:
+			if len(got) != 0 {
+				t.Errorf("seat 2 has no chips but was dealt %v", got)
+			}
+		case want == "":
+			if len(got) != 2 {
+				t.Errorf("seat %d was dealt %v", i, got)
+			}
+		case !reflect.DeepEqual(got, mustCards(t, want)):
+			t.Errorf("seat %d was dealt %v, want %s", i, got, want)
+		}
+	}
+
+	act(t, g, BotAction{Action: Call}, BotAction{Action: Call}, BotAction{Action: Check})
+	for range 3 {
+		act(t, g, BotAction{Action: Check}, BotAction{Action: Check}, BotAction{Action: Check})
+	}
+	if want := mustCards(t, "Qd9c5s3h8d"); !reflect.DeepEqual(g.CommunityCards, want) {
+		t.Errorf("board = %v, want %v", g.CommunityCards, want)
+	}
+}
+
+func TestScriptNextHandNoChips(t *testing.T) {
+	deal, err := ParseScriptedDeal("AsAh KsKh 7c2d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := newTestGame(t, 1, "a", "b", "c")
+	g.Players[1].Chips = 0
+	if err := g.ScriptNextHand(deal); err == nil {
+		t.Error("scripted hole cards for a seat with no chips")
+	}
+
+	// A seat that busts after the script is set fails the deal instead of
+	// giving its cards to the next seat
+	g = newTestGame(t, 1, "a", "b", "c")
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	g.Players[1].Chips = 0
+	if err := g.StartNewHand(); err == nil {
+		t.Error("dealt a script for a seat with no chips")
+	}
+	if g.HandNumber != 0 {
+		t.Errorf("hand %d started", g.HandNumber)
+	}
+}
@@ -0,0 +1,219 @@
+package game
+
+import (
+	"encoding/csv"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Transfer is a payment from one player to another to settle up. House
+// and prize pool balances appear as the accounts' names.
+type Transfer struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount int    `json:"amount"`
+}
+
+// SettleUpReport is a table's results and the payments that settle them
+type SettleUpReport struct {
+	Table       string             `json:"table"`
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Hands       int                `json:"hands"`
+	Players     []PlayerSettlement `json:"players"`
+	House       int                `json:"house"` // Rake and fees
+	Transfers   []Transfer         `json:"transfers"`
+}
+
+// SettleUp reports each player's result and the fewest transfers that
+// settle them. It can only be run between hands.
+func (l *Ledger) SettleUp() (*SettleUpReport, error) {
+	if l.replica.handInProgress() {
+		return nil, ErrGameInProgress
+	}
+
+	report := &SettleUpReport{
+		Table:       l.Table,
+		GeneratedAt: time.Now().UTC(),
+		Hands:       l.replica.HandNumber,
+		Players:     l.Settlement(),
+		House:       l.balances[HouseAccount],
+	}
+
+	balances := make(map[string]int)
+	for _, s := range report.Players {
+		balances[s.PlayerID] = s.Net
+	}
+	// What the house and an unpaid prize pool hold is owed to them
+	balances[HouseAccount] = l.balances[HouseAccount]
+	balances[PrizePoolAccount] = l.balances[PrizePoolAccount]
+	report.Transfers = SettleTransfers(balances)
+	return report, nil
+}
+
+// maxExactSettle is the most debtors and creditors settled exactly; more
+// are settled greedily, which may take a few more transfers
+const maxExactSettle = 16
+
+// SettleTransfers returns the fewest transfers that bring every balance to
+// zero, given balances that sum to zero: positive for money owed to the
+// account and negative for money it owes. A group of n accounts whose
+// balances sum to zero settles in n-1 transfers, so the fewest transfers
+// come from splitting the accounts into as many such groups as possible.
+func SettleTransfers(balances map[string]int) []Transfer {
+	var ids []string
+	for id, b := range balances {
+		if b != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > maxExactSettle {
+		return settleGreedily(ids, balances)
+	}
+
+	// best[mask] is the most zero-sum groups the accounts in mask split into
+	n := len(ids)
+	full := 1<<n - 1
+	sum := make([]int, 1<<n)
+	best := make([]int, 1<<n)
+	for mask := 1; mask <= full; mask++ {
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				sum[mask] = sum[mask&^(1<<i)] + balances[ids[i]]
+				best[mask] = max(best[mask], best[mask&^(1<<i)])
+			}
+		}
+		if sum[mask] == 0 {
+			best[mask]++
+		}
+	}
+	if sum[full] != 0 {
+		return settleGreedily(ids, balances)
+	}
+
+	// Take out the group holding the lowest account, one that cannot be
+	// split further and leaves the rest splitting into the remaining groups
+	var transfers []Transfer
+	for mask := full; mask != 0; {
+		low := mask & -mask
+		for sub := mask; sub > 0; sub = (sub - 1) & mask {
+			if sub&low == 0 || sum[sub] != 0 || best[sub] != 1 || best[mask&^sub] != best[mask]-1 {
+				continue
+			}
+			var group []string
+			for i := 0; i < n; i++ {
+				if sub&(1<<i) != 0 {
+					group = append(group, ids[i])
+				}
+			}
+			transfers = append(transfers, settleGreedily(group, balances)...)
+			mask &^= sub
+			break
+		}
+	}
+	return transfers
+}
+
+// settleGreedily pays the largest debt to the largest credit until every
+// balance is settled
+func settleGreedily(ids []string, balances map[string]int) []Transfer {
+	remaining := make(map[string]int, len(ids))
+	for _, id := range ids {
+		remaining[id] = balances[id]
+	}
+
+	var transfers []Transfer
+	for {
+		debtor, creditor := "", ""
+		for _, id := range ids {
+			b := remaining[id]
+			if b < 0 && (debtor == "" || b < remaining[debtor]) {
+				debtor = id
+			}
+			if b > 0 && (creditor == "" || b > remaining[creditor]) {
+				creditor = id
+			}
+		}
+		if debtor == "" || creditor == "" {
+			return transfers
+		}
+		amount := min(-remaining[debtor], remaining[creditor])
+		transfers = append(transfers, Transfer{From: debtor, To: creditor, Amount: amount})
+		remaining[debtor] += amount
+		remaining[creditor] -= amount
+	}
+}
+
+// WriteCSV writes each player's result followed by the transfers
+func (r *SettleUpReport) WriteCSV(w io.Writer) error {
+	records := [][]string{{"player", "name", "buy-in", "rebuys", "cashed out", "stack", "net"}}
+	for _, p := range r.Players {
+		records = append(records, []string{
+			p.PlayerID,
+			p.Name,
+			strconv.Itoa(p.BuyIn),
+			strconv.Itoa(p.Rebuys),
+			strconv.Itoa(p.CashedOut),
+			strconv.Itoa(p.Stack),
+			strconv.Itoa(p.Net),
+		})
+	}
+	records = append(records, nil, []string{"from", "to", "amount"})
+	for _, t := range r.Transfers {
+		records = append(records, []string{t.From, t.To, strconv.Itoa(t.Amount)})
+	}
+	return csv.NewWriter(w).WriteAll(records)
+}
+
+// WriteHTML writes the report as a printable page
+func (r *SettleUpReport) WriteHTML(w io.Writer) error {
+	names := make(map[string]string)
+	for _, p := range r.Players {
+		names[p.PlayerID] = p.Name
+	}
+	return settleUpTemplate.Execute(w, struct {
+		*SettleUpReport
+		Names map[string]string
+	}{r, names})
+}
+
+var settleUpTemplate = template.Must(template.New("settle").Funcs(template.FuncMap{
+	"name": func(names map[string]string, id string) string {
+		if name := names[id]; name != "" {
+			return name
+		}
+		return id
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Settle up: {{.Table}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #999; padding: 0.3em 0.8em; }
+td.num { text-align: right; }
+.loss { color: #b00; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Settle up: {{.Table}}</h1>
+<p>{{.Hands}} hands, {{.GeneratedAt.Format "2 Jan 2006 15:04 MST"}}{{if .House}}, {{.House}} to the house{{end}}</p>
+<table>
+<tr><th>Player</th><th>Buy-in</th><th>Rebuys</th><th>Cashed out</th><th>Stack</th><th>Net</th></tr>
+{{range .Players}}<tr><td>{{name $.Names .PlayerID}}</td><td class="num">{{.BuyIn}}</td><td class="num">{{.Rebuys}}</td><td class="num">{{.CashedOut}}</td><td class="num">{{.Stack}}</td><td class="num{{if lt .Net 0}} loss{{end}}">{{.Net}}</td></tr>
+{{end}}</table>
+<h2>Transfers</h2>
+{{if .Transfers}}<table>
+<tr><th>From</th><th>To</th><th>Amount</th></tr>
+{{range .Transfers}}<tr><td>{{name $.Names .From}}</td><td>{{name $.Names .To}}</td><td class="num">{{.Amount}}</td></tr>
+{{end}}</table>
+{{else}}<p>Everyone is square.</p>
+{{end}}</body>
+</html>
+`))
@@ -0,0 +1,86 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// checkSettles checks transfers bring every balance to zero, each paying a
+// positive amount from an account that owes to one that is owed
+func checkSettles(t *testing.T, balances map[string]int, transfers []Transfer) {
+	t.Helper()
+	remaining := make(map[string]int)
+	for id, b := range balances {
+		remaining[id] = b
+	}
+	for _, tr := range transfers {
+		if tr.Amount <= 0 || balances[tr.From] >= 0 || balances[tr.To] <= 0 {
+			t.Errorf("transfer %+v with balances %v", tr, balances)
+		}
+		remaining[tr.From] += tr.Amount
+		remaining[tr.To] -= tr.Amount
+	}
+	for id, b := range remaining {
+		if b != 0 {
+			t.Errorf("%s left with %d after %v", id, b, transfers)
+		}
+	}
+}
+
+func TestSettleTransfers(t *testing.T) {
+	tests := []struct {
+		name     string
+		balances map[string]int
+		want     int // Transfers
+	}{
+		{"nobody", map[string]int{}, 0},
+		{"all square", map[string]int{"a": 0, "b": 0}, 0},
+		{"one debt", map[string]int{"a": 10, "b": -10}, 1},
+		{"zero balances ignored", map[string]int{"a": 10, "b": -10, "c": 0}, 1},
+		{"one winner", map[string]int{"a": 30, "b": -10, "c": -20}, 2},
+		{"two matching pairs", map[string]int{"a": 5, "b": -5, "c": 7, "d": -7}, 2},
+		{"house", map[string]int{"a": 95, "b": -100, HouseAccount: 5}, 2},
+		// 4 and -4 settle on their own, leaving four accounts for three
+		// transfers
+		{"pair hidden among others", map[string]int{"a": 10, "b": 4, "c": 3, "d": -7, "e": -6, "f": -4}, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SettleTransfers(tt.balances)
+			if len(got) != tt.want {
+				t.Errorf("%d transfers %v, want %d", len(got), got, tt.want)
+			}
+			checkSettles(t, tt.balances, got)
+		})
+	}
+}
+
+func TestSettleTransfersBeatsGreedy(t *testing.T) {
+	balances := map[string]int{"a": 10, "b": 4, "c": 3, "d": -7, "e": -6, "f": -4}
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	greedy := settleGreedily(ids, balances)
+	checkSettles(t, balances, greedy)
+	if exact := SettleTransfers(balances); len(exact) >= len(greedy) {
+		t.Errorf("exact %d transfers, greedy %d", len(exact), len(greedy))
+	}
+}
+
+func TestSettleTransfersManyAccounts(t *testing.T) {
+	// Past maxExactSettle accounts are settled greedily, which still
+	// settles them all, ten winners and ten losers
+	balances := make(map[string]int)
+	for i := range 10 {
+		balances[fmt.Sprintf("win%d", i)] = 10 * (i + 1)
+		balances[fmt.Sprintf("lose%d", i)] = -5 * (2*i + 1)
+	}
+	balances["lose9"] -= 50
+	if len(balances) <= maxExactSettle {
+		t.Fatalf("only %d accounts", len(balances))
+	}
+
+	got := SettleTransfers(balances)
+	checkSettles(t, balances, got)
+	if nonZero := len(balances); len(got) > nonZero-1 {
+		t.Errorf("%d transfers for %d accounts", len(got), nonZero)
+	}
+}
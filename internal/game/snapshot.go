@@ -0,0 +1,482 @@
+package game
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is the snapshot format written by Snapshot. Restore
+// rejects any other version.
+const SnapshotVersion = 3
+
+// snapshotMagic starts the binary encoding of a snapshot
+const snapshotMagic = "PKSNAP"
+
+// Snapshot errors
+var (
+	ErrSnapshotVersion = errors.New("unsupported snapshot version")
+	ErrSnapshotInvalid = errors.New("invalid snapshot")
+)
+
+// Snapshot is the complete state of a PokerGame, including the deck order
+// and the unrevealed provably fair seeds, so it must be stored as securely
+// as the live game. The RNG, OnEvent hook and the stats tracker's OnHand
+// hook are not part of it.
+type Snapshot struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"takenAt"`
+
+	SmallBlind   int       `json:"smallBlind"`
+	BigBlind     int       `json:"bigBlind"`
+	HiLo         bool      `json:"hiLo"`
+	Rake         RakeRules `json:"rake"`
+	ProvablyFair bool      `json:"provablyFair"`
+
+	FairShuffle       *FairShuffleSnapshot `json:"fairShuffle,omitempty"`
+	NextFairShuffle   *FairShuffleSnapshot `json:"nextFairShuffle,omitempty"`
+	ShuffleCommitment string               `json:"shuffleCommitment,omitempty"`
+	NextScript        *ScriptedDeal        `json:"nextScript,omitempty"`
+	NextStackedDeck   []Card               `json:"nextStackedDeck,omitempty"`
+
+	Players      []PlayerSnapshot `json:"players"`
+	DealerIndex  int              `json:"dealerIndex"`
+	CurrentIndex int              `json:"currentIndex"`
+
+	Deck           *DeckSnapshot `json:"deck,omitempty"`
+	CommunityCards []Card        `json:"communityCards"`
+	Pot            int           `json:"pot"`
+	SidePots       []SidePot     `json:"sidePots"`
+	CurrentBet     int           `json:"currentBet"`
+	MinRaise       int           `json:"minRaise"`
+
+	BettingRound     BettingRound `json:"bettingRound"`
+	LastAggressor    string       `json:"lastAggressor"`
+	NumActivePlayers int          `json:"numActivePlayers"`
+
+	HandNumber   int      `json:"handNumber"`
+	HandComplete bool     `json:"handComplete"`
+	Winners      []Winner `json:"winners"`
+	HandRake     int      `json:"handRake"`
+
+	AllInEquity []StreetEquity     `json:"allInEquity,omitempty"`
+	AllInEV     map[string]float64 `json:"allInEV,omitempty"`
+	RunningOut  bool               `json:"runningOut,omitempty"`
+	StepRunOut  bool               `json:"stepRunOut,omitempty"`
+
+	SkipAllInEquity bool           `json:"skipAllInEquity,omitempty"`
+	Stats           *StatsSnapshot `json:"stats,omitempty"`
+
+	History   []HandHistory `json:"history"`
+	Events    []Event       `json:"events"`
+	KeepHands int           `json:"keepHands,omitempty"`
+}
+
+// StatsSnapshot is a stats tracker's totals, by player ID, and the events
+// of the hand in progress, which it counts once the hand ends
+type StatsSnapshot struct {
+	Players []PlayerStats `json:"players"`
+	Hand    []Event       `json:"hand,omitempty"`
+}
+
+// PlayerSnapshot is a player's full state, hole cards included
+type PlayerSnapshot struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Chips          int    `json:"chips"`
+	HoleCards      []Card `json:"holeCards"`
+	CurrentBet     int    `json:"currentBet"`
+	TotalBetInHand int    `json:"totalBetInHand"`
+	HasActed       bool   `json:"hasActed"`
+	IsFolded       bool   `json:"isFolded"`
+	IsAllIn        bool   `json:"isAllIn"`
+	IsActive       bool   `json:"isActive"`
+	SeatPosition   int    `json:"seatPosition"`
+}
+
+// DeckSnapshot is the deck order, top card first, and how far it is dealt
+type DeckSnapshot struct {
+	Cards             []Card `json:"cards"`
+	Used              int    `json:"used"`
+	Stacked           bool   `json:"stacked"`
+	Burned            []Card `json:"burned"`
+	Discards          []Card `json:"discards"`
+	ReshuffleDiscards bool   `json:"reshuffleDiscards"`
+}
+
+// FairShuffleSnapshot is a provably fair shuffle with its secret server seed
+type FairShuffleSnapshot struct {
+	HandNumber  int          `json:"handNumber"`
+	Commitment  string       `json:"commitment"`
+	ClientSeeds []ClientSeed `json:"clientSeeds"`
+	ServerSeed  string       `json:"serverSeed"` // Hex
+	Locked      bool         `json:"locked"`
+}
+
+// Snapshot captures the game's full state. It shares no memory with the
+// game, so play can carry on while it is written out.
+func (g *PokerGame) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Version: SnapshotVersion,
+		TakenAt: time.Now().UTC(),
+
+		SmallBlind:   g.SmallBlind,
+		BigBlind:     g.BigBlind,
+		HiLo:         g.HiLo,
+		Rake:         cloneRakeRules(g.Rake),
+		ProvablyFair: g.ProvablyFair,
+
+		FairShuffle:       snapshotFairShuffle(g.fairShuffle),
+		NextFairShuffle:   snapshotFairShuffle(g.nextFairShuffle),
+		ShuffleCommitment: g.shuffleCommitment,
+		NextStackedDeck:   cloneCards(g.nextStackedDeck),
+
+		DealerIndex:  g.DealerIndex,
+		CurrentIndex: g.CurrentIndex,
+
+		CommunityCards: cloneCards(g.CommunityCards),
+		Pot:            g.Pot,
+		CurrentBet:     g.CurrentBet,
+		MinRaise:       g.MinRaise,
+
+		BettingRound:     g.BettingRound,
+		LastAggressor:    g.LastAggressor,
+		NumActivePlayers: g.NumActivePlayers,
+
+		HandNumber:   g.HandNumber,
+		HandComplete: g.HandComplete,
+		HandRake:     g.HandRake,
+
+		RunningOut: g.runningOut,
+		StepRunOut: g.StepRunOut,
+
+		SkipAllInEquity: g.SkipAllInEquity,
+		Stats:           snapshotStats(g.Stats),
+	}
+
+	if g.nextScript != nil {
+		script := &ScriptedDeal{Board: cloneCards(g.nextScript.Board)}
+		for _, hole := range g.nextScript.Hole {
+			script.Hole = append(script.Hole, cloneCards(hole))
+		}
+		s.NextScript = script
+	}
+
+	for _, p := range g.Players {
+		s.Players = append(s.Players, PlayerSnapshot{
+			ID:             p.ID,
+			Name:           p.Name,
+			Chips:          p.Chips,
+			HoleCards:      cloneCards(p.HoleCards),
+			CurrentBet:     p.CurrentBet,
+			TotalBetInHand: p.TotalBetInHand,
+			HasActed:       p.HasActed,
+			IsFolded:       p.IsFolded,
+			IsAllIn:        p.IsAllIn,
+			IsActive:       p.IsActive,
+			SeatPosition:   p.SeatPosition,
+		})
+	}
+
+	if d := g.Deck; d != nil {
+		s.Deck = &DeckSnapshot{
+			Cards:             cloneCards(d.cards),
+			Used:              d.used,
+			Stacked:           d.stacked,
+			Burned:            cloneCards(d.burned),
+			Discards:          cloneCards(d.discards),
+			ReshuffleDiscards: d.reshuffleDiscards,
+		}
+	}
+
+	for _, pot := range g.SidePots {
+		s.SidePots = append(s.SidePots, SidePot{
+			Amount:          pot.Amount,
+			EligiblePlayers: append([]string{}, pot.EligiblePlayers...),
+		})
+	}
+	for _, w := range g.Winners {
+		s.Winners = append(s.Winners, cloneWinner(w))
+	}
+	for _, e := range g.AllInEquity {
+		s.AllInEquity = append(s.AllInEquity, cloneStreetEquity(e))
+	}
+	if g.allInEV != nil {
+		s.AllInEV = make(map[string]float64, len(g.allInEV))
+		for id, ev := range g.allInEV {
+			s.AllInEV[id] = ev
+		}
+	}
+
+	// Completed hands and events are never modified, so their slices of
+	// cards can be shared
+	s.History = append([]HandHistory{}, g.History...)
+	s.Events = append([]Event{}, g.Events...)
+	s.KeepHands = g.KeepHands
+	return s
+}
+
+// Restore rebuilds a game from a snapshot, ready to carry on mid-hand
+// exactly where the snapshot was taken. New hands are shuffled with rng,
+// or crypto/rand when it is nil.
+func Restore(s *Snapshot, rng RNG) (*PokerGame, error) {
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, s.Version)
+	}
+	if rng == nil {
+		rng = NewCryptoRNG()
+	}
+
+	g := &PokerGame{
+		SmallBlind:   s.SmallBlind,
+		BigBlind:     s.BigBlind,
+		HiLo:         s.HiLo,
+		Rake:         cloneRakeRules(s.Rake),
+		RNG:          rng,
+		ProvablyFair: s.ProvablyFair,
+
+		shuffleCommitment: s.ShuffleCommitment,
+		nextStackedDeck:   cloneCards(s.NextStackedDeck),
+
+		Players:      make([]*PokerPlayer, 0, len(s.Players)),
+		DealerIndex:  s.DealerIndex,
+		CurrentIndex: s.CurrentIndex,
+
+		CommunityCards: cloneCards(s.CommunityCards),
+		Pot:            s.Pot,
+		CurrentBet:     s.CurrentBet,
+		MinRaise:       s.MinRaise,
+
+		BettingRound:     s.BettingRound,
+		LastAggressor:    s.LastAggressor,
+		NumActivePlayers: s.NumActivePlayers,
+
+		HandNumber:   s.HandNumber,
+		HandComplete: s.HandComplete,
+		HandRake:     s.HandRake,
+
+		runningOut: s.RunningOut,
+		StepRunOut: s.StepRunOut,
+
+		SkipAllInEquity: s.SkipAllInEquity,
+		Stats:           restoreStats(s.Stats),
+
+		History:   append([]HandHistory{}, s.History...),
+		Events:    append([]Event{}, s.Events...),
+		KeepHands: s.KeepHands,
+	}
+
+	var err error
+	if g.fairShuffle, err = restoreFairShuffle(s.FairShuffle); err != nil {
+		return nil, err
+	}
+	if g.nextFairShuffle, err = restoreFairShuffle(s.NextFairShuffle); err != nil {
+		return nil, err
+	}
+
+	if s.NextScript != nil {
+		script := &ScriptedDeal{Board: cloneCards(s.NextScript.Board)}
+		for _, hole := range s.NextScript.Hole {
+			script.Hole = append(script.Hole, cloneCards(hole))
+		}
+		g.nextScript = script
+	}
+
+	for _, p := range s.Players {
+		g.Players = append(g.Players, &PokerPlayer{
+			ID:             p.ID,
+			Name:           p.Name,
+			Chips:          p.Chips,
+			HoleCards:      cloneCards(p.HoleCards),
+			CurrentBet:     p.CurrentBet,
+			TotalBetInHand: p.TotalBetInHand,
+			HasActed:       p.HasActed,
+			IsFolded:       p.IsFolded,
+			IsAllIn:        p.IsAllIn,
+			IsActive:       p.IsActive,
+			SeatPosition:   p.SeatPosition,
+		})
+	}
+	if len(g.Players) > 0 && (g.CurrentIndex < 0 || g.CurrentIndex >= len(g.Players) ||
+		g.DealerIndex < 0 || g.DealerIndex >= len(g.Players)) {
+		return nil, fmt.Errorf("%w: player index out of range", ErrSnapshotInvalid)
+	}
+
+	if s.Deck != nil {
+		if len(s.Deck.Cards) != 52 || s.Deck.Used < 0 || s.Deck.Used > 52 {
+			return nil, fmt.Errorf("%w: deck has %d cards with %d dealt", ErrSnapshotInvalid, len(s.Deck.Cards), s.Deck.Used)
+		}
+		deck, err := NewStackedDeck(s.Deck.Cards)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSnapshotInvalid, err)
+		}
+		deck.used = s.Deck.Used
+		deck.stacked = s.Deck.Stacked
+		deck.rng = rng
+		deck.burned = cloneCards(s.Deck.Burned)
+		deck.discards = cloneCards(s.Deck.Discards)
+		deck.reshuffleDiscards = s.Deck.ReshuffleDiscards
+		g.Deck = deck
+	}
+
+	for _, pot := range s.SidePots {
+		g.SidePots = append(g.SidePots, SidePot{
+			Amount:          pot.Amount,
+			EligiblePlayers: append([]string{}, pot.EligiblePlayers...),
+		})
+	}
+	for _, w := range s.Winners {
+		g.Winners = append(g.Winners, cloneWinner(w))
+	}
+	for _, e := range s.AllInEquity {
+		g.AllInEquity = append(g.AllInEquity, cloneStreetEquity(e))
+	}
+	if s.AllInEV != nil {
+		g.allInEV = make(map[string]float64, len(s.AllInEV))
+		for id, ev := range s.AllInEV {
+			g.allInEV[id] = ev
+		}
+	}
+	return g, nil
+}
+
+// MarshalBinary encodes the snapshot compactly: a magic string, the
+// version byte and the snapshot as a gob
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(snapshotMagic)
+	buf.WriteByte(byte(s.Version))
+	if err := gob.NewEncoder(&buf).Encode((*snapshotGob)(s)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a snapshot written by MarshalBinary
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(snapshotMagic)) || len(data) <= len(snapshotMagic) {
+		return fmt.Errorf("%w: not a binary snapshot", ErrSnapshotInvalid)
+	}
+	if version := int(data[len(snapshotMagic)]); version != SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrSnapshotVersion, version)
+	}
+	return gob.NewDecoder(bytes.NewReader(data[len(snapshotMagic)+1:])).Decode((*snapshotGob)(s))
+}
+
+// snapshotGob has Snapshot's fields without its methods, so gob encodes
+// the fields rather than calling MarshalBinary again
+type snapshotGob Snapshot
+
+// ParseSnapshot decodes a snapshot in either the JSON or binary encoding
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	s := &Snapshot{}
+	if bytes.HasPrefix(data, []byte(snapshotMagic)) {
+		if err := s.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotInvalid, err)
+	}
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotVersion, s.Version)
+	}
+	return s, nil
+}
+
+func snapshotFairShuffle(f *FairShuffle) *FairShuffleSnapshot {
+	if f == nil {
+		return nil
+	}
+	return &FairShuffleSnapshot{
+		HandNumber:  f.HandNumber,
+		Commitment:  f.Commitment,
+		ClientSeeds: append([]ClientSeed{}, f.ClientSeeds...),
+		ServerSeed:  hex.EncodeToString(f.serverSeed),
+		Locked:      f.locked,
+	}
+}
+
+func restoreFairShuffle(s *FairShuffleSnapshot) (*FairShuffle, error) {
+	if s == nil {
+		return nil, nil
+	}
+	seed, err := hex.DecodeString(s.ServerSeed)
+	if err != nil || len(seed) != ServerSeedSize {
+		return nil, fmt.Errorf("%w: bad server seed for hand %d", ErrSnapshotInvalid, s.HandNumber)
+	}
+	return &FairShuffle{
+		HandNumber:  s.HandNumber,
+		Commitment:  s.Commitment,
+		ClientSeeds: append([]ClientSeed{}, s.ClientSeeds...),
+		serverSeed:  seed,
+		locked:      s.Locked,
+	}, nil
+}
+
+func snapshotStats(t *StatsTracker) *StatsSnapshot {
+	if t == nil {
+		return nil
+	}
+	s := &StatsSnapshot{Hand: append([]Event{}, t.hand...)}
+	for _, p := range t.players {
+		s.Players = append(s.Players, p.clone())
+	}
+	sort.Slice(s.Players, func(i, j int) bool {
+		return s.Players[i].PlayerID < s.Players[j].PlayerID
+	})
+	return s
+}
+
+func restoreStats(s *StatsSnapshot) *StatsTracker {
+	if s == nil {
+		return nil
+	}
+	t := NewStatsTracker()
+	for _, p := range s.Players {
+		t.Load(p)
+	}
+	t.hand = append(t.hand, s.Hand...)
+	return t
+}
+
+func cloneCards(cards []Card) []Card {
+	if cards == nil {
+		return nil
+	}
+	return append([]Card{}, cards...)
+}
+
+func cloneWinner(w Winner) Winner {
+	w.BestHand = cloneCards(w.BestHand)
+	w.LowHand = cloneCards(w.LowHand)
+	return w
+}
+
+func cloneStreetEquity(e StreetEquity) StreetEquity {
+	e.Board = cloneCards(e.Board)
+	equities := make(map[string]float64, len(e.Equities))
+	for id, eq := range e.Equities {
+		equities[id] = eq
+	}
+	e.Equities = equities
+	return e
+}
+
+func cloneRakeRules(r RakeRules) RakeRules {
+	if r.CapByPlayers != nil {
+		caps := make(map[int]int, len(r.CapByPlayers))
+		for n, c := range r.CapByPlayers {
+			caps[n] = c
+		}
+		r.CapByPlayers = caps
+	}
+	return r
+}
@@ -0,0 +1,75 @@
+package game
+
+import "testing"
+
+func TestRestoreCarriesOnMidHand(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func(s *Snapshot) (*Snapshot, error)
+	}{
+		{"in memory", func(s *Snapshot) (*Snapshot, error) { return s, nil }},
+		{"binary", func(s *Snapshot) (*Snapshot, error) {
+			data, err := s.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			var out Snapshot
+			return &out, out.UnmarshalBinary(data)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, 8, "a", "b", "c")
+			g.Stats = NewStatsTracker()
+			g.SkipAllInEquity = true
+			playHands(t, g, 3, 8)
+			if err := g.StartNewHand(); err != nil {
+				t.Fatal(err)
+			}
+			act(t, g, BotAction{Action: Call})
+
+			s, err := tt.encode(g.Snapshot())
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored, err := Restore(s, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !restored.SkipAllInEquity {
+				t.Error("SkipAllInEquity not restored")
+			}
+			if restored.Stats == nil {
+				t.Fatal("stats not restored")
+			}
+
+			// Both games finish the hand the same way, counting it in their stats
+			for _, played := range []*PokerGame{g, restored} {
+				act(t, played, BotAction{Action: Call}, BotAction{Action: Check})
+				for !played.HandComplete {
+					act(t, played, BotAction{Action: Check})
+				}
+			}
+			if got, want := mustJSON(t, restored.GetState()), mustJSON(t, g.GetState()); got != want {
+				t.Errorf("state differs\n got %s\nwant %s", got, want)
+			}
+			for _, p := range g.Players {
+				got, want := restored.Stats.Player(p.ID), g.Stats.Player(p.ID)
+				if mustJSON(t, got) != mustJSON(t, want) {
+					t.Errorf("%s stats = %+v, want %+v", p.ID, got, want)
+				}
+				if want.Hands != 4 {
+					t.Errorf("%s played %d hands, want 4", p.ID, want.Hands)
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsOtherVersions(t *testing.T) {
+	s := newTestGame(t, 9, "a", "b").Snapshot()
+	s.Version = SnapshotVersion - 1
+	if _, err := Restore(s, nil); err == nil {
+		t.Error("old snapshot restored")
+	}
+}
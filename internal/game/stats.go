@@ -0,0 +1,377 @@
+package game
+
+// StatCounts are the counts HUD statistics are worked out from
+type StatCounts struct {
+	Hands          int `json:"hands"`          // Dealt in
+	VPIP           int `json:"vpip"`           // Hands with chips put in voluntarily before the flop
+	PFR            int `json:"pfr"`            // Hands raised before the flop
+	ThreeBetChance int `json:"threeBetChance"` // Hands facing a single raise before the flop
+	ThreeBet       int `json:"threeBet"`       // Hands re-raising that raise
+	Aggressive     int `json:"aggressive"`     // Bets and raises after the flop
+	Calls          int `json:"calls"`          // Calls after the flop
+	SawFlop        int `json:"sawFlop"`
+	Showdowns      int `json:"showdowns"`
+}
+
+// Add adds another set of counts to c
+func (c *StatCounts) Add(o StatCounts) {
+	c.Hands += o.Hands
+	c.VPIP += o.VPIP
+	c.PFR += o.PFR
+	c.ThreeBetChance += o.ThreeBetChance
+	c.ThreeBet += o.ThreeBet
+	c.Aggressive += o.Aggressive
+	c.Calls += o.Calls
+	c.SawFlop += o.SawFlop
+	c.Showdowns += o.Showdowns
+}
+
+// HUDStats are the statistics shown beside a seat. Percentages are 0-100.
+type HUDStats struct {
+	Hands    int      `json:"hands"`
+	VPIP     float64  `json:"vpip"`
+	PFR      float64  `json:"pfr"`
+	ThreeBet float64  `json:"threeBet"`
+	AF       *float64 `json:"af,omitempty"` // Bets and raises per call after the flop, nil with no calls
+	WTSD     float64  `json:"wtsd"`         // Went to showdown, of hands that saw the flop
+}
+
+// HUD works out the statistics from the counts
+func (c StatCounts) HUD() HUDStats {
+	h := HUDStats{
+		Hands:    c.Hands,
+		VPIP:     percent(c.VPIP, c.Hands),
+		PFR:      percent(c.PFR, c.Hands),
+		ThreeBet: percent(c.ThreeBet, c.ThreeBetChance),
+		WTSD:     percent(c.Showdowns, c.SawFlop),
+	}
+	if c.Calls > 0 {
+		af := float64(c.Aggressive) / float64(c.Calls)
+		h.AF = &af
+	}
+	return h
+}
+
+func percent(n, of int) float64 {
+	if of == 0 {
+		return 0
+	}
+	return 100 * float64(n) / float64(of)
+}
+
+// VersusCounts are a player's counts against one opponent: how they met
+// that opponent's raises and bets, and the showdowns the two went to
+type VersusCounts struct {
+	Hands          int `json:"hands"`          // Dealt in together
+	ThreeBetChance int `json:"threeBetChance"` // Hands facing the opponent's open raise
+	ThreeBet       int `json:"threeBet"`       // Hands re-raising it
+	CBetFaced      int `json:"cBetFaced"`      // Hands facing the opponent's continuation bet on the flop
+	FoldToCBet     int `json:"foldToCBet"`     // Hands folding to it
+	Showdowns      int `json:"showdowns"`      // Showdowns both went to
+	ShowdownsWon   int `json:"showdownsWon"`   // Those the player won chips at
+}
+
+// Add adds another set of counts to c
+func (c *VersusCounts) Add(o VersusCounts) {
+	c.Hands += o.Hands
+	c.ThreeBetChance += o.ThreeBetChance
+	c.ThreeBet += o.ThreeBet
+	c.CBetFaced += o.CBetFaced
+	c.FoldToCBet += o.FoldToCBet
+	c.Showdowns += o.Showdowns
+	c.ShowdownsWon += o.ShowdownsWon
+}
+
+// VersusStats are the statistics against one opponent. Percentages are
+// 0-100.
+type VersusStats struct {
+	Hands         int     `json:"hands"`
+	ThreeBet      float64 `json:"threeBet"`      // Of the opponent's open raises
+	FoldToCBet    float64 `json:"foldToCBet"`    // Of the opponent's continuation bets
+	Showdowns     int     `json:"showdowns"`     // Showdowns against the opponent
+	WonAtShowdown float64 `json:"wonAtShowdown"` // Of those showdowns
+}
+
+// Stats works out the statistics from the counts
+func (c VersusCounts) Stats() VersusStats {
+	return VersusStats{
+		Hands:         c.Hands,
+		ThreeBet:      percent(c.ThreeBet, c.ThreeBetChance),
+		FoldToCBet:    percent(c.FoldToCBet, c.CBetFaced),
+		Showdowns:     c.Showdowns,
+		WonAtShowdown: percent(c.ShowdownsWon, c.Showdowns),
+	}
+}
+
+// PlayerStats is a player's counts overall and against each opponent,
+// keyed by the opponent's ID
+type PlayerStats struct {
+	PlayerID string `json:"playerId"`
+	StatCounts
+	Versus map[string]VersusCounts `json:"versus,omitempty"`
+}
+
+// Add adds another set of a player's stats to s
+func (s *PlayerStats) Add(o PlayerStats) {
+	s.StatCounts.Add(o.StatCounts)
+	for id, c := range o.Versus {
+		if s.Versus == nil {
+			s.Versus = make(map[string]VersusCounts)
+		}
+		v := s.Versus[id]
+		v.Add(c)
+		s.Versus[id] = v
+	}
+}
+
+// clone returns a copy of s that shares no memory with it
+func (s PlayerStats) clone() PlayerStats {
+	c := PlayerStats{PlayerID: s.PlayerID}
+	c.Add(s)
+	return c
+}
+
+// HandStats counts one hand's statistics for each player dealt in, from
+// the hand's events
+func HandStats(events []Event) []PlayerStats {
+	var (
+		dealt     []string
+		counts    = make(map[string]*StatCounts)
+		versus    = make(map[[2]string]*VersusCounts) // By player and opponent
+		folded    = make(map[string]bool)
+		won       = make(map[string]bool)
+		bets      = make(map[string]int) // Each player's bet this street
+		current   int                    // Bet to match this street
+		raises    int                    // Raises this street, the big blind counting as none
+		raiser    string                 // Who made the last raise
+		aggressor string                 // Who made the last raise before the flop
+		cBettor   string                 // Who bet the flop after raising before it
+		faced     = make(map[string]bool)
+		street    = PreFlop
+		showdown  bool
+	)
+	// vs returns a player's counts against an opponent
+	vs := func(id, opponent string) *VersusCounts {
+		key := [2]string{id, opponent}
+		if versus[key] == nil {
+			versus[key] = &VersusCounts{}
+		}
+		return versus[key]
+	}
+	for _, e := range events {
+		switch e.Type {
+		case EventSmallBlindPosted, EventBigBlindPosted:
+			bets[e.PlayerID] += e.Amount
+			current = max(current, bets[e.PlayerID])
+
+		case EventHoleCardsDealt:
+			if counts[e.PlayerID] == nil {
+				dealt = append(dealt, e.PlayerID)
+				counts[e.PlayerID] = &StatCounts{Hands: 1}
+			}
+
+		case EventStreetDealt:
+			round, err := parseBettingRound(e.Street)
+			if err != nil {
+				continue
+			}
+			if street == PreFlop {
+				aggressor = raiser
+			}
+			street, current, raises, raiser = round, 0, 0, ""
+			clear(bets)
+			clear(faced)
+			if round == Flop {
+				for _, id := range dealt {
+					if !folded[id] {
+						counts[id].SawFlop = 1
+					}
+				}
+			}
+			if round == Showdown {
+				showdown = true
+			}
+
+		case EventPotAwarded:
+			if e.Amount > 0 {
+				won[e.PlayerID] = true
+			}
+
+		case EventActionTaken:
+			c := counts[e.PlayerID]
+			action, err := ParseActionType(e.Action)
+			if c == nil || err != nil {
+				continue
+			}
+			// Facing a single raise before the flop or the preflop
+			// raiser's flop bet, counted once a hand
+			facing := ""
+			if street == PreFlop && raises == 1 && c.ThreeBetChance == 0 {
+				c.ThreeBetChance = 1
+				facing = raiser
+				vs(e.PlayerID, raiser).ThreeBetChance = 1
+			}
+			if street == Flop && cBettor != "" && raises == 1 && !faced[e.PlayerID] {
+				faced[e.PlayerID] = true
+				facing = cBettor
+				vs(e.PlayerID, cBettor).CBetFaced = 1
+			}
+
+			// Actions are recorded as the engine took them: calls and
+			// all-ins by the chips added, bets and raises by the new bet
+			before := current
+			switch action {
+			case Fold:
+				folded[e.PlayerID] = true
+			case Call, AllIn:
+				bets[e.PlayerID] += e.Amount
+			case Bet, Raise:
+				bets[e.PlayerID] = e.Amount
+			}
+			current = max(current, bets[e.PlayerID])
+			raised := current > before
+			called := !raised && (action == Call || action == AllIn) && e.Amount > 0
+
+			if street == PreFlop {
+				if raised || called {
+					c.VPIP = 1
+				}
+				if raised {
+					c.PFR = 1
+					if raises == 1 && c.ThreeBetChance == 1 {
+						c.ThreeBet = 1
+						if facing != "" {
+							vs(e.PlayerID, facing).ThreeBet = 1
+						}
+					}
+				}
+			} else {
+				if street == Flop && raised && raises == 0 && e.PlayerID == aggressor {
+					cBettor = e.PlayerID
+				}
+				if street == Flop && action == Fold && facing != "" {
+					vs(e.PlayerID, facing).FoldToCBet = 1
+				}
+				if raised {
+					c.Aggressive++
+				}
+				if called {
+					c.Calls++
+				}
+			}
+			if raised {
+				raises++
+				raiser = e.PlayerID
+			}
+		}
+	}
+
+	if showdown {
+		for _, id := range dealt {
+			if !folded[id] {
+				counts[id].Showdowns = 1
+			}
+		}
+	}
+
+	stats := make([]PlayerStats, len(dealt))
+	for i, id := range dealt {
+		stats[i] = PlayerStats{PlayerID: id, StatCounts: *counts[id]}
+		for _, opponent := range dealt {
+			if opponent == id {
+				continue
+			}
+			v := vs(id, opponent)
+			v.Hands = 1
+			if showdown && !folded[id] && !folded[opponent] {
+				v.Showdowns = 1
+				if won[id] {
+					v.ShowdownsWon = 1
+				}
+			}
+			if stats[i].Versus == nil {
+				stats[i].Versus = make(map[string]VersusCounts)
+			}
+			stats[i].Versus[opponent] = *v
+		}
+	}
+	return stats
+}
+
+// StatsTracker keeps each player's statistics up to date as a game's hands
+// end. Set it as a game's Stats to show a HUD in GameState.
+type StatsTracker struct {
+	// OnHand is called with each hand's stats as it ends, so they can be
+	// added to stored totals
+	OnHand func(hand []PlayerStats)
+
+	players map[string]*PlayerStats
+	hand    []Event // The current hand's events
+}
+
+// NewStatsTracker returns a tracker with no stats
+func NewStatsTracker() *StatsTracker {
+	return &StatsTracker{players: make(map[string]*PlayerStats)}
+}
+
+// Load sets a player's totals, such as those kept from earlier sessions
+func (t *StatsTracker) Load(s PlayerStats) {
+	c := s.clone()
+	t.players[s.PlayerID] = &c
+}
+
+// Record follows a game event, counting the hand once it ends
+func (t *StatsTracker) Record(e Event) {
+	switch e.Type {
+	case EventHandStarted:
+		t.hand = t.hand[:0]
+	case EventHandEnded:
+		stats := HandStats(t.hand)
+		for _, s := range stats {
+			total := t.players[s.PlayerID]
+			if total == nil {
+				total = &PlayerStats{PlayerID: s.PlayerID}
+				t.players[s.PlayerID] = total
+			}
+			total.Add(s)
+		}
+		t.hand = t.hand[:0]
+		if t.OnHand != nil && len(stats) > 0 {
+			t.OnHand(stats)
+		}
+		return
+	}
+	if e.HandNumber > 0 {
+		t.hand = append(t.hand, e)
+	}
+}
+
+// Player returns a copy of a player's totals
+func (t *StatsTracker) Player(id string) PlayerStats {
+	if s := t.players[id]; s != nil {
+		return s.clone()
+	}
+	return PlayerStats{PlayerID: id}
+}
+
+// HUD returns a player's statistics. It is nil before the player has
+// played a hand.
+func (t *StatsTracker) HUD(playerID string) *HUDStats {
+	s := t.players[playerID]
+	if s == nil || s.Hands == 0 {
+		return nil
+	}
+	h := s.HUD()
+	return &h
+}
+
+// Versus returns a player's statistics against an opponent. It is nil
+// before the two have been dealt in together.
+func (t *StatsTracker) Versus(playerID, opponentID string) *VersusStats {
+	s := t.players[playerID]
+	if s == nil || s.Versus[opponentID].Hands == 0 {
+		return nil
+	}
+	v := s.Versus[opponentID].Stats()
+	return &v
+}
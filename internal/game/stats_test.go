@@ -0,0 +1,153 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHUD(t *testing.T) {
+	af := func(f float64) *float64 { return &f }
+	tests := []struct {
+		name   string
+		counts StatCounts
+		want   HUDStats
+	}{
+		{"no hands", StatCounts{}, HUDStats{}},
+		{
+			"percentages",
+			StatCounts{Hands: 8, VPIP: 2, PFR: 1, ThreeBetChance: 4, ThreeBet: 1, SawFlop: 4, Showdowns: 1},
+			HUDStats{Hands: 8, VPIP: 25, PFR: 12.5, ThreeBet: 25, WTSD: 25},
+		},
+		{"aggression", StatCounts{Hands: 1, Aggressive: 3, Calls: 2}, HUDStats{Hands: 1, AF: af(1.5)}},
+		{"passive", StatCounts{Hands: 1, Calls: 2}, HUDStats{Hands: 1, AF: af(0)}},
+		// With no calls to divide by, AF is undefined however often they bet
+		{"never calls", StatCounts{Hands: 1, Aggressive: 3}, HUDStats{Hands: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.counts.HUD(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("HUD() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandStats(t *testing.T) {
+	g := newTestGame(t, 7, "a", "b", "c")
+	tracker := NewStatsTracker()
+	g.Stats = tracker
+	// toAct returns who is to act
+	toAct := func() string { return g.Players[g.CurrentIndex].ID }
+	// play plays an action by whoever is to act and returns who it was
+	play := func(action ActionType, amount int) string {
+		id := toAct()
+		act(t, g, BotAction{Action: action, Amount: amount})
+		return id
+	}
+
+	// The button opens, the small blind 3-bets and the big blind folds.
+	// The button calls, then folds to the small blind's continuation bet.
+	start := len(g.Events)
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	button := play(Raise, 30)
+	sb := play(Raise, 90)
+	bb := play(Fold, 0)
+	play(Call, 0)
+	play(Bet, 100)
+	play(Fold, 0)
+
+	got := make(map[string]PlayerStats)
+	for _, s := range HandStats(g.Events[start:]) {
+		got[s.PlayerID] = s
+	}
+	want := map[string]PlayerStats{
+		button: {
+			PlayerID:   button,
+			StatCounts: StatCounts{Hands: 1, VPIP: 1, PFR: 1, SawFlop: 1},
+			Versus: map[string]VersusCounts{
+				sb: {Hands: 1, CBetFaced: 1, FoldToCBet: 1},
+				bb: {Hands: 1},
+			},
+		},
+		sb: {
+			PlayerID:   sb,
+			StatCounts: StatCounts{Hands: 1, VPIP: 1, PFR: 1, ThreeBetChance: 1, ThreeBet: 1, Aggressive: 1, SawFlop: 1},
+			Versus: map[string]VersusCounts{
+				button: {Hands: 1, ThreeBetChance: 1, ThreeBet: 1},
+				bb:     {Hands: 1},
+			},
+		},
+		bb: {
+			PlayerID:   bb,
+			StatCounts: StatCounts{Hands: 1},
+			Versus: map[string]VersusCounts{
+				button: {Hands: 1},
+				sb:     {Hands: 1},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("first hand:\n got %+v\nwant %+v", got, want)
+	}
+
+	// Everyone limps, the small blind bets the flop and is called twice,
+	// then it is checked down and a's aces win
+	deal, err := ParseScriptedDeal("AsAh KsKh 7c2d | Qd9c5s 3h 8d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.ScriptNextHand(deal); err != nil {
+		t.Fatal(err)
+	}
+	start = len(g.Events)
+	if err := g.StartNewHand(); err != nil {
+		t.Fatal(err)
+	}
+	play(Call, 0)
+	play(Call, 0)
+	checker := play(Check, 0)
+	bettor := play(Bet, 10)
+	play(Call, 0)
+	play(Call, 0)
+	for range 6 {
+		play(Check, 0)
+	}
+
+	for _, s := range HandStats(g.Events[start:]) {
+		want := StatCounts{Hands: 1, VPIP: 1, Calls: 1, SawFlop: 1, Showdowns: 1}
+		switch s.PlayerID {
+		case bettor:
+			want.Aggressive, want.Calls = 1, 0
+		case checker:
+			want.VPIP = 0
+		}
+		if s.StatCounts != want {
+			t.Errorf("second hand, %s: got %+v, want %+v", s.PlayerID, s.StatCounts, want)
+		}
+		for opponent, v := range s.Versus {
+			want := VersusCounts{Hands: 1, Showdowns: 1}
+			if s.PlayerID == "a" {
+				want.ShowdownsWon = 1
+			}
+			if v != want {
+				t.Errorf("second hand, %s against %s: got %+v, want %+v", s.PlayerID, opponent, v, want)
+			}
+		}
+	}
+
+	// The tracker adds the hands up
+	if v := tracker.Versus("a", "b"); v == nil || v.Hands != 2 || v.Showdowns != 1 || v.WonAtShowdown != 100 {
+		t.Errorf("a against b: %+v", v)
+	}
+	if v := tracker.Versus(button, sb); v == nil || v.FoldToCBet != 100 {
+		t.Errorf("%s against %s: %+v", button, sb, v)
+	}
+	if v := tracker.Versus(button, button); v != nil {
+		t.Errorf("%s against themselves: %+v", button, v)
+	}
+	if h := tracker.HUD(button); h == nil || h.Hands != 2 || h.PFR != 50 {
+		t.Errorf("%s: %+v", button, h)
+	}
+}
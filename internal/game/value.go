@@ -0,0 +1,126 @@
+package game
+
+// HandValue is a comparable score for the best five-card hand among five
+// to seven cards: higher values are better hands and equal values tie.
+// It ranks hands exactly like EvaluateBestHand and CompareHands but skips
+// building cards and descriptions, for simulations and bots.
+type HandValue uint32
+
+// Rank returns the hand rank a value was scored as
+func (v HandValue) Rank() HandRank {
+	return HandRank(v >> 20)
+}
+
+// EvaluateHandValue scores the best five-card hand among cards
+func EvaluateHandValue(cards []Card) HandValue {
+	var rankCounts [Ace + 1]int
+	var suitMasks [Spades + 1]uint16
+	var suitCounts [Spades + 1]int
+	var rankMask uint16
+
+	for _, c := range cards {
+		rankCounts[c.Rank]++
+		suitMasks[c.Suit] |= 1 << c.Rank
+		suitCounts[c.Suit]++
+		rankMask |= 1 << c.Rank
+	}
+
+	// Flushes and straight flushes
+	for suit, n := range suitCounts {
+		if n < 5 {
+			continue
+		}
+		if high := straightHigh(suitMasks[suit]); high != 0 {
+			if high == Ace {
+				return makeValue(RoyalFlush, high)
+			}
+			return makeValue(StraightFlush, high)
+		}
+		return makeValue(Flush, topRanks(suitMasks[suit], 5)...)
+	}
+
+	// Group ranks by count, highest first
+	var quads, trips, pairs []Rank
+	for r := Ace; r >= Two; r-- {
+		switch rankCounts[r] {
+		case 4:
+			quads = append(quads, r)
+		case 3:
+			trips = append(trips, r)
+		case 2:
+			pairs = append(pairs, r)
+		}
+	}
+
+	if len(quads) > 0 {
+		return makeValue(FourOfAKind, append([]Rank{quads[0]}, topRanks(rankMask&^(1<<quads[0]), 1)...)...)
+	}
+
+	if len(trips) > 0 && (len(trips) > 1 || len(pairs) > 0) {
+		pair := Rank(0)
+		if len(pairs) > 0 {
+			pair = pairs[0]
+		}
+		if len(trips) > 1 && trips[1] > pair {
+			pair = trips[1]
+		}
+		return makeValue(FullHouse, trips[0], pair)
+	}
+
+	if high := straightHigh(rankMask); high != 0 {
+		return makeValue(Straight, high)
+	}
+
+	if len(trips) > 0 {
+		return makeValue(ThreeOfAKind, append([]Rank{trips[0]}, topRanks(rankMask&^(1<<trips[0]), 2)...)...)
+	}
+
+	if len(pairs) >= 2 {
+		kickers := rankMask &^ (1 << pairs[0]) &^ (1 << pairs[1])
+		return makeValue(TwoPair, pairs[0], pairs[1], topRanks(kickers, 1)[0])
+	}
+
+	if len(pairs) == 1 {
+		return makeValue(OnePair, append([]Rank{pairs[0]}, topRanks(rankMask&^(1<<pairs[0]), 3)...)...)
+	}
+
+	return makeValue(HighCard, topRanks(rankMask, 5)...)
+}
+
+// makeValue packs a hand rank and up to five tie-break ranks
+func makeValue(rank HandRank, ranks ...Rank) HandValue {
+	v := HandValue(rank) << 20
+	for i, r := range ranks {
+		if i >= 5 {
+			break
+		}
+		v |= HandValue(r) << (16 - 4*i)
+	}
+	return v
+}
+
+// straightHigh returns the high card of the best straight in a rank mask,
+// or 0 if there is none
+func straightHigh(mask uint16) Rank {
+	if mask&(1<<Ace) != 0 {
+		mask |= 1 << 1 // Ace plays low in the wheel
+	}
+	for high := Ace; high >= Five; high-- {
+		run := uint16(0x1f) << (high - 4)
+		if mask&run == run {
+			return high
+		}
+	}
+	return 0
+}
+
+// topRanks returns the n highest ranks set in a rank mask
+func topRanks(mask uint16, n int) []Rank {
+	ranks := make([]Rank, 0, n)
+	for r := Ace; r >= Two && len(ranks) < n; r-- {
+		if mask&(1<<r) != 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,237 @@
+package leaderboard
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"poker-room/internal/store"
+)
+
+// Cache is a store that keeps the leaderboard totals up to date as hands
+// and tournaments are saved through it, so building a board does not read
+// every hand. Days, and with them the daily and weekly windows, start at
+// midnight in its location.
+type Cache struct {
+	store.Store
+
+	mu          sync.Mutex // Also orders saves, so the totals follow the store
+	loc         *time.Location
+	days        map[time.Time]map[string]*handTotals // By day and player
+	hands       map[handKey]cachedHand
+	blinds      map[string]int // Each room's big blind
+	tournaments []store.Tournament
+	ratings     map[string]float64
+}
+
+// handTotals are a player's cash game results
+type handTotals struct {
+	hands      int
+	net        int
+	bigBlinds  float64 // Won, over hands with a known big blind
+	blindHands int
+}
+
+// handKey identifies a hand; saving one with the same key replaces it
+type handKey struct {
+	roomID, gameID string
+	handNumber     int
+}
+
+// cachedHand is what a hand added to the totals, to take out again if it
+// is replaced
+type cachedHand struct {
+	day      time.Time
+	bigBlind int // 0 if unknown
+	nets     map[string]int
+}
+
+// NewCache reads a store's hands and tournaments once and returns a cache
+// keeping their totals, with days starting in loc
+func NewCache(s store.Store, loc *time.Location) (*Cache, error) {
+	c := &Cache{
+		Store:  s,
+		loc:    loc,
+		days:   make(map[time.Time]map[string]*handTotals),
+		hands:  make(map[handKey]cachedHand),
+		blinds: make(map[string]int),
+	}
+	hands, err := s.Hands(store.HandQuery{})
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range hands {
+		c.addHand(h)
+	}
+	if err := c.loadTournaments(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SaveHand saves a hand to the store and adds it to the totals
+func (c *Cache) SaveHand(h store.HandRecord) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.Store.SaveHand(h); err != nil {
+		return err
+	}
+	c.addHand(h)
+	return nil
+}
+
+// SaveTournament saves a tournament to the store. Ratings depend on the
+// order of every finish and tournaments are few, so they are all read
+// again and rerated.
+func (c *Cache) SaveTournament(t store.Tournament) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.Store.SaveTournament(t); err != nil {
+		return err
+	}
+	return c.loadTournaments()
+}
+
+// addHand counts a hand, taking out the one it replaces
+func (c *Cache) addHand(h store.HandRecord) {
+	key := handKey{h.RoomID, h.GameID, h.HandNumber}
+	if old, ok := c.hands[key]; ok {
+		c.count(old, -1)
+	}
+
+	bigBlind := handBigBlind(h)
+	if bigBlind == 0 {
+		if _, ok := c.blinds[h.RoomID]; !ok {
+			if room, err := c.Store.Room(h.RoomID); err == nil {
+				c.blinds[h.RoomID] = room.BigBlind
+			}
+		}
+		bigBlind = c.blinds[h.RoomID]
+	}
+	y, m, d := h.PlayedAt.In(c.loc).Date()
+	hand := cachedHand{day: time.Date(y, m, d, 0, 0, 0, 0, c.loc), bigBlind: bigBlind, nets: make(map[string]int)}
+	for _, r := range h.History.Results {
+		hand.nets[r.PlayerID] += r.Net
+	}
+	c.hands[key] = hand
+	c.count(hand, 1)
+}
+
+// count adds a hand to its day's totals, or with sign -1 takes it out
+func (c *Cache) count(h cachedHand, sign int) {
+	day := c.days[h.day]
+	if day == nil {
+		day = make(map[string]*handTotals)
+		c.days[h.day] = day
+	}
+	for id, net := range h.nets {
+		t := day[id]
+		if t == nil {
+			t = &handTotals{}
+			day[id] = t
+		}
+		t.hands += sign
+		t.net += sign * net
+		if h.bigBlind > 0 {
+			t.bigBlinds += float64(sign*net) / float64(h.bigBlind)
+			t.blindHands += sign
+		}
+		if t.hands == 0 {
+			delete(day, id)
+		}
+	}
+}
+
+// loadTournaments reads every tournament and rates them
+func (c *Cache) loadTournaments() error {
+	tournaments, err := c.Store.Tournaments(store.TournamentQuery{})
+	if err != nil {
+		return err
+	}
+	c.tournaments, c.ratings = tournaments, Ratings(tournaments)
+	return nil
+}
+
+// Build ranks the players by their totals
+func (c *Cache) Build(q Query, now time.Time) (*Board, error) {
+	switch q.Metric {
+	case Net, BB100, Hands, ROI, Rating:
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownMetric, q.Metric)
+	}
+	if q.Window == "" {
+		q.Window = AllTime
+	}
+	since, err := q.Window.Since(now.In(c.loc))
+	if err != nil {
+		return nil, err
+	}
+
+	players := make(map[string]*results)
+	player := func(id string) *results {
+		r := players[id]
+		if r == nil {
+			r = &results{Entry: Entry{PlayerID: id, Name: id}}
+			players[id] = r
+		}
+		return r
+	}
+
+	c.mu.Lock()
+	for day, totals := range c.days {
+		if day.Before(since) {
+			continue
+		}
+		for id, t := range totals {
+			r := player(id)
+			r.Hands += t.hands
+			r.Net += t.net
+			r.bigBlinds += t.bigBlinds
+			r.blindHands += t.blindHands
+		}
+	}
+	// Ratings take every finish ever, so a rating is the same on every board
+	for _, t := range c.tournaments {
+		if t.FinishedAt.Before(since) {
+			continue
+		}
+		cost := t.Entry.BuyIn + t.Entry.Fee
+		for _, f := range t.Finishes {
+			r := player(f.PlayerID)
+			r.Tournaments++
+			r.invested += cost * max(f.Entries, 1)
+			r.prizes += f.Prize
+		}
+	}
+	ratings := c.ratings
+	c.mu.Unlock()
+
+	ranked := make([]Entry, 0, len(players))
+	for id, r := range players {
+		if r.blindHands > 0 {
+			r.BB100 = 100 * r.bigBlinds / float64(r.blindHands)
+		}
+		if r.invested > 0 {
+			r.ROI = 100 * float64(r.prizes-r.invested) / float64(r.invested)
+		}
+		if rating, ok := ratings[id]; ok {
+			r.Rating = rating
+		}
+		if p, err := c.Store.Player(id); err == nil && p.Name != "" {
+			r.Name = p.Name
+		}
+
+		count := r.Hands
+		if q.Metric == ROI || q.Metric == Rating {
+			count = r.Tournaments
+		}
+		if count > 0 && count >= q.Min {
+			ranked = append(ranked, r.Entry)
+		}
+	}
+	rank(ranked, q.Metric)
+	if q.Limit > 0 && len(ranked) > q.Limit {
+		ranked = ranked[:q.Limit]
+	}
+	return &Board{Metric: q.Metric, Window: q.Window, Since: since, Entries: ranked}, nil
+}
@@ -0,0 +1,142 @@
+// Package leaderboard ranks players across every room by their cash game
+// and tournament results
+package leaderboard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"poker-room/internal/game"
+	"poker-room/internal/store"
+)
+
+// Metric is what a leaderboard ranks players by
+type Metric string
+
+const (
+	Net    Metric = "net"    // Chips won in cash games
+	BB100  Metric = "bb100"  // Big blinds won per 100 cash game hands
+	Hands  Metric = "hands"  // Cash game hands played
+	ROI    Metric = "roi"    // Tournament return on investment
+	Rating Metric = "rating" // Elo rating from tournament finishes
+)
+
+// Window is the period a leaderboard covers
+type Window string
+
+const (
+	Daily   Window = "day"  // Since midnight
+	Weekly  Window = "week" // Since midnight on Monday
+	AllTime Window = "all"
+)
+
+// Leaderboard errors
+var (
+	ErrUnknownMetric = errors.New("unknown leaderboard metric")
+	ErrUnknownWindow = errors.New("unknown leaderboard window")
+)
+
+// Since returns when the window starts, in now's time zone. All time
+// starts at the zero time.
+func (w Window) Since(now time.Time) (time.Time, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch w {
+	case Daily:
+		return midnight, nil
+	case Weekly:
+		// Weekday counts from Sunday
+		return midnight.AddDate(0, 0, -(int(now.Weekday())+6)%7), nil
+	case AllTime, "":
+		return time.Time{}, nil
+	}
+	return time.Time{}, fmt.Errorf("%w %q", ErrUnknownWindow, w)
+}
+
+// Query selects a leaderboard
+type Query struct {
+	Metric Metric
+	Window Window // All time if empty
+	Min    int    // Fewest hands, or tournaments for ROI and rating, to be ranked
+	Limit  int    // Only the top entries, 0 for all
+}
+
+// Entry is a player's place on a leaderboard, with all their results in
+// the window whatever the board ranks by
+type Entry struct {
+	Rank        int     `json:"rank"` // Players tied share a rank
+	PlayerID    string  `json:"playerId"`
+	Name        string  `json:"name"`
+	Hands       int     `json:"hands"`
+	Net         int     `json:"net"`
+	BB100       float64 `json:"bb100"`
+	Tournaments int     `json:"tournaments"`
+	ROI         float64 `json:"roi"`              // Percent
+	Rating      float64 `json:"rating,omitempty"` // As of now, whatever the window
+}
+
+// Board is a ranked leaderboard
+type Board struct {
+	Metric  Metric    `json:"metric"`
+	Window  Window    `json:"window"`
+	Since   time.Time `json:"since"`
+	Entries []Entry   `json:"entries"`
+}
+
+// results are a player's totals in the window
+type results struct {
+	Entry
+	handTotals
+	invested int // Tournament entries paid
+	prizes   int
+}
+
+// Build ranks the players in a store's hands and tournaments, reading
+// every one of them; a Cache keeps the totals instead
+func Build(s store.Store, q Query, now time.Time) (*Board, error) {
+	c, err := NewCache(s, now.Location())
+	if err != nil {
+		return nil, err
+	}
+	return c.Build(q, now)
+}
+
+// handBigBlind returns the big blind a hand was played at, from its events
+func handBigBlind(h store.HandRecord) int {
+	for _, e := range h.Events {
+		if e.Type == game.EventHandStarted {
+			return e.BigBlind
+		}
+	}
+	return 0
+}
+
+// rank sorts entries best first and numbers them, ties sharing a rank
+func rank(entries []Entry, m Metric) {
+	value := func(e Entry) float64 {
+		switch m {
+		case Net:
+			return float64(e.Net)
+		case BB100:
+			return e.BB100
+		case Hands:
+			return float64(e.Hands)
+		case ROI:
+			return e.ROI
+		}
+		return e.Rating
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if vi, vj := value(entries[i]), value(entries[j]); vi != vj {
+			return vi > vj
+		}
+		return entries[i].PlayerID < entries[j].PlayerID
+	})
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && value(entries[i]) == value(entries[i-1]) {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+}
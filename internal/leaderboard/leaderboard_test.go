@@ -0,0 +1,177 @@
+package leaderboard
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"poker-room/internal/game"
+	"poker-room/internal/store"
+)
+
+// Wednesday afternoon
+var now = time.Date(2026, 1, 7, 15, 0, 0, 0, time.UTC)
+
+// hand is a hand played at a time, with each player's net
+func hand(game_ string, number int, at time.Time, nets map[string]int) store.HandRecord {
+	h := store.HandRecord{RoomID: "room", GameID: game_, HandNumber: number, PlayedAt: at}
+	for id, net := range nets {
+		h.History.Results = append(h.History.Results, game.PlayerResult{PlayerID: id, Net: net})
+	}
+	return h
+}
+
+// fill saves hands today, on Monday and last month at a 10 big blind room
+func fill(t *testing.T, s store.Store) {
+	t.Helper()
+	if err := s.SaveRoom(store.Room{ID: "room", BigBlind: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SavePlayer(store.Player{ID: "a", Name: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+	hands := []store.HandRecord{
+		hand("g1", 1, now.AddDate(0, -1, 0), map[string]int{"a": -300, "b": 300}),
+		hand("g2", 1, now.AddDate(0, 0, -2), map[string]int{"a": 100, "b": -50, "c": -50}),
+		hand("g2", 2, now.Add(-time.Hour), map[string]int{"a": 20, "c": -20}),
+		hand("g2", 3, now.Add(-time.Minute), map[string]int{"b": 20, "c": -20}),
+	}
+	for _, h := range hands {
+		if err := s.SaveHand(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// places lists a board's entries as rank and player
+func places(b *Board) []string {
+	var out []string
+	for _, e := range b.Entries {
+		out = append(out, string(rune('0'+e.Rank))+e.PlayerID)
+	}
+	return out
+}
+
+func TestBuild(t *testing.T) {
+	s := store.NewMemoryStore()
+	fill(t, s)
+	tests := []struct {
+		name string
+		q    Query
+		want []string
+	}{
+		{"net all time", Query{Metric: Net}, []string{"1b", "2c", "3a"}},
+		// a and b both won 20 today; ties share a rank
+		{"net today", Query{Metric: Net, Window: Daily}, []string{"1a", "1b", "3c"}},
+		{"net this week", Query{Metric: Net, Window: Weekly}, []string{"1a", "2b", "3c"}},
+		{"hands this week", Query{Metric: Hands, Window: Weekly}, []string{"1c", "2a", "2b"}},
+		{"bb100", Query{Metric: BB100, Window: Weekly}, []string{"1a", "2b", "3c"}},
+		{"fewest hands", Query{Metric: Net, Window: Weekly, Min: 3}, []string{"1c"}},
+		{"limit", Query{Metric: Net, Limit: 1}, []string{"1b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Build(s, tt.q, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := places(b); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("board = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	b, _ := Build(s, Query{Metric: Net, Window: Weekly}, now)
+	if want := (Entry{Rank: 1, PlayerID: "a", Name: "Ann", Hands: 2, Net: 120, BB100: 600}); b.Entries[0] != want {
+		t.Errorf("a = %+v, want %+v", b.Entries[0], want)
+	}
+	if want := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC); !b.Since.Equal(want) {
+		t.Errorf("week since %s, want Monday", b.Since)
+	}
+
+	if _, err := Build(s, Query{Metric: "luck"}, now); !errors.Is(err, ErrUnknownMetric) {
+		t.Errorf("got %v, want %v", err, ErrUnknownMetric)
+	}
+	if _, err := Build(s, Query{Metric: Net, Window: "year"}, now); !errors.Is(err, ErrUnknownWindow) {
+		t.Errorf("got %v, want %v", err, ErrUnknownWindow)
+	}
+}
+
+// Hands and tournaments saved through a cache give the boards built from
+// the store
+func TestCache(t *testing.T) {
+	s := store.NewMemoryStore()
+	c, err := NewCache(s, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fill(t, c)
+	// Saving a hand again replaces it
+	if err := c.SaveHand(hand("g2", 3, now.Add(-time.Minute), map[string]int{"b": -40, "c": 40})); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SaveTournament(store.Tournament{ID: "t1", FinishedAt: now, Finishes: []store.TournamentFinish{{PlayerID: "c", Place: 1}, {PlayerID: "a", Place: 2}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, metric := range []Metric{Net, BB100, Hands, ROI, Rating} {
+		for _, window := range []Window{Daily, Weekly, AllTime} {
+			q := Query{Metric: metric, Window: window}
+			cached, err := c.Build(q, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			built, err := Build(s, q, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cached, built) {
+				t.Errorf("%s %s: cached %+v, built %+v", metric, window, cached.Entries, built.Entries)
+			}
+		}
+	}
+
+	b, _ := c.Build(Query{Metric: Net, Window: Daily}, now)
+	if got, want := places(b), []string{"1a", "1c", "3b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after replacing a hand, today = %v, want %v", got, want)
+	}
+}
+
+func TestRatings(t *testing.T) {
+	finish := func(ids ...string) store.Tournament {
+		var t store.Tournament
+		for i, id := range ids {
+			t.Finishes = append(t.Finishes, store.TournamentFinish{PlayerID: id, Place: i + 1})
+		}
+		return t
+	}
+	tests := []struct {
+		name        string
+		tournaments []store.Tournament
+		want        map[string]float64
+	}{
+		// Evenly matched, a win moves K/2
+		{"heads-up", []store.Tournament{finish("a", "b")}, map[string]float64{"a": 1516, "b": 1484}},
+		// Each rating moves by the average over the opponents
+		{"three-way", []store.Tournament{finish("a", "b", "c")}, map[string]float64{"a": 1516, "b": 1500, "c": 1484}},
+		// The underdog gains more than the favourite would have
+		{"upset", []store.Tournament{finish("a", "b"), finish("b", "a")}, map[string]float64{"a": 1498.5305, "b": 1501.4695}},
+		{"a lone finisher is not rated", []store.Tournament{finish("a")}, map[string]float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Ratings(tt.tournaments)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ratings = %v, want %v", got, tt.want)
+			}
+			for id, want := range tt.want {
+				if math.Abs(got[id]-want) > 1e-4 {
+					t.Errorf("ratings = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
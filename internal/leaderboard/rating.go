@@ -0,0 +1,56 @@
+package leaderboard
+
+import (
+	"math"
+
+	"poker-room/internal/store"
+)
+
+// Elo rating settings
+const (
+	InitialRating = 1500.0
+	ratingK       = 32.0 // Most a tournament can move a rating
+)
+
+// Ratings rates players by their tournament finishes with Elo. Each finish
+// counts as a win against everyone placed lower and a loss against
+// everyone placed higher, scaled so a tournament moves a rating no more
+// than a single game would. Tournaments are rated in the order given,
+// which should be the order they finished.
+func Ratings(tournaments []store.Tournament) map[string]float64 {
+	ratings := make(map[string]float64)
+	for _, t := range tournaments {
+		n := len(t.Finishes)
+		if n < 2 {
+			continue
+		}
+
+		before := make([]float64, n)
+		for i, f := range t.Finishes {
+			rating, ok := ratings[f.PlayerID]
+			if !ok {
+				rating = InitialRating
+			}
+			before[i] = rating
+		}
+		for i, f := range t.Finishes {
+			change := 0.0
+			for j, o := range t.Finishes {
+				if i == j {
+					continue
+				}
+				score := 0.5
+				switch {
+				case f.Place < o.Place:
+					score = 1
+				case f.Place > o.Place:
+					score = 0
+				}
+				expected := 1 / (1 + math.Pow(10, (before[j]-before[i])/400))
+				change += score - expected
+			}
+			ratings[f.PlayerID] = before[i] + ratingK*change/float64(n-1)
+		}
+	}
+	return ratings
+}
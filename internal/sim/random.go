@@ -0,0 +1,49 @@
+package sim
+
+import "poker-room/internal/game"
+
+// RandomBot acts at random to fuzz the engine. Most of its actions are
+// sensible; the rest are any action with any amount, including action
+// types and amounts the engine must reject.
+type RandomBot struct {
+	RNG game.RNG
+}
+
+// NewRandomBot returns a bot acting with rng
+func NewRandomBot(rng game.RNG) *RandomBot {
+	return &RandomBot{RNG: rng}
+}
+
+// Decide picks an action at random
+func (b *RandomBot) Decide(state *game.GameState, holeCards []game.Card) game.BotAction {
+	chips, bet := 0, 0
+	for _, p := range state.Players {
+		if p.ID == state.CurrentPlayerID {
+			chips, bet = p.Chips, p.CurrentBet
+		}
+	}
+
+	if b.RNG.Intn(5) == 0 {
+		// Anything at all
+		return game.BotAction{
+			Action: game.ActionType(b.RNG.Intn(8) - 1),
+			Amount: b.RNG.Intn(2*chips+3) - chips - 1,
+		}
+	}
+
+	facing := state.CurrentBet > bet
+	switch n := b.RNG.Intn(10); {
+	case n == 0:
+		return game.BotAction{Action: game.AllIn}
+	case n < 3 && facing:
+		return game.BotAction{Action: game.Fold}
+	case n < 7 && facing:
+		return game.BotAction{Action: game.Call}
+	case n < 7:
+		return game.BotAction{Action: game.Check}
+	case state.CurrentBet == 0:
+		return game.BotAction{Action: game.Bet, Amount: state.BigBlind + b.RNG.Intn(max(chips, 1))}
+	}
+	to := state.CurrentBet + max(state.MinRaise, state.BigBlind)
+	return game.BotAction{Action: game.Raise, Amount: to + b.RNG.Intn(max(chips+bet-to, 1))}
+}
@@ -0,0 +1,511 @@
+// Package sim plays bots against each other at full speed to measure their
+// win rates, checking the engine as it goes.
+//
+// Rule bots play about 6,500 hands a second on one CPU. Equity bots spend
+// most of that time simulating deals: with the default bots, one of them
+// an equity bot, it is about 1,300, so a million hands takes 12 minutes
+// or more. Fewer EquityBot.Samples play faster and less well: a quarter of
+// the default about doubles the rate.
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"poker-room/internal/game"
+)
+
+// Seat is a bot to seat. New is called once per table, so a bot that
+// learns does so at its own table only.
+type Seat struct {
+	Name string
+	New  func(rng game.RNG) game.Bot
+}
+
+// Config sets up a simulation. Zero fields take the defaults.
+type Config struct {
+	Seats      []Seat
+	Hands      int   // In total, across tables
+	Seed       int64 // Sessions are seeded Seed, Seed+1, ... in turn across the tables
+	Tables     int   // Played at once, default the number of CPUs
+	SmallBlind int   // Default 1
+	BigBlind   int   // Default 2
+	Stack      int   // Starting stack and rebuy, default 100 big blinds
+
+	// A fresh game starts every SessionHands hands, so event logs stay
+	// short, default 1000
+	SessionHands int
+	// A hand taking more than MaxActions actions is deadlocked, default 500
+	MaxActions int
+	// A table making no progress for StallTimeout is deadlocked and the
+	// run stops, default 10 seconds
+	StallTimeout time.Duration
+	// Failures recorded of each kind, default 10
+	MaxFailures int
+	// Calculate equity at all-ins as real tables do. It is most of the
+	// time a hand takes, so it is off by default.
+	AllInEquity bool
+	// Rake taken from each pot, none by default
+	Rake game.RakeRules
+}
+
+// Failure kinds
+const (
+	Crash     = "crash"     // A bot or the engine panicked
+	Deadlock  = "deadlock"  // A hand never finished
+	ChipError = "chipError" // Chips appeared or vanished
+	Rejected  = "rejected"  // The engine refused even a check or fold
+)
+
+// Failure is a problem found in a hand, with what it takes to reproduce
+// it: the session's seed and the hand's events. A one-table run seeded
+// with Seed plays the session again, bots that learn aside, so the hand
+// is its Hand'th.
+type Failure struct {
+	Kind    string       `json:"kind"`
+	Table   int          `json:"table"`
+	Seed    int64        `json:"seed"` // The session's
+	Hand    int          `json:"hand"` // Counted from 1 in the session
+	Message string       `json:"message"`
+	Stack   string       `json:"stack,omitempty"` // Where it panicked, for crashes
+	Events  []game.Event `json:"events,omitempty"`
+}
+
+// BotResult is a seat's win rate
+type BotResult struct {
+	Name  string  `json:"name"`
+	Hands int     `json:"hands"`
+	NetBB float64 `json:"netBB"`
+	BB100 float64 `json:"bb100"`
+	CI95  float64 `json:"ci95"` // Half-width of the 95% confidence interval on BB100
+}
+
+// Report is the outcome of a simulation
+type Report struct {
+	Hands          int            `json:"hands"`
+	Duration       time.Duration  `json:"duration"`
+	HandsPerSecond float64        `json:"handsPerSecond"`
+	Bots           []BotResult    `json:"bots"`
+	Failures       []Failure      `json:"failures,omitempty"`
+	FailureCounts  map[string]int `json:"failureCounts,omitempty"`
+	Stalled        bool           `json:"stalled,omitempty"` // Stopped early by a deadlock
+}
+
+// Passed reports whether the simulation found no problems
+func (r *Report) Passed() bool {
+	return len(r.FailureCounts) == 0 && !r.Stalled
+}
+
+// String formats the report for a terminal
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d hands in %s (%.0f hands/s)\n", r.Hands, r.Duration.Round(time.Millisecond), r.HandsPerSecond)
+	for _, bot := range r.Bots {
+		fmt.Fprintf(&b, "  %-20s %+9.2f bb/100 ± %.2f  (%d hands)\n", bot.Name, bot.BB100, bot.CI95, bot.Hands)
+	}
+	kinds := make([]string, 0, len(r.FailureCounts))
+	for kind := range r.FailureCounts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		fmt.Fprintf(&b, "  %d %s\n", r.FailureCounts[kind], kind)
+	}
+	for _, f := range r.Failures {
+		fmt.Fprintf(&b, "    %s at table %d (seed %d) hand %d: %s\n", f.Kind, f.Table, f.Seed, f.Hand, f.Message)
+	}
+	if r.Stalled {
+		b.WriteString("  stopped: a table stalled\n")
+	}
+	return b.String()
+}
+
+// ErrSeats is returned for a simulation without two to six bots
+var ErrSeats = errors.New("a simulation needs two to six seats")
+
+// Run plays the simulation
+func Run(cfg Config) (*Report, error) {
+	if len(cfg.Seats) < 2 || len(cfg.Seats) > 6 {
+		return nil, ErrSeats
+	}
+	cfg.defaults()
+
+	start := time.Now()
+	tables := make([]*table, cfg.Tables)
+	var wg sync.WaitGroup
+	for i := range tables {
+		hands := cfg.Hands / cfg.Tables
+		if i < cfg.Hands%cfg.Tables {
+			hands++
+		}
+		tables[i] = newTable(cfg, i, hands)
+		wg.Add(1)
+		go func(t *table) {
+			defer wg.Done()
+			t.run()
+		}(tables[i])
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	stalled := watch(tables, cfg.StallTimeout, done)
+
+	report := &Report{Duration: time.Since(start), Stalled: stalled, FailureCounts: make(map[string]int)}
+	results := make([]welford, len(cfg.Seats))
+	for _, t := range tables {
+		t.mu.Lock()
+		report.Hands += t.played
+		for i := range results {
+			results[i].merge(t.results[i])
+		}
+		report.Failures = append(report.Failures, t.failures...)
+		for kind, n := range t.counts {
+			report.FailureCounts[kind] += n
+		}
+		t.mu.Unlock()
+	}
+	if report.Duration > 0 {
+		report.HandsPerSecond = float64(report.Hands) / report.Duration.Seconds()
+	}
+	for i, s := range cfg.Seats {
+		w := results[i]
+		report.Bots = append(report.Bots, BotResult{
+			Name:  s.Name,
+			Hands: w.n,
+			NetBB: w.sum(),
+			BB100: 100 * w.mean,
+			CI95:  100 * 1.96 * w.stderr(),
+		})
+	}
+	if len(report.FailureCounts) == 0 {
+		report.FailureCounts = nil
+	}
+	return report, nil
+}
+
+func (cfg *Config) defaults() {
+	if cfg.Tables <= 0 {
+		cfg.Tables = runtime.NumCPU()
+	}
+	cfg.Tables = max(min(cfg.Tables, cfg.Hands), 1)
+	if cfg.SmallBlind <= 0 {
+		cfg.SmallBlind = 1
+	}
+	if cfg.BigBlind <= 0 {
+		cfg.BigBlind = 2 * cfg.SmallBlind
+	}
+	if cfg.Stack <= 0 {
+		cfg.Stack = 100 * cfg.BigBlind
+	}
+	if cfg.SessionHands <= 0 {
+		cfg.SessionHands = 1000
+	}
+	if cfg.MaxActions <= 0 {
+		cfg.MaxActions = 500
+	}
+	if cfg.StallTimeout <= 0 {
+		cfg.StallTimeout = 10 * time.Second
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = 10
+	}
+}
+
+// watch waits for the tables to finish. A table making no progress for
+// timeout is reported as deadlocked and the others are stopped; the stuck
+// one cannot be interrupted, so it is left behind. It reports whether any
+// table stalled.
+func watch(tables []*table, timeout time.Duration, done <-chan struct{}) bool {
+	last := make([]int64, len(tables))
+	since := make([]time.Time, len(tables))
+	stuck := make([]bool, len(tables))
+	for i := range since {
+		since[i] = time.Now()
+	}
+	stalled := false
+	tick := time.NewTicker(min(timeout/4, time.Second))
+	defer tick.Stop()
+	for {
+		select {
+		case <-done:
+			return stalled
+		case now := <-tick.C:
+			waiting := false
+			for i, t := range tables {
+				if stuck[i] || t.finished.Load() {
+					continue
+				}
+				if progress := t.progress.Load(); progress != last[i] {
+					last[i], since[i] = progress, now
+				} else if now.Sub(since[i]) >= timeout {
+					t.fail(Deadlock, fmt.Sprintf("no progress for %s", timeout), nil)
+					stuck[i], stalled = true, true
+					for _, other := range tables {
+						other.stop.Store(true)
+					}
+					continue
+				}
+				waiting = true
+			}
+			if stalled && !waiting {
+				return true
+			}
+		}
+	}
+}
+
+// table plays one table's share of the hands
+type table struct {
+	cfg   Config
+	index int
+	hands int
+	bots  map[string]game.Bot
+	rngs  []*sessionRNG // The bots', reseeded each session
+
+	progress atomic.Int64 // Actions taken, for the watchdog
+	stop     atomic.Bool
+	finished atomic.Bool
+
+	mu       sync.Mutex // Guards what the watchdog and Run read
+	seed     int64      // The session's, written by the table only
+	hand     int        // Counted from 1 in the session, likewise
+	total    int        // Hands started at the table, likewise
+	played   int
+	results  []welford
+	failures []Failure
+	counts   map[string]int
+}
+
+func newTable(cfg Config, index, hands int) *table {
+	t := &table{
+		cfg:     cfg,
+		index:   index,
+		hands:   hands,
+		results: make([]welford, len(cfg.Seats)),
+		counts:  make(map[string]int),
+	}
+	return t
+}
+
+// playerID names a seat's player
+func playerID(seat int) string {
+	return fmt.Sprintf("bot%d", seat)
+}
+
+// sessionRNG lets bots made once for a table draw from each session's seed
+type sessionRNG struct {
+	game.RNG
+}
+
+func (t *table) run() {
+	defer t.finished.Store(true)
+	t.bots = make(map[string]game.Bot, len(t.cfg.Seats))
+	for i, s := range t.cfg.Seats {
+		rng := &sessionRNG{}
+		t.rngs = append(t.rngs, rng)
+		t.bots[playerID(i)] = s.New(rng)
+	}
+
+	for session := 0; t.total < t.hands && !t.stop.Load(); session++ {
+		// Each session has its own seed, so a failure can be played again
+		// without the hands before it
+		seed := t.cfg.Seed + int64(session*t.cfg.Tables+t.index)
+		t.mu.Lock()
+		t.seed, t.hand = seed, 0
+		t.mu.Unlock()
+		for i, rng := range t.rngs {
+			rng.RNG = game.NewSeededRNG(seed*1000 + int64(i))
+		}
+
+		g := game.NewPokerGameWithRNG(t.cfg.SmallBlind, t.cfg.BigBlind, game.NewSeededRNG(seed))
+		g.SkipAllInEquity = !t.cfg.AllInEquity
+		g.Rake = t.cfg.Rake
+		g.OnEvent = func(e game.Event) { game.ShowBots(t.bots, e) }
+		for i, s := range t.cfg.Seats {
+			if err := g.AddPlayer(playerID(i), s.Name, t.cfg.Stack); err != nil {
+				t.fail(Crash, err.Error(), nil)
+				return
+			}
+		}
+		// Chips bought in and raked so far this session
+		expected, raked := t.cfg.Stack*len(t.cfg.Seats), 0
+
+		for t.hand < t.cfg.SessionHands && t.total < t.hands && !t.stop.Load() {
+			t.mu.Lock()
+			t.hand++
+			t.total++
+			t.mu.Unlock()
+			first := g.LastEventSeq()
+			ok := t.play(g)
+			if ok {
+				raked += g.HandRake
+				ok = t.check(g, expected, raked, first)
+			}
+			if !ok {
+				// Carry on with a fresh game
+				break
+			}
+			for _, p := range g.Players {
+				if p.Chips == 0 {
+					if err := g.Rebuy(p.ID, t.cfg.Stack); err != nil {
+						t.fail(Crash, "rebuy: "+err.Error(), g.EventsSince(first))
+						return
+					}
+					expected += t.cfg.Stack
+				}
+			}
+		}
+	}
+}
+
+// play plays a hand, recording any crash, deadlock or rejected action
+func (t *table) play(g *game.PokerGame) (ok bool) {
+	first := g.LastEventSeq()
+	defer func() {
+		if r := recover(); r != nil {
+			t.record(Failure{Kind: Crash, Message: fmt.Sprint(r), Stack: string(debug.Stack()), Events: g.EventsSince(first)})
+			ok = false
+		}
+	}()
+
+	if err := g.StartNewHand(); err != nil {
+		t.fail(Crash, "start hand: "+err.Error(), g.EventsSince(first))
+		return false
+	}
+	for actions := 0; !g.HandComplete; actions++ {
+		if actions >= t.cfg.MaxActions {
+			t.fail(Deadlock, fmt.Sprintf("hand unfinished after %d actions", actions), g.EventsSince(first))
+			return false
+		}
+		state := g.GetState()
+		id := state.CurrentPlayerID
+		decision := t.bots[id].Decide(state, g.GetPlayerCards(id))
+		t.progress.Add(1)
+
+		if err := g.ProcessAction(id, decision.Action, decision.Amount); err == nil {
+			continue
+		}
+		// As PlayBots does, a rejected action becomes a check or fold
+		fallback := game.Fold
+		if state.CurrentBet <= currentBet(state, id) {
+			fallback = game.Check
+		}
+		if err := g.ProcessAction(id, fallback, 0); err != nil {
+			t.fail(Rejected, fmt.Sprintf("%s cannot %s: %v", id, fallback, err), g.EventsSince(first))
+			return false
+		}
+	}
+	return true
+}
+
+func currentBet(state *game.GameState, id string) int {
+	for _, p := range state.Players {
+		if p.ID == id {
+			return p.CurrentBet
+		}
+	}
+	return 0
+}
+
+// check makes sure no chips were made or lost in the hand, given the chips
+// bought in and raked in the session so far, and records each seat's result
+func (t *table) check(g *game.PokerGame, expected, raked, first int) bool {
+	chips, net := 0, 0
+	for _, p := range g.Players {
+		if p.Chips < 0 {
+			t.fail(ChipError, fmt.Sprintf("%s has %d chips", p.ID, p.Chips), g.EventsSince(first))
+			return false
+		}
+		chips += p.Chips
+	}
+	if chips+raked != expected {
+		t.fail(ChipError, fmt.Sprintf("%d chips on the table and %d raked, expected %d", chips, raked, expected), g.EventsSince(first))
+		return false
+	}
+
+	history := g.History[len(g.History)-1]
+	results := make(map[string]int, len(history.Results))
+	for _, r := range history.Results {
+		results[r.PlayerID] = r.Net
+		net += r.Net
+	}
+	if net != -history.Rake {
+		t.fail(ChipError, fmt.Sprintf("results sum to %d", net), g.EventsSince(first))
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.played++
+	for i := range t.cfg.Seats {
+		if net, ok := results[playerID(i)]; ok {
+			t.results[i].add(float64(net) / float64(g.BigBlind))
+		}
+	}
+	return true
+}
+
+// fail records a failure, keeping the first few of each kind
+func (t *table) fail(kind, message string, events []game.Event) {
+	t.record(Failure{Kind: kind, Message: message, Events: events})
+}
+
+// record records a failure at the current hand
+func (t *table) record(f Failure) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counts[f.Kind]++
+	if t.counts[f.Kind] > t.cfg.MaxFailures {
+		return
+	}
+	f.Table, f.Seed, f.Hand = t.index, t.seed, t.hand
+	f.Events = append([]game.Event(nil), f.Events...)
+	t.failures = append(t.failures, f)
+}
+
+// welford keeps a running mean and variance
+type welford struct {
+	n    int
+	mean float64
+	m2   float64
+}
+
+func (w *welford) add(x float64) {
+	w.n++
+	d := x - w.mean
+	w.mean += d / float64(w.n)
+	w.m2 += d * (x - w.mean)
+}
+
+// merge combines another set of samples into w
+func (w *welford) merge(o welford) {
+	if o.n == 0 {
+		return
+	}
+	n := w.n + o.n
+	d := o.mean - w.mean
+	w.mean += d * float64(o.n) / float64(n)
+	w.m2 += o.m2 + d*d*float64(w.n)*float64(o.n)/float64(n)
+	w.n = n
+}
+
+func (w welford) sum() float64 { return w.mean * float64(w.n) }
+
+// stderr is the standard error of the mean
+func (w welford) stderr() float64 {
+	if w.n < 2 {
+		return 0
+	}
+	return math.Sqrt(w.m2/float64(w.n-1)) / math.Sqrt(float64(w.n))
+}
@@ -0,0 +1,126 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"poker-room/internal/game"
+)
+
+// ruleSeat seats a rule bot of a style
+func ruleSeat(style game.BotStyle) Seat {
+	return Seat{Name: string(style), New: func(rng game.RNG) game.Bot {
+		bot, _ := game.NewRuleBot(style, rng)
+		return bot
+	}}
+}
+
+var testSeats = []Seat{
+	ruleSeat(game.TightAggressive),
+	ruleSeat(game.Maniac),
+	ruleSeat(game.CallingStation),
+	{Name: "random", New: func(rng game.RNG) game.Bot { return NewRandomBot(rng) }},
+}
+
+func TestRunConservesChips(t *testing.T) {
+	for _, rake := range []game.RakeRules{{}, {Percent: 5, Cap: 6}} {
+		report, err := Run(Config{Seats: testSeats, Hands: 2000, Seed: 1, Tables: 2, SessionHands: 300, Rake: rake})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.Passed() {
+			t.Fatalf("rake %v: failed\n%s", rake, report)
+		}
+		if report.Hands != 2000 {
+			t.Errorf("rake %v: played %d hands, want 2000", rake, report.Hands)
+		}
+		// Every hand's results sum to minus its rake, so the bots' do too
+		net := 0.0
+		for _, bot := range report.Bots {
+			if bot.Hands != report.Hands {
+				t.Errorf("rake %v: %s played %d hands", rake, bot.Name, bot.Hands)
+			}
+			net += bot.NetBB
+		}
+		if rake.Percent == 0 && math.Abs(net) > 1e-6 {
+			t.Errorf("no rake: bots net %.2f bb, want 0", net)
+		}
+		if rake.Percent > 0 && net >= 0 {
+			t.Errorf("rake %v: bots net %.2f bb, want a loss", rake, net)
+		}
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	cfg := Config{Seats: testSeats, Hands: 500, Seed: 9, Tables: 3, SessionHands: 50}
+	first, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := Run(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first.Bots, second.Bots) {
+		t.Errorf("same seed, different results:\n%v\n%v", first.Bots, second.Bots)
+	}
+}
+
+// acesBot panics when dealt aces and otherwise calls
+type acesBot struct{}
+
+func (acesBot) Decide(state *game.GameState, holeCards []game.Card) game.BotAction {
+	if len(holeCards) == 2 && holeCards[0].Rank == game.Ace && holeCards[1].Rank == game.Ace {
+		panic("aces")
+	}
+	return game.BotAction{Action: game.Call}
+}
+
+// plays sums up a failure's hand, leaving out the times
+func plays(f Failure) []string {
+	var out []string
+	for _, e := range f.Events {
+		out = append(out, fmt.Sprintf("%s %s %s %d %v", e.Type, e.PlayerID, e.Action, e.Amount, e.Cards))
+	}
+	return out
+}
+
+func TestFailureReplaysFromSessionSeed(t *testing.T) {
+	seats := []Seat{
+		ruleSeat(game.CallingStation),
+		{Name: "aces", New: func(game.RNG) game.Bot { return acesBot{} }},
+	}
+	report, err := Run(Config{Seats: seats, Hands: 2000, Seed: 40, Tables: 2, SessionHands: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Failures) == 0 {
+		t.Fatal("no failures")
+	}
+	f := report.Failures[len(report.Failures)-1]
+	if f.Kind != Crash || f.Seed == 40 || f.Hand < 1 || f.Hand > 5 {
+		t.Fatalf("failure = %s seed %d hand %d", f.Kind, f.Seed, f.Hand)
+	}
+
+	// A one-table run from the session's seed crashes at the same hand
+	again, err := Run(Config{Seats: seats, Hands: f.Hand, Seed: f.Seed, Tables: 1, SessionHands: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Failures) != 1 {
+		t.Fatalf("replay failures = %+v", again.Failures)
+	}
+	g := again.Failures[0]
+	if g.Seed != f.Seed || g.Hand != f.Hand || !reflect.DeepEqual(plays(g), plays(f)) {
+		t.Errorf("replayed as seed %d hand %d\n%v\nwant seed %d hand %d\n%v", g.Seed, g.Hand, plays(g), f.Seed, f.Hand, plays(f))
+	}
+}
+
+func TestRunRejectsSeats(t *testing.T) {
+	if _, err := Run(Config{Seats: testSeats[:1], Hands: 10}); !errors.Is(err, ErrSeats) {
+		t.Errorf("got %v, want %v", err, ErrSeats)
+	}
+}
@@ -0,0 +1,353 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"poker-room/internal/game"
+)
+
+var (
+	_ Store = (*MemoryStore)(nil)
+	_ Store = (*FileStore)(nil)
+)
+
+// FileStore keeps records in memory and appends every change to a journal
+// in its directory, which is replayed when the store is opened again. Room
+// snapshots are kept in files of their own, since each replaces the last.
+type FileStore struct {
+	mu      sync.Mutex
+	dir     string
+	journal *os.File
+	mem     *MemoryStore
+}
+
+// journalEntry is one line of the journal: a record saved, or a balance
+// or stat totals after an adjustment
+type journalEntry struct {
+	Player     *Player           `json:"player,omitempty"`
+	Room       *Room             `json:"room,omitempty"`
+	Balance    *balanceEntry     `json:"balance,omitempty"`
+	Stats      *game.PlayerStats `json:"stats,omitempty"`
+	Hand       *HandRecord       `json:"hand,omitempty"`
+	Session    *Session          `json:"session,omitempty"`
+	Tournament *Tournament       `json:"tournament,omitempty"`
+}
+
+type balanceEntry struct {
+	PlayerID string `json:"playerId"`
+	Balance  int    `json:"balance"`
+}
+
+const journalName = "journal.jsonl"
+
+// OpenFileStore opens or creates a store in dir, loading its journal. A
+// last line cut short or garbled by a crash is dropped.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "snapshots"), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, journalName), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileStore{dir: dir, journal: f, mem: NewMemoryStore()}
+	good, err := s.load()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Truncate(good); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(good, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load replays the journal and returns the length of its complete lines
+func (s *FileStore) load() (int64, error) {
+	r := bufio.NewReader(s.journal)
+	var offset int64
+	for n := 1; ; n++ {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			// Anything after the last newline is a partial write
+			return offset, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		var e journalEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			if _, peekErr := r.Peek(1); peekErr == io.EOF {
+				// A torn last line, ended by garbage rather than cut short
+				return offset, nil
+			}
+			return 0, fmt.Errorf("journal line %d: %w", n, err)
+		}
+		if err := s.apply(e); err != nil {
+			return 0, fmt.Errorf("journal line %d: %w", n, err)
+		}
+		offset += int64(len(line))
+	}
+}
+
+// apply makes a journal entry's change in memory
+func (s *FileStore) apply(e journalEntry) error {
+	switch {
+	case e.Player != nil:
+		return s.mem.SavePlayer(*e.Player)
+	case e.Room != nil:
+		return s.mem.SaveRoom(*e.Room)
+	case e.Balance != nil:
+		s.mem.mu.Lock()
+		s.mem.balances[e.Balance.PlayerID] = e.Balance.Balance
+		s.mem.mu.Unlock()
+		return nil
+	case e.Stats != nil:
+		s.mem.mu.Lock()
+		s.mem.stats[e.Stats.PlayerID] = *e.Stats
+		s.mem.mu.Unlock()
+		return nil
+	case e.Hand != nil:
+		s.mem.mu.Lock()
+		s.mem.putHand(*e.Hand)
+		s.mem.mu.Unlock()
+		return nil
+	case e.Session != nil:
+		return s.mem.SaveSession(*e.Session)
+	case e.Tournament != nil:
+		return s.mem.SaveTournament(*e.Tournament)
+	}
+	return errors.New("empty journal entry")
+}
+
+// write appends an entry to the journal, syncs it and applies it
+func (s *FileStore) write(e journalEntry) error {
+	if s.journal == nil {
+		return ErrClosed
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := s.journal.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := s.journal.Sync(); err != nil {
+		return err
+	}
+	return s.apply(e)
+}
+
+func (s *FileStore) SavePlayer(p Player) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(journalEntry{Player: &p})
+}
+
+func (s *FileStore) Player(id string) (Player, error) { return s.mem.Player(id) }
+func (s *FileStore) Players() ([]Player, error)       { return s.mem.Players() }
+
+func (s *FileStore) SaveRoom(r Room) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(journalEntry{Room: &r})
+}
+
+func (s *FileStore) Room(id string) (Room, error) { return s.mem.Room(id) }
+func (s *FileStore) Rooms() ([]Room, error)       { return s.mem.Rooms() }
+
+func (s *FileStore) Balance(playerID string) (int, error) { return s.mem.Balance(playerID) }
+
+func (s *FileStore) AdjustBalance(playerID string, delta int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.mu.RLock()
+	balance, err := s.mem.adjusted(playerID, delta)
+	s.mem.mu.RUnlock()
+	if err != nil {
+		return 0, err
+	}
+	if err := s.write(journalEntry{Balance: &balanceEntry{PlayerID: playerID, Balance: balance}}); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+func (s *FileStore) Stats(playerID string) (game.PlayerStats, error) { return s.mem.Stats(playerID) }
+
+func (s *FileStore) AddStats(stats game.PlayerStats) (game.PlayerStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.mu.RLock()
+	total := s.mem.addedStats(stats)
+	s.mem.mu.RUnlock()
+	if err := s.write(journalEntry{Stats: &total}); err != nil {
+		return game.PlayerStats{}, err
+	}
+	return s.mem.Stats(stats.PlayerID)
+}
+
+func (s *FileStore) SaveHand(h HandRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mem.mu.RLock()
+	h.ID = s.mem.handID(h)
+	s.mem.mu.RUnlock()
+	return s.write(journalEntry{Hand: &h})
+}
+
+func (s *FileStore) Hands(q HandQuery) ([]HandRecord, error) { return s.mem.Hands(q) }
+
+func (s *FileStore) SaveSession(session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(journalEntry{Session: &session})
+}
+
+func (s *FileStore) Sessions(q SessionQuery) ([]Session, error) { return s.mem.Sessions(q) }
+
+func (s *FileStore) SaveTournament(t Tournament) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(journalEntry{Tournament: &t})
+}
+
+func (s *FileStore) Tournaments(q TournamentQuery) ([]Tournament, error) {
+	return s.mem.Tournaments(q)
+}
+
+// SaveSnapshot writes the snapshot to a temporary file and renames it over
+// the last, so a crash leaves one or the other intact
+func (s *FileStore) SaveSnapshot(roomID string, snap *game.Snapshot) error {
+	data, err := snap.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.journal == nil {
+		return ErrClosed
+	}
+	return writeFileAtomic(s.snapshotPath(roomID), data)
+}
+
+func (s *FileStore) Snapshot(roomID string) (*game.Snapshot, error) {
+	data, err := os.ReadFile(s.snapshotPath(roomID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("snapshot for room %q: %w", roomID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return game.ParseSnapshot(data)
+}
+
+// snapshotPath hex-encodes the room ID so any ID makes a safe file name
+func (s *FileStore) snapshotPath(roomID string) string {
+	return filepath.Join(s.dir, "snapshots", hex.EncodeToString([]byte(roomID))+".snap")
+}
+
+// Compact rewrites the journal with only the current records, dropping
+// replaced records and old balances
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.journal == nil {
+		return ErrClosed
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	m := s.mem
+	m.mu.RLock()
+	var err error
+	for _, p := range m.players {
+		err = errors.Join(err, enc.Encode(journalEntry{Player: &p}))
+	}
+	for _, r := range m.rooms {
+		err = errors.Join(err, enc.Encode(journalEntry{Room: &r}))
+	}
+	for id, balance := range m.balances {
+		err = errors.Join(err, enc.Encode(journalEntry{Balance: &balanceEntry{PlayerID: id, Balance: balance}}))
+	}
+	for _, stats := range m.stats {
+		err = errors.Join(err, enc.Encode(journalEntry{Stats: &stats}))
+	}
+	for _, h := range m.hands {
+		err = errors.Join(err, enc.Encode(journalEntry{Hand: &h}))
+	}
+	for _, session := range m.sessions {
+		err = errors.Join(err, enc.Encode(journalEntry{Session: &session}))
+	}
+	for _, t := range m.tourneys {
+		err = errors.Join(err, enc.Encode(journalEntry{Tournament: &t}))
+	}
+	m.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, journalName)
+	if err := writeFileAtomic(path, buf.Bytes()); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	s.journal.Close()
+	s.journal = f
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.journal == nil {
+		return nil
+	}
+	err := s.journal.Close()
+	s.journal = nil
+	s.mem.Close()
+	return err
+}
+
+// writeFileAtomic replaces a file by writing a temporary file beside it,
+// syncing it and renaming it into place
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
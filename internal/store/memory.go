@@ -0,0 +1,301 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"poker-room/internal/game"
+)
+
+// MemoryStore keeps everything in memory, for tests and single-process
+// servers that do not need to survive a restart
+type MemoryStore struct {
+	mu        sync.RWMutex
+	players   map[string]Player
+	rooms     map[string]Room
+	balances  map[string]int
+	stats     map[string]game.PlayerStats
+	hands     []HandRecord // In the order saved
+	handIndex map[handKey]int
+	lastHand  int64 // Highest hand ID given out
+	sessions  map[string]Session
+	tourneys  map[string]Tournament
+	snapshots map[string][]byte // Binary encoded, so callers never share memory
+	closed    bool
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		players:   make(map[string]Player),
+		rooms:     make(map[string]Room),
+		balances:  make(map[string]int),
+		stats:     make(map[string]game.PlayerStats),
+		handIndex: make(map[handKey]int),
+		sessions:  make(map[string]Session),
+		tourneys:  make(map[string]Tournament),
+		snapshots: make(map[string][]byte),
+	}
+}
+
+func (m *MemoryStore) SavePlayer(p Player) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.players[p.ID] = p
+	return nil
+}
+
+func (m *MemoryStore) Player(id string) (Player, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.players[id]
+	if !ok {
+		return Player{}, fmt.Errorf("player %q: %w", id, ErrNotFound)
+	}
+	return p, nil
+}
+
+// Players returns every player ordered by ID
+func (m *MemoryStore) Players() ([]Player, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	players := make([]Player, 0, len(m.players))
+	for _, p := range m.players {
+		players = append(players, p)
+	}
+	sort.Slice(players, func(i, j int) bool { return players[i].ID < players[j].ID })
+	return players, nil
+}
+
+func (m *MemoryStore) SaveRoom(r Room) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.rooms[r.ID] = r
+	return nil
+}
+
+func (m *MemoryStore) Room(id string) (Room, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.rooms[id]
+	if !ok {
+		return Room{}, fmt.Errorf("room %q: %w", id, ErrNotFound)
+	}
+	return r, nil
+}
+
+// Rooms returns every room, oldest first
+func (m *MemoryStore) Rooms() ([]Room, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	rooms := make([]Room, 0, len(m.rooms))
+	for _, r := range m.rooms {
+		rooms = append(rooms, r)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		if !rooms[i].CreatedAt.Equal(rooms[j].CreatedAt) {
+			return rooms[i].CreatedAt.Before(rooms[j].CreatedAt)
+		}
+		return rooms[i].ID < rooms[j].ID
+	})
+	return rooms, nil
+}
+
+func (m *MemoryStore) Balance(playerID string) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.balances[playerID], nil
+}
+
+func (m *MemoryStore) AdjustBalance(playerID string, delta int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return 0, ErrClosed
+	}
+	balance, err := m.adjusted(playerID, delta)
+	if err != nil {
+		return 0, err
+	}
+	m.balances[playerID] = balance
+	return balance, nil
+}
+
+// adjusted returns what a balance would be after adding delta
+func (m *MemoryStore) adjusted(playerID string, delta int) (int, error) {
+	balance := m.balances[playerID] + delta
+	if balance < 0 {
+		return 0, fmt.Errorf("%w: %s has %d", ErrInsufficientFunds, playerID, m.balances[playerID])
+	}
+	return balance, nil
+}
+
+func (m *MemoryStore) Stats(playerID string) (game.PlayerStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.addedStats(game.PlayerStats{PlayerID: playerID}), nil
+}
+
+func (m *MemoryStore) AddStats(s game.PlayerStats) (game.PlayerStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return game.PlayerStats{}, ErrClosed
+	}
+	total := m.addedStats(s)
+	m.stats[s.PlayerID] = total
+	return m.addedStats(game.PlayerStats{PlayerID: s.PlayerID}), nil
+}
+
+// addedStats returns a copy of a player's totals with s added
+func (m *MemoryStore) addedStats(s game.PlayerStats) game.PlayerStats {
+	total := game.PlayerStats{PlayerID: s.PlayerID}
+	total.Add(m.stats[s.PlayerID])
+	total.Add(s)
+	return total
+}
+
+func (m *MemoryStore) SaveHand(h HandRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	h.ID = m.handID(h)
+	m.putHand(h)
+	return nil
+}
+
+// handID returns the ID of the hand h replaces, or the next free one
+func (m *MemoryStore) handID(h HandRecord) int64 {
+	if i, ok := m.handIndex[h.key()]; ok {
+		return m.hands[i].ID
+	}
+	return m.lastHand + 1
+}
+
+// putHand stores a hand that already has its ID
+func (m *MemoryStore) putHand(h HandRecord) {
+	m.lastHand = max(m.lastHand, h.ID)
+	if i, ok := m.handIndex[h.key()]; ok {
+		m.hands[i] = h
+		return
+	}
+	m.handIndex[h.key()] = len(m.hands)
+	m.hands = append(m.hands, h)
+}
+
+// Hands returns the matching hands in the order they were saved
+func (m *MemoryStore) Hands(q HandQuery) ([]HandRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var hands []HandRecord
+	for _, h := range m.hands {
+		if q.matches(h) {
+			hands = append(hands, h)
+		}
+	}
+	if q.Limit > 0 && len(hands) > q.Limit {
+		hands = hands[len(hands)-q.Limit:]
+	}
+	return hands, nil
+}
+
+func (m *MemoryStore) SaveSession(s Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.sessions[s.ID] = s
+	return nil
+}
+
+// Sessions returns the matching sessions, earliest first
+func (m *MemoryStore) Sessions(q SessionQuery) ([]Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var sessions []Session
+	for _, s := range m.sessions {
+		if q.matches(s) {
+			sessions = append(sessions, s)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].JoinedAt.Equal(sessions[j].JoinedAt) {
+			return sessions[i].JoinedAt.Before(sessions[j].JoinedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions, nil
+}
+
+func (m *MemoryStore) SaveTournament(t Tournament) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	t.Finishes = append([]TournamentFinish(nil), t.Finishes...)
+	m.tourneys[t.ID] = t
+	return nil
+}
+
+// Tournaments returns the matching tournaments in the order they finished
+func (m *MemoryStore) Tournaments(q TournamentQuery) ([]Tournament, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var tournaments []Tournament
+	for _, t := range m.tourneys {
+		if q.matches(t) {
+			t.Finishes = append([]TournamentFinish(nil), t.Finishes...)
+			tournaments = append(tournaments, t)
+		}
+	}
+	sort.Slice(tournaments, func(i, j int) bool {
+		if !tournaments[i].FinishedAt.Equal(tournaments[j].FinishedAt) {
+			return tournaments[i].FinishedAt.Before(tournaments[j].FinishedAt)
+		}
+		return tournaments[i].ID < tournaments[j].ID
+	})
+	return tournaments, nil
+}
+
+func (m *MemoryStore) SaveSnapshot(roomID string, s *game.Snapshot) error {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrClosed
+	}
+	m.snapshots[roomID] = data
+	return nil
+}
+
+func (m *MemoryStore) Snapshot(roomID string) (*game.Snapshot, error) {
+	m.mu.RLock()
+	data, ok := m.snapshots[roomID]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("snapshot for room %q: %w", roomID, ErrNotFound)
+	}
+	return game.ParseSnapshot(data)
+}
+
+func (m *MemoryStore) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	return nil
+}
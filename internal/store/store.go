@@ -0,0 +1,186 @@
+// Package store persists players, rooms, bankrolls, player stats, hand
+// histories, sessions and tournament results so they survive a server restart
+package store
+
+import (
+	"errors"
+	"time"
+
+	"poker-room/internal/game"
+)
+
+// Store errors
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrClosed            = errors.New("store is closed")
+)
+
+// Store is implemented by each storage backend. Saving a record with an
+// existing ID replaces it. Methods are safe for concurrent use.
+type Store interface {
+	SavePlayer(p Player) error
+	Player(id string) (Player, error)
+	Players() ([]Player, error)
+
+	SaveRoom(r Room) error
+	Room(id string) (Room, error)
+	Rooms() ([]Room, error)
+
+	// Balance returns a player's bankroll, 0 for a new player
+	Balance(playerID string) (int, error)
+	// AdjustBalance adds delta to a bankroll and returns the new balance,
+	// failing with ErrInsufficientFunds rather than going below zero
+	AdjustBalance(playerID string, delta int) (int, error)
+
+	// Stats returns a player's HUD stat totals, empty for a new player
+	Stats(playerID string) (game.PlayerStats, error)
+	// AddStats adds a hand's stats to a player's totals and returns them
+	AddStats(s game.PlayerStats) (game.PlayerStats, error)
+
+	// SaveHand records a hand, replacing any with the same room, game and
+	// hand number. The store numbers each new hand for its ID.
+	SaveHand(h HandRecord) error
+	Hands(q HandQuery) ([]HandRecord, error)
+
+	SaveSession(s Session) error
+	Sessions(q SessionQuery) ([]Session, error)
+
+	SaveTournament(t Tournament) error
+	Tournaments(q TournamentQuery) ([]Tournament, error)
+
+	// SaveSnapshot keeps the latest snapshot of a room's game
+	SaveSnapshot(roomID string, s *game.Snapshot) error
+	Snapshot(roomID string) (*game.Snapshot, error)
+
+	Close() error
+}
+
+// Player is a registered player
+type Player struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Room is a table and its settings
+type Room struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	HostID     string     `json:"hostId"`
+	SmallBlind int        `json:"smallBlind"`
+	BigBlind   int        `json:"bigBlind"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	ClosedAt   *time.Time `json:"closedAt,omitempty"`
+}
+
+// HandRecord is a completed hand with the events that played it
+type HandRecord struct {
+	ID         int64            `json:"id"` // Unique in the store, numbered from 1 as hands are first saved
+	RoomID     string           `json:"roomId"`
+	GameID     string           `json:"gameId"` // Tells the room's games apart, as each numbers its hands from 1
+	HandNumber int              `json:"handNumber"`
+	PlayedAt   time.Time        `json:"playedAt"`
+	History    game.HandHistory `json:"history"`
+	Events     []game.Event     `json:"events"` // From game.HandLog, so they stand alone
+}
+
+// handKey identifies a hand for SaveHand to replace
+type handKey struct {
+	roomID, gameID string
+	handNumber     int
+}
+
+func (h HandRecord) key() handKey {
+	return handKey{h.RoomID, h.GameID, h.HandNumber}
+}
+
+// HandQuery selects hand records. Empty fields match everything.
+type HandQuery struct {
+	RoomID   string
+	PlayerID string    // Hands the player was dealt into
+	Since    time.Time // Played at or after
+	Limit    int       // Only the most recent, 0 for all
+}
+
+// Session is a player's stay at a room, from sitting down to leaving
+type Session struct {
+	ID       string     `json:"id"`
+	PlayerID string     `json:"playerId"`
+	RoomID   string     `json:"roomId"`
+	JoinedAt time.Time  `json:"joinedAt"`
+	LeftAt   *time.Time `json:"leftAt,omitempty"`
+	BuyIn    int        `json:"buyIn"`   // Total chips bought, rebuys included
+	CashOut  int        `json:"cashOut"` // Chips taken away on leaving
+}
+
+// SessionQuery selects sessions. Empty fields match everything.
+type SessionQuery struct {
+	RoomID   string
+	PlayerID string
+	OpenOnly bool // Only sessions without LeftAt
+}
+
+// Tournament is a finished tournament and where each entrant placed
+type Tournament struct {
+	ID         string             `json:"id"`
+	Name       string             `json:"name"`
+	Entry      game.EntryFee      `json:"entry"`
+	StartedAt  time.Time          `json:"startedAt"`
+	FinishedAt time.Time          `json:"finishedAt"`
+	Finishes   []TournamentFinish `json:"finishes"`
+}
+
+// TournamentFinish is an entrant's result. Place 1 is the winner.
+type TournamentFinish struct {
+	PlayerID string `json:"playerId"`
+	Place    int    `json:"place"`
+	Entries  int    `json:"entries"` // Paid entries, rebuys and re-entries included
+	Prize    int    `json:"prize"`
+}
+
+// TournamentQuery selects tournaments. Empty fields match everything.
+type TournamentQuery struct {
+	PlayerID string    // Tournaments the player entered
+	Since    time.Time // Finished at or after
+}
+
+// matches reports whether a hand fits the query, apart from its limit
+func (q HandQuery) matches(h HandRecord) bool {
+	if q.RoomID != "" && h.RoomID != q.RoomID {
+		return false
+	}
+	if !q.Since.IsZero() && h.PlayedAt.Before(q.Since) {
+		return false
+	}
+	if q.PlayerID == "" {
+		return true
+	}
+	for _, r := range h.History.Results {
+		if r.PlayerID == q.PlayerID {
+			return true
+		}
+	}
+	return false
+}
+
+func (q SessionQuery) matches(s Session) bool {
+	return (q.RoomID == "" || s.RoomID == q.RoomID) &&
+		(q.PlayerID == "" || s.PlayerID == q.PlayerID) &&
+		(!q.OpenOnly || s.LeftAt == nil)
+}
+
+func (q TournamentQuery) matches(t Tournament) bool {
+	if !q.Since.IsZero() && t.FinishedAt.Before(q.Since) {
+		return false
+	}
+	if q.PlayerID == "" {
+		return true
+	}
+	for _, f := range t.Finishes {
+		if f.PlayerID == q.PlayerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"poker-room/internal/game"
+)
+
+var start = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func hand(gameID string, number int, players ...string) HandRecord {
+	h := HandRecord{
+		RoomID:     "room",
+		GameID:     gameID,
+		HandNumber: number,
+		PlayedAt:   start.Add(time.Duration(number) * time.Minute),
+		History:    game.HandHistory{HandNumber: number},
+	}
+	for _, id := range players {
+		h.History.Results = append(h.History.Results, game.PlayerResult{PlayerID: id})
+	}
+	return h
+}
+
+// fill saves one of each record, and hands from two games in the same room
+func fill(t *testing.T, s Store) {
+	t.Helper()
+	steps := []func() error{
+		func() error { return s.SavePlayer(Player{ID: "a", Name: "Ann", CreatedAt: start}) },
+		func() error {
+			return s.SaveRoom(Room{ID: "room", Name: "Room", HostID: "a", SmallBlind: 1, BigBlind: 2, CreatedAt: start})
+		},
+		func() error { _, err := s.AdjustBalance("a", 500); return err },
+		func() error {
+			_, err := s.AddStats(game.PlayerStats{PlayerID: "a", StatCounts: game.StatCounts{Hands: 3}})
+			return err
+		},
+		func() error { return s.SaveHand(hand("g1", 1, "a", "b")) },
+		func() error { return s.SaveHand(hand("g1", 2, "a", "b")) },
+		// A new game numbers its hands from 1 again
+		func() error { return s.SaveHand(hand("g2", 1, "b", "c")) },
+		func() error {
+			return s.SaveSession(Session{ID: "s1", PlayerID: "a", RoomID: "room", JoinedAt: start, BuyIn: 200})
+		},
+		func() error {
+			return s.SaveTournament(Tournament{ID: "t1", FinishedAt: start, Finishes: []TournamentFinish{{PlayerID: "a", Place: 1}}})
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkFilled checks a store holds what fill saved
+func checkFilled(t *testing.T, s Store) {
+	t.Helper()
+	if p, err := s.Player("a"); err != nil || p.Name != "Ann" {
+		t.Errorf("player = %+v, %v", p, err)
+	}
+	if r, err := s.Room("room"); err != nil || r.HostID != "a" {
+		t.Errorf("room = %+v, %v", r, err)
+	}
+	if b, err := s.Balance("a"); err != nil || b != 500 {
+		t.Errorf("balance = %d, %v", b, err)
+	}
+	if st, err := s.Stats("a"); err != nil || st.Hands != 3 {
+		t.Errorf("stats = %+v, %v", st, err)
+	}
+
+	hands, err := s.Hands(HandQuery{RoomID: "room"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []HandRecord
+	for _, h := range hands {
+		got = append(got, HandRecord{ID: h.ID, GameID: h.GameID, HandNumber: h.HandNumber})
+	}
+	want := []HandRecord{{ID: 1, GameID: "g1", HandNumber: 1}, {ID: 2, GameID: "g1", HandNumber: 2}, {ID: 3, GameID: "g2", HandNumber: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hands = %+v, want %+v", got, want)
+	}
+	if hands, _ := s.Hands(HandQuery{PlayerID: "c"}); len(hands) != 1 || hands[0].GameID != "g2" {
+		t.Errorf("hands for c = %+v", hands)
+	}
+
+	if sessions, err := s.Sessions(SessionQuery{PlayerID: "a"}); err != nil || len(sessions) != 1 || sessions[0].BuyIn != 200 {
+		t.Errorf("sessions = %+v, %v", sessions, err)
+	}
+	if tournaments, err := s.Tournaments(TournamentQuery{PlayerID: "a"}); err != nil || len(tournaments) != 1 {
+		t.Errorf("tournaments = %+v, %v", tournaments, err)
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(t *testing.T) Store
+	}{
+		{"memory", func(t *testing.T) Store { return NewMemoryStore() }},
+		{"file", func(t *testing.T) Store {
+			s, err := OpenFileStore(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			return s
+		}},
+	}
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			s := b.open(t)
+			defer s.Close()
+			fill(t, s)
+			checkFilled(t, s)
+
+			// Saving a hand again replaces it and keeps its ID
+			replaced := hand("g1", 2, "a", "b")
+			replaced.History.Rake = 3
+			if err := s.SaveHand(replaced); err != nil {
+				t.Fatal(err)
+			}
+			hands, _ := s.Hands(HandQuery{})
+			if len(hands) != 3 || hands[1].ID != 2 || hands[1].History.Rake != 3 {
+				t.Errorf("after replacing, hands = %+v", hands)
+			}
+
+			if _, err := s.AdjustBalance("a", -501); !errors.Is(err, ErrInsufficientFunds) {
+				t.Errorf("overdraw: got %v, want %v", err, ErrInsufficientFunds)
+			}
+			if err := s.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.SavePlayer(Player{ID: "b"}); !errors.Is(err, ErrClosed) {
+				t.Errorf("save after close: got %v, want %v", err, ErrClosed)
+			}
+		})
+	}
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fill(t, s)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, compact := range []bool{false, true} {
+		s, err = OpenFileStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkFilled(t, s)
+		if compact {
+			if err := s.Compact(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// IDs carry on from the journal
+	s, err = OpenFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	checkFilled(t, s)
+	if err := s.SaveHand(hand("g2", 2, "b", "c")); err != nil {
+		t.Fatal(err)
+	}
+	if hands, _ := s.Hands(HandQuery{Limit: 1}); len(hands) != 1 || hands[0].ID != 4 {
+		t.Errorf("new hand = %+v, want ID 4", hands)
+	}
+}
+
+func TestFileStoreTornLastLine(t *testing.T) {
+	tests := []struct {
+		name string
+		tail string
+		ok   bool
+	}{
+		{"cut short", `{"player":{"id":"z","na`, true},
+		{"garbled", "\x00\x00\x00\x00\n", true},
+		{"garbled mid-journal", "\x00\x00\n" + `{"player":{"id":"z"}}` + "\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := OpenFileStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fill(t, s)
+			s.Close()
+
+			path := filepath.Join(dir, journalName)
+			good, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, append(append([]byte{}, good...), tt.tail...), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			s, err = OpenFileStore(dir)
+			if !tt.ok {
+				if err == nil {
+					s.Close()
+					t.Fatal("opened a journal corrupted before its end")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkFilled(t, s)
+			if err := s.SavePlayer(Player{ID: "b", Name: "Bea"}); err != nil {
+				t.Fatal(err)
+			}
+			s.Close()
+
+			// The torn line was cut off, so the new record follows the good ones
+			s, err = OpenFileStore(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Close()
+			checkFilled(t, s)
+			if p, err := s.Player("b"); err != nil || p.Name != "Bea" {
+				t.Errorf("player written after the torn line = %+v, %v", p, err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ let gameState = {
     playerId: null,
     playerName: null,
     isHost: false,
+    bots: [],
     currentGameState: null,
     myCards: []
 };
@@ -34,6 +35,13 @@ const actionPanel = document.getElementById('action-panel');
 const hostControls = document.getElementById('host-controls');
 const startGameBtn = document.getElementById('start-game-btn');
 
+// Bot controls, for the host
+const botStyleSelect = document.getElementById('bot-style');
+const botNameInput = document.getElementById('bot-name');
+const botChipsInput = document.getElementById('bot-chips');
+const addBotBtn = document.getElementById('add-bot-btn');
+const botList = document.getElementById('bot-list');
+
 // Chat elements
 const chatMessages = document.getElementById('chat-messages');
 const chatInput = document.getElementById('chat-input');
@@ -78,6 +86,7 @@ function setupEventListeners() {
     // Game room
     leaveRoomBtn.addEventListener('click', leaveRoom);
     startGameBtn.addEventListener('click', startGame);
+    addBotBtn.addEventListener('click', addBot);
     
     // Chat
     sendChatBtn.addEventListener('click', sendChat);
@@ -187,6 +196,7 @@ function handleJoinedRoom(data) {
     if (data.room.hostId === gameState.playerId) {
         gameState.isHost = true;
         hostControls.style.display = 'block';
+        loadBotStyles();
     }
     
     addGameLogEntry(`You joined room ${data.room.code}`);
@@ -298,6 +308,9 @@ function leaveRoom() {
         gameState.roomId = null;
         gameState.playerId = null;
         gameState.isHost = false;
+        gameState.bots = [];
+        renderBotList();
+        hostControls.style.display = 'none';
         ws.close();
         
         showLandingPage();
@@ -311,6 +324,96 @@ function startGame() {
     }));
 }
 
+// Bots
+async function loadBotStyles() {
+    try {
+        const response = await fetch('/api/bots');
+        const styles = await response.json();
+        if (!response.ok) {
+            throw new Error(styles.error || 'Failed to load bot styles');
+        }
+
+        botStyleSelect.innerHTML = '';
+        styles.forEach(s => {
+            const option = document.createElement('option');
+            option.value = s.style;
+            option.textContent = s.name;
+            botStyleSelect.appendChild(option);
+        });
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+// Identifies the host to the bot API, with the ID given on joining
+function hostHeaders() {
+    return { 'X-Player-ID': gameState.playerId };
+}
+
+async function addBot() {
+    const chips = parseInt(botChipsInput.value);
+    if (!chips || chips <= 0) {
+        showError('Please enter the bot\'s chips');
+        return;
+    }
+
+    try {
+        const response = await fetch(`/api/rooms/${encodeURIComponent(gameState.roomId)}/bots`, {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json', ...hostHeaders() },
+            body: JSON.stringify({
+                style: botStyleSelect.value,
+                name: botNameInput.value.trim(),
+                chips: chips
+            })
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            throw new Error(data.error || 'Failed to add bot');
+        }
+
+        gameState.bots.push({ id: data.playerId, name: data.name });
+        botNameInput.value = '';
+        renderBotList();
+        addGameLogEntry(`${data.name} (bot) joined the room`);
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+async function removeBot(playerId) {
+    try {
+        const response = await fetch(`/api/rooms/${encodeURIComponent(gameState.roomId)}/bots/${encodeURIComponent(playerId)}`, {
+            method: 'DELETE',
+            headers: hostHeaders()
+        });
+        if (!response.ok) {
+            const data = await response.json();
+            throw new Error(data.error || 'Failed to remove bot');
+        }
+
+        gameState.bots = gameState.bots.filter(b => b.id !== playerId);
+        renderBotList();
+    } catch (error) {
+        showError(error.message);
+    }
+}
+
+function renderBotList() {
+    botList.innerHTML = '';
+    gameState.bots.forEach(bot => {
+        const item = document.createElement('li');
+        const name = document.createElement('span');
+        name.textContent = bot.name;
+        const removeBtn = document.createElement('button');
+        removeBtn.className = 'btn btn-danger btn-small';
+        removeBtn.textContent = 'Remove';
+        removeBtn.addEventListener('click', () => removeBot(bot.id));
+        item.append(name, removeBtn);
+        botList.appendChild(item);
+    });
+}
+
 // Game Actions
 function sendAction(action, amount = 0) {
     ws.send(JSON.stringify({